@@ -2,6 +2,8 @@
 package ne
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 )
@@ -144,6 +146,61 @@ type Client interface {
 	DeleteDeviceLinkGroup(uuid string) error
 }
 
+// ClientWithContext interface describes operations provided by Network Edge client library
+// that accept per-call context. Context is used for request deadlines, cancellation and
+// request scoped values
+type ClientWithContext interface {
+	Client
+
+	GetAccountsWithContext(ctx context.Context, metroCode string) ([]Account, error)
+	GetDeviceTypesWithContext(ctx context.Context) ([]DeviceType, error)
+	GetDevicePlatformsWithContext(ctx context.Context, deviceTypeCode string) ([]DevicePlatform, error)
+	GetDeviceSoftwareVersionsWithContext(ctx context.Context, deviceTypeCode string) ([]DeviceSoftwareVersion, error)
+
+	CreateDeviceWithContext(ctx context.Context, device Device) (*string, error)
+	CreateRedundantDeviceWithContext(ctx context.Context, primary Device, secondary Device) (*string, *string, error)
+	AddSecondaryWithContext(ctx context.Context, primaryUuid string, secondary Device) (*string, error)
+	GetDeviceWithContext(ctx context.Context, uuid string) (*Device, error)
+	GetDevicesWithContext(ctx context.Context, statuses []string) ([]Device, error)
+	GetDeviceAdditionalBandwidthDetailsWithContext(ctx context.Context, uuid string) (*DeviceAdditionalBandwidthDetails, error)
+	GetDeviceACLDetailsWithContext(ctx context.Context, uuid string) (*DeviceACLDetails, error)
+	NewDeviceUpdateRequestWithContext(uuid string) DeviceUpdateRequestWithContext
+	DeleteDeviceWithContext(ctx context.Context, uuid string) error
+	DeleteSecondaryDeviceWithContext(ctx context.Context, uuid string) error
+
+	CreateSSHUserWithContext(ctx context.Context, username string, password string, device string) (*string, error)
+	GetSSHUsersWithContext(ctx context.Context) ([]SSHUser, error)
+	GetSSHUserWithContext(ctx context.Context, uuid string) (*SSHUser, error)
+	NewSSHUserUpdateRequestWithContext(uuid string) SSHUserUpdateRequestWithContext
+	DeleteSSHUserWithContext(ctx context.Context, uuid string) error
+
+	CreateBGPConfigurationWithContext(ctx context.Context, config BGPConfiguration) (*string, error)
+	GetBGPConfigurationWithContext(ctx context.Context, uuid string) (*BGPConfiguration, error)
+	NewBGPConfigurationUpdateRequestWithContext(uuid string) BGPUpdateRequestWithContext
+	GetBGPConfigurationForConnectionWithContext(ctx context.Context, uuid string) (*BGPConfiguration, error)
+
+	GetSSHPublicKeysWithContext(ctx context.Context) ([]SSHPublicKey, error)
+	GetSSHPublicKeyWithContext(ctx context.Context, uuid string) (*SSHPublicKey, error)
+	CreateSSHPublicKeyWithContext(ctx context.Context, key SSHPublicKey) (*string, error)
+	DeleteSSHPublicKeyWithContext(ctx context.Context, uuid string) error
+
+	CreateACLTemplateWithContext(ctx context.Context, template ACLTemplate) (*string, error)
+	GetACLTemplatesWithContext(ctx context.Context) ([]ACLTemplate, error)
+	GetACLTemplateWithContext(ctx context.Context, uuid string) (*ACLTemplate, error)
+	ReplaceACLTemplateWithContext(ctx context.Context, uuid string, template ACLTemplate) error
+	DeleteACLTemplateWithContext(ctx context.Context, uuid string) error
+
+	UploadLicenseFileWithContext(ctx context.Context, metroCode, deviceTypeCode, deviceManagementMode, licenseMode, fileName string, reader io.Reader) (*string, error)
+	UploadFileWithContext(ctx context.Context, metroCode, deviceTypeCode, processType, deviceManagementMode, licenseMode, fileName string, reader io.Reader) (*string, error)
+	GetFileWithContext(ctx context.Context, uuid string) (*File, error)
+
+	GetDeviceLinkGroupsWithContext(ctx context.Context) ([]DeviceLinkGroup, error)
+	GetDeviceLinkGroupWithContext(ctx context.Context, uuid string) (*DeviceLinkGroup, error)
+	CreateDeviceLinkGroupWithContext(ctx context.Context, linkGroup DeviceLinkGroup) (*string, error)
+	NewDeviceLinkGroupUpdateRequestWithContext(uuid string) DeviceLinkUpdateRequestWithContext
+	DeleteDeviceLinkGroupWithContext(ctx context.Context, uuid string) error
+}
+
 // DeviceUpdateRequest describes composite request to update given Network Edge device
 type DeviceUpdateRequest interface {
 	WithDeviceName(deviceName string) DeviceUpdateRequest
@@ -157,6 +214,15 @@ type DeviceUpdateRequest interface {
 	Execute() error
 }
 
+// DeviceUpdateRequestWithContext describes composite request to update given Network Edge
+// device that can be executed with per-call context
+type DeviceUpdateRequestWithContext interface {
+	DeviceUpdateRequest
+	ExecuteWithContext(ctx context.Context) error
+	ExecuteWithResult(ctx context.Context, opts *UpdateOptions) (*UpdateResult, error)
+	ExecuteWithRollback(ctx context.Context) (*DeviceUpdateReport, error)
+}
+
 // SSHUserUpdateRequest describes composite request to update given Network Edge SSH user
 type SSHUserUpdateRequest interface {
 	WithNewPassword(password string) SSHUserUpdateRequest
@@ -164,6 +230,14 @@ type SSHUserUpdateRequest interface {
 	Execute() error
 }
 
+// SSHUserUpdateRequestWithContext describes composite request to update given Network Edge
+// SSH user that can be executed with per-call context
+type SSHUserUpdateRequestWithContext interface {
+	SSHUserUpdateRequest
+	ExecuteWithContext(ctx context.Context) error
+	ExecuteWithResult(ctx context.Context, opts *UpdateOptions) (*UpdateResult, error)
+}
+
 // BGPUpdateRequest describes request to update given BGP configuration
 type BGPUpdateRequest interface {
 	WithLocalIPAddress(localIPAddress string) BGPUpdateRequest
@@ -174,6 +248,13 @@ type BGPUpdateRequest interface {
 	Execute() error
 }
 
+// BGPUpdateRequestWithContext describes request to update given BGP configuration
+// that can be executed with per-call context
+type BGPUpdateRequestWithContext interface {
+	BGPUpdateRequest
+	ExecuteWithContext(ctx context.Context) error
+}
+
 // DeviceLinkUpdateRequest descrobes request to update given Device Link Group
 type DeviceLinkUpdateRequest interface {
 	WithGroupName(name string) DeviceLinkUpdateRequest
@@ -185,12 +266,24 @@ type DeviceLinkUpdateRequest interface {
 	Execute() error
 }
 
+// DeviceLinkUpdateRequestWithContext describes request to update given Device Link Group
+// that can be executed with per-call context
+type DeviceLinkUpdateRequestWithContext interface {
+	DeviceLinkUpdateRequest
+	ExecuteWithContext(ctx context.Context) error
+	ExecuteWithResult(ctx context.Context, opts *UpdateOptions) (*UpdateResult, error)
+}
+
 // Error describes Network Edge error that occurs during API call processing
 type Error struct {
 	//ErrorCode is short error identifier
 	ErrorCode string
 	//ErrorMessage is textual description of an error
 	ErrorMessage string
+	//Property is a path of request property that is related to an error
+	Property string
+	//MoreInfo provides additional information about an error
+	MoreInfo string
 }
 
 // ChangeError describes single error that occurred during update of selected target property
@@ -202,7 +295,12 @@ type ChangeError struct {
 }
 
 func (e ChangeError) Error() string {
-	return fmt.Sprintf("change type '%s', target '%s', value '%s', cause: '%s'", e.Type, e.Target, e.Value, e.Cause)
+	return fmt.Sprintf("change type '%s', target '%s', value '%s', cause: '%s'", e.Type, e.Target, redactedChangeValue(e.Target, e.Value), e.Cause)
+}
+
+// Unwrap returns error that caused change to fail
+func (e ChangeError) Unwrap() error {
+	return e.Cause
 }
 
 // UpdateError describes error that occurred during composite update request and consists of multiple atomic change errors
@@ -232,6 +330,41 @@ func (e UpdateError) Error() string {
 	return str
 }
 
+// Unwrap returns cause of a failed change, when exactly one change failed.
+// Use errors.Is and errors.As to match causes of many failed changes
+func (e UpdateError) Unwrap() error {
+	if len(e.Failed) != 1 {
+		return nil
+	}
+	return e.Failed[0].Cause
+}
+
+// Is verifies if all failed changes match given target error. Update error where
+// only some of the changes, i.e. one of many device associations, failed with
+// ErrNotFound does not match ErrNotFound, as remaining failures may need attention
+func (e UpdateError) Is(target error) bool {
+	if len(e.Failed) == 0 {
+		return false
+	}
+	for i := range e.Failed {
+		if !errors.Is(e.Failed[i], target) {
+			return false
+		}
+	}
+	return true
+}
+
+// As finds first failed change that matches given target and sets target to that error.
+// Unlike Is, it does not require all failed changes to match
+func (e UpdateError) As(target interface{}) bool {
+	for i := range e.Failed {
+		if errors.As(e.Failed[i], target) {
+			return true
+		}
+	}
+	return false
+}
+
 // Account describes Network Edge customer account details
 type Account struct {
 	Name      *string
@@ -319,6 +452,10 @@ type DeviceType struct {
 	Vendor      *string
 	Category    *string
 	MetroCodes  []string
+	//IsClusteringSupported indicates if devices of this type can be clustered
+	IsClusteringSupported *bool
+	//MaxClusterNodes is a maximum number of nodes in a cluster
+	MaxClusterNodes *int
 }
 
 // DevicePlatform describes Network Edge platform configurations
@@ -0,0 +1,176 @@
+package ne
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/equinix/rest-go"
+	"github.com/go-resty/resty/v2"
+)
+
+var (
+	//ErrNotFound indicates that requested resource does not exist
+	ErrNotFound = errors.New("resource not found")
+	//ErrConflict indicates that request conflicts with current state of a resource
+	ErrConflict = errors.New("resource conflict")
+	//ErrDeviceRemoved indicates that device is deprovisioning or already deprovisioned
+	ErrDeviceRemoved = errors.New("device removed")
+	//ErrValidation indicates that request was rejected due to invalid input
+	ErrValidation = errors.New("validation failed")
+	//ErrRateLimited indicates that request was rejected due to rate limiting
+	ErrRateLimited = errors.New("rate limited")
+)
+
+//requestIDHeaders are response headers that may carry API request identifier
+var requestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id", "X-Transaction-Id"}
+
+// APIError describes error response returned by Network Edge API, or transport
+// error that prevented request from completing. APIError matches sentinel errors,
+// like ErrNotFound, with errors.Is and can be set to rest.Error with errors.As
+// for compatibility. Transport errors, i.e. context.DeadlineExceeded, are
+// reachable with errors.Is and errors.As
+type APIError struct {
+	//HTTPCode is HTTP status code
+	HTTPCode int
+	//Message is textual, general description of an error
+	Message string
+	//RequestID is an identifier of a request that failed, if returned by API
+	RequestID string
+	//Errors is list of application errors
+	Errors  []Error
+	restErr rest.Error
+	//cause is a transport error, if request did not complete
+	cause error
+}
+
+func (e APIError) Error() string {
+	if e.RequestID == "" {
+		return e.restErr.Error()
+	}
+	return fmt.Sprintf("%s, RequestID: %q", e.restErr.Error(), e.RequestID)
+}
+
+// Unwrap returns transport error that prevented request from completing
+// or underlying rest.Error otherwise
+func (e APIError) Unwrap() error {
+	if e.cause != nil {
+		return e.cause
+	}
+	return e.restErr
+}
+
+// As sets rest.Error target to underlying rest.Error
+func (e APIError) As(target interface{}) bool {
+	restErr, ok := target.(*rest.Error)
+	if !ok {
+		return false
+	}
+	*restErr = e.restErr
+	return true
+}
+
+// Is verifies if API error matches given sentinel error
+func (e APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.HTTPCode == http.StatusNotFound
+	case ErrConflict:
+		return e.HTTPCode == http.StatusConflict
+	case ErrDeviceRemoved:
+		return e.HasErrorCode(ErrorCodeDeviceRemoved)
+	case ErrValidation:
+		return e.HTTPCode == http.StatusBadRequest || e.HTTPCode == http.StatusUnprocessableEntity
+	case ErrRateLimited:
+		return e.HTTPCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// HasErrorCode verifies if any of application errors has given error code
+func (e APIError) HasErrorCode(code string) bool {
+	for i := range e.Errors {
+		if e.Errors[i].ErrorCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNotFound verifies if given error, or any error it wraps, indicates that
+// requested resource does not exist
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsConflict verifies if given error, or any error it wraps, indicates that
+// request conflicts with current state of a resource
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsDeviceRemoved verifies if given error, or any error it wraps, indicates that
+// device is deprovisioning or already deprovisioned
+func IsDeviceRemoved(err error) bool {
+	return errors.Is(err, ErrDeviceRemoved)
+}
+
+// IsValidationError verifies if given error, or any error it wraps, indicates that
+// request was rejected due to invalid input
+func IsValidationError(err error) bool {
+	return errors.Is(err, ErrValidation)
+}
+
+// IsRateLimited verifies if given error, or any error it wraps, indicates that
+// request was rejected due to rate limiting
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Unexported package methods
+//_______________________________________________________________________
+
+func newAPIError(resp *resty.Response, restErr rest.Error) APIError {
+	apiErr := APIError{
+		HTTPCode: restErr.HTTPCode,
+		Message:  restErr.Message,
+		Errors:   make([]Error, len(restErr.ApplicationErrors)),
+		restErr:  restErr,
+	}
+	for i, appErr := range restErr.ApplicationErrors {
+		apiErr.Errors[i] = Error{
+			ErrorCode:    appErr.Code,
+			ErrorMessage: appErr.Message,
+			Property:     appErr.Property,
+			MoreInfo:     appErr.AdditionalInfo,
+		}
+	}
+	apiErr.RequestID = requestIDFromHeader(resp.Header())
+	return apiErr
+}
+
+//newTransportError creates APIError for request that failed with a given
+//transport error and optional response
+func newTransportError(resp *resty.Response, cause error) APIError {
+	restErr := rest.Error{Message: "HTTP operation failed: " + cause.Error()}
+	if resp != nil {
+		restErr.HTTPCode = resp.StatusCode()
+	}
+	return APIError{
+		HTTPCode: restErr.HTTPCode,
+		Message:  restErr.Message,
+		restErr:  restErr,
+		cause:    cause,
+	}
+}
+
+//requestIDFromHeader returns API request identifier from given response headers
+func requestIDFromHeader(header http.Header) string {
+	for _, name := range requestIDHeaders {
+		if value := header.Get(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,139 @@
+package ne
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/equinix/ne-go/internal/api"
+	"github.com/equinix/rest-go"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIError(t *testing.T) {
+	//given
+	resp := httpmock.NewStringResponse(http.StatusBadRequest,
+		`[{"errorCode":"EQ-4006103","errorMessage":"Device is deprovisioned","property":"uuid","moreInfo":"info"}]`)
+	resp.Header.Set("Content-Type", "application/json")
+	resp.Header.Set("X-Correlation-Id", "req-123")
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("%s/ne/v1/devices/myDevice", baseURL), httpmock.ResponderFromResponse(resp))
+	defer httpmock.DeactivateAndReset()
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	err := c.DeleteDevice("myDevice")
+	//then
+	apiErr := APIError{}
+	assert.True(t, errors.As(err, &apiErr), "Error is APIError")
+	assert.Equal(t, http.StatusBadRequest, apiErr.HTTPCode, "HTTP code matches")
+	assert.Equal(t, "req-123", apiErr.RequestID, "Request ID matches")
+	assert.Equal(t, []Error{{ErrorCode: ErrorCodeDeviceRemoved, ErrorMessage: "Device is deprovisioned", Property: "uuid", MoreInfo: "info"}},
+		apiErr.Errors, "Application errors match")
+	assert.True(t, IsDeviceRemoved(err), "Error matches ErrDeviceRemoved")
+	assert.True(t, IsValidationError(err), "Error matches ErrValidation")
+	assert.False(t, IsNotFound(err), "Error does not match ErrNotFound")
+	assert.Contains(t, err.Error(), "req-123", "Error message contains request ID")
+	restErr := rest.Error{}
+	assert.True(t, errors.As(err, &restErr), "Error unwraps to rest.Error")
+	assert.Equal(t, ErrorCodeDeviceRemoved, restErr.ApplicationErrors[0].Code, "rest.Error application error code matches")
+}
+
+func TestAPIError_sentinels(t *testing.T) {
+	tests := []struct {
+		code     int
+		sentinel error
+		check    func(error) bool
+	}{
+		{http.StatusNotFound, ErrNotFound, IsNotFound},
+		{http.StatusConflict, ErrConflict, IsConflict},
+		{http.StatusUnprocessableEntity, ErrValidation, IsValidationError},
+		{http.StatusTooManyRequests, ErrRateLimited, IsRateLimited},
+	}
+	for _, tc := range tests {
+		//given
+		err := APIError{HTTPCode: tc.code}
+		//then
+		assert.True(t, errors.Is(err, tc.sentinel), "Error with code %d matches %v", tc.code, tc.sentinel)
+		assert.True(t, tc.check(err), "Helper matches error with code %d", tc.code)
+		assert.False(t, errors.Is(APIError{HTTPCode: http.StatusInternalServerError}, tc.sentinel), "Server error does not match %v", tc.sentinel)
+	}
+}
+
+func TestUpdateError_unwrap(t *testing.T) {
+	//given
+	devID := "myDevice"
+	testHc := setupMockedClient("PATCH", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID), http.StatusNotFound,
+		api.ErrorResponse{ErrorCode: "EQ-123", ErrorMessage: "not found"})
+	defer httpmock.DeactivateAndReset()
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	err := c.NewDeviceUpdateRequest(devID).WithDeviceName("newName").Execute()
+	//then
+	changeErr := ChangeError{}
+	assert.True(t, errors.As(err, &changeErr), "UpdateError unwraps to ChangeError")
+	assert.Equal(t, "deviceFields", changeErr.Target, "Change target matches")
+	assert.True(t, IsNotFound(err), "UpdateError matches ErrNotFound")
+	apiErr := APIError{}
+	assert.True(t, errors.As(err, &apiErr), "UpdateError unwraps to APIError")
+	assert.Equal(t, "EQ-123", apiErr.Errors[0].ErrorCode, "Error code matches")
+}
+
+func TestUpdateError_multipleChanges(t *testing.T) {
+	//given
+	updateErr := UpdateError{}
+	updateErr.AddChangeError(changeTypeUpdate, "first", "value", errors.New("some error"))
+	updateErr.AddChangeError(changeTypeUpdate, "second", "value", APIError{HTTPCode: http.StatusConflict})
+	conflictErr := UpdateError{}
+	conflictErr.AddChangeError(changeTypeUpdate, "first", "value", APIError{HTTPCode: http.StatusConflict})
+	conflictErr.AddChangeError(changeTypeUpdate, "second", "value", APIError{HTTPCode: http.StatusConflict})
+	//then
+	assert.False(t, IsConflict(updateErr), "Sentinel matched by some of the changes is not matched")
+	assert.True(t, IsConflict(conflictErr), "Sentinel matched by all changes is matched")
+	apiErr := APIError{}
+	assert.True(t, errors.As(updateErr, &apiErr), "Cause of second change is found")
+	assert.False(t, IsNotFound(updateErr), "Not related sentinel is not matched")
+	assert.Nil(t, updateErr.Unwrap(), "Update error with many changes unwraps to nil")
+	assert.Nil(t, UpdateError{}.Unwrap(), "Empty update error unwraps to nil")
+}
+
+func TestUpdateError_restError(t *testing.T) {
+	//given
+	updateErr := UpdateError{}
+	updateErr.AddChangeError(changeTypeUpdate, "first", "value", errors.New("some error"))
+	updateErr.AddChangeError(changeTypeUpdate, "second", "value",
+		APIError{HTTPCode: http.StatusConflict, restErr: rest.Error{HTTPCode: http.StatusConflict, Message: "conflict"}})
+	//when
+	restErr := rest.Error{}
+	ok := errors.As(updateErr, &restErr)
+	//then
+	assert.True(t, ok, "rest.Error of a failed change is reachable")
+	assert.Equal(t, http.StatusConflict, restErr.HTTPCode, "rest.Error HTTP code matches")
+	assert.Equal(t, "conflict", restErr.Message, "rest.Error message matches")
+}
+
+func TestAPIError_transport(t *testing.T) {
+	//given
+	devID := "myDevice"
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID),
+		httpmock.NewErrorResponder(context.DeadlineExceeded))
+	defer httpmock.DeactivateAndReset()
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	_, err := c.GetDevice(devID)
+	//then
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "Transport error is reachable")
+	restErr := rest.Error{}
+	assert.True(t, errors.As(err, &restErr), "Error can be set to rest.Error")
+	assert.Contains(t, restErr.Message, "HTTP operation failed", "rest.Error message matches")
+}
+
+func TestDeviceStateError_deviceRemoved(t *testing.T) {
+	assert.True(t, IsDeviceRemoved(DeviceStateError{State: DeviceStateDeprovisioned}), "Deprovisioned device matches ErrDeviceRemoved")
+	assert.False(t, IsDeviceRemoved(DeviceStateError{State: DeviceStateFailed}), "Failed device does not match ErrDeviceRemoved")
+}
@@ -4,6 +4,7 @@ go 1.14
 
 require (
 	github.com/equinix/rest-go v1.3.0
+	github.com/go-resty/resty/v2 v2.3.0
 	github.com/jarcoal/httpmock v1.0.8
 	github.com/stretchr/testify v1.7.0
 )
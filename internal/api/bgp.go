@@ -20,3 +20,9 @@ type BGPConfiguration struct {
 type BGPConfigurationCreateResponse struct {
 	UUID *string `json:"uuid,omitempty"`
 }
+
+//BGPConfigurationsResponse describes response for a get BGP configuration list request
+type BGPConfigurationsResponse struct {
+	Pagination Pagination         `json:"pagination,omitempty"`
+	Data       []BGPConfiguration `json:"data,omitempty"`
+}
@@ -0,0 +1,16 @@
+package api
+
+//DeviceSoftwareUpgradeRequest describes request to upgrade software version of a device
+type DeviceSoftwareUpgradeRequest struct {
+	Version *string `json:"version,omitempty"`
+}
+
+//DeviceSoftwareUpgradeResponse describes software upgrade details of a device
+type DeviceSoftwareUpgradeResponse struct {
+	Status        *string `json:"status,omitempty"`
+	SourceVersion *string `json:"sourceVersion,omitempty"`
+	TargetVersion *string `json:"targetVersion,omitempty"`
+	RequestedDate *string `json:"requestedDate,omitempty"`
+	CompletedDate *string `json:"completedDate,omitempty"`
+	ErrorMessage  *string `json:"errorMessage,omitempty"`
+}
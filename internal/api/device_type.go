@@ -8,14 +8,21 @@ type DeviceTypeResponse struct {
 
 //DeviceType describes Network Edge device type
 type DeviceType struct {
-	Code                  *string                     `json:"deviceTypeCode,omitempty"`
-	Name                  *string                     `json:"name,omitempty"`
-	Description           *string                     `json:"description,omitempty"`
-	Vendor                *string                     `json:"vendor,omitempty"`
-	Category              *string                     `json:"category,omitempty"`
-	AvailableMetros       []DeviceTypeAvailableMetro  `json:"availableMetros,omitempty"`
-	SoftwarePackages      []DeviceTypeSoftwarePackage `json:"softwarePackages,omitempty"`
-	DeviceManagementTypes DeviceManagementTypes       `json:"deviceManagementTypes,omitempty"`
+	Code                  *string                      `json:"deviceTypeCode,omitempty"`
+	Name                  *string                      `json:"name,omitempty"`
+	Description           *string                      `json:"description,omitempty"`
+	Vendor                *string                      `json:"vendor,omitempty"`
+	Category              *string                      `json:"category,omitempty"`
+	AvailableMetros       []DeviceTypeAvailableMetro   `json:"availableMetros,omitempty"`
+	SoftwarePackages      []DeviceTypeSoftwarePackage  `json:"softwarePackages,omitempty"`
+	DeviceManagementTypes DeviceManagementTypes        `json:"deviceManagementTypes,omitempty"`
+	ClusteringDetails     *DeviceTypeClusteringDetails `json:"clusteringDetails,omitempty"`
+}
+
+//DeviceTypeClusteringDetails describes clustering capabilities of a device type
+type DeviceTypeClusteringDetails struct {
+	ClusteringEnabled *bool `json:"clusteringEnabled,omitempty"`
+	MaxAllowedNodes   *int  `json:"maxAllowedNodes,omitempty"`
 }
 
 //DeviceTypeAvailableMetro describes metro in which network edge device is available
@@ -0,0 +1,168 @@
+package ne
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// LogLevel is a severity of a log record
+type LogLevel int
+
+const (
+	//LogLevelDebug is used for records with request and response bodies
+	LogLevelDebug LogLevel = iota
+	//LogLevelInfo is used for records of successful requests
+	LogLevelInfo
+	//LogLevelWarn is used for records of requests that failed with error response
+	//or transport error
+	LogLevelWarn
+)
+
+// LogAttr is a key-value pair attached to a log record
+type LogAttr struct {
+	Key   string
+	Value interface{}
+}
+
+// Logger is used by RestClient to log Network Edge API requests. Each attempt
+// is logged with method, path, status, latency and request ID. Request and
+// response bodies are logged in a separate record, only if debug level is enabled.
+// Secrets, like passwords, authentication keys and license tokens, are replaced
+// with RedactedValue and contents of uploaded files are never logged
+type Logger interface {
+	//Enabled verifies if records of a given level are logged
+	Enabled(ctx context.Context, level LogLevel) bool
+	//Log writes record with a given level, message and attributes
+	Log(ctx context.Context, level LogLevel, msg string, attrs ...LogAttr)
+}
+
+// SetLogger sets logger of Network Edge API requests. Nil logger disables logging
+func (c *RestClient) SetLogger(logger Logger) *RestClient {
+	c.logger = logger
+	return c
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Unexported package methods
+//_______________________________________________________________________
+
+//logRequest logs given attempt of a request, that took given time, along with
+//its outcome. Bodies are logged in a separate record when debug level is enabled
+func (c RestClient) logRequest(ctx context.Context, req *resty.Request, attempt int, latency time.Duration, resp *resty.Response, err error) {
+	if c.logger == nil {
+		return
+	}
+	level := LogLevelInfo
+	attrs := []LogAttr{
+		{Key: "method", Value: req.Method},
+		{Key: "path", Value: requestPath(req)},
+		{Key: "attempt", Value: attempt},
+		{Key: "latency", Value: latency},
+	}
+	if resp != nil && resp.RawResponse != nil {
+		attrs = append(attrs, LogAttr{Key: "status", Value: resp.StatusCode()})
+		if requestID := requestIDFromHeader(resp.Header()); requestID != "" {
+			attrs = append(attrs, LogAttr{Key: "request_id", Value: requestID})
+		}
+		if resp.IsError() {
+			level = LogLevelWarn
+		}
+	}
+	if err != nil {
+		level = LogLevelWarn
+		attrs = append(attrs, LogAttr{Key: "error", Value: err.Error()})
+	}
+	if c.logger.Enabled(ctx, level) {
+		c.logger.Log(ctx, level, "Network Edge API request", attrs...)
+	}
+	if !c.logger.Enabled(ctx, LogLevelDebug) {
+		return
+	}
+	attrs = append(attrs, LogAttr{Key: "request_body", Value: requestBody(req)})
+	if resp != nil && resp.RawResponse != nil {
+		attrs = append(attrs, LogAttr{Key: "response_body", Value: redactedBody(resp.Body())})
+	}
+	c.logger.Log(ctx, LogLevelDebug, "Network Edge API request bodies", attrs...)
+}
+
+//requestPath returns path of a request's URL, along with its query
+func requestPath(req *resty.Request) string {
+	if req.RawRequest != nil && req.RawRequest.URL != nil {
+		return req.RawRequest.URL.RequestURI()
+	}
+	return req.URL
+}
+
+//requestBody returns redacted request body. Multipart requests, like file
+//uploads, are represented by their form fields only
+func requestBody(req *resty.Request) string {
+	if len(req.FormData) > 0 {
+		keys := make([]string, 0, len(req.FormData))
+		for key := range req.FormData {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		fields := make([]string, len(keys))
+		for i, key := range keys {
+			value := strings.Join(req.FormData[key], ",")
+			if isSensitiveName(key) {
+				value = RedactedValue
+			}
+			fields[i] = key + "=" + value
+		}
+		return "multipart form, file contents omitted: " + strings.Join(fields, " ")
+	}
+	switch body := req.Body.(type) {
+	case nil:
+		return ""
+	case string:
+		return redactedBody([]byte(body))
+	case []byte:
+		return redactedBody(body)
+	}
+	data, err := json.Marshal(req.Body)
+	if err != nil {
+		return "body can't be encoded: " + err.Error()
+	}
+	return redactedBody(data)
+}
+
+//redactedBody returns given body with values of sensitive JSON properties
+//replaced with RedactedValue. Bodies that are not JSON are returned as is
+func redactedBody(body []byte) string {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return string(body)
+	}
+	data, err := json.Marshal(redactJSONValue(value))
+	if err != nil {
+		return string(body)
+	}
+	return string(data)
+}
+
+func redactJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, elem := range v {
+			if _, ok := elem.(string); ok && isSensitiveName(key) {
+				v[key] = RedactedValue
+				continue
+			}
+			v[key] = redactJSONValue(elem)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactJSONValue(v[i])
+		}
+	}
+	return value
+}
@@ -0,0 +1,41 @@
+//go:build go1.21
+// +build go1.21
+
+package ne
+
+import (
+	"context"
+	"log/slog"
+)
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlogLogger creates Logger that writes records with a given slog logger.
+// Log levels are mapped to slog levels of the same names
+func NewSlogLogger(logger *slog.Logger) Logger {
+	return slogLogger{logger: logger}
+}
+
+func (l slogLogger) Enabled(ctx context.Context, level LogLevel) bool {
+	return l.logger.Enabled(ctx, slogLevel(level))
+}
+
+func (l slogLogger) Log(ctx context.Context, level LogLevel, msg string, attrs ...LogAttr) {
+	slogAttrs := make([]slog.Attr, len(attrs))
+	for i := range attrs {
+		slogAttrs[i] = slog.Any(attrs[i].Key, attrs[i].Value)
+	}
+	l.logger.LogAttrs(ctx, slogLevel(level), msg, slogAttrs...)
+}
+
+func slogLevel(level LogLevel) slog.Level {
+	switch level {
+	case LogLevelDebug:
+		return slog.LevelDebug
+	case LogLevelWarn:
+		return slog.LevelWarn
+	}
+	return slog.LevelInfo
+}
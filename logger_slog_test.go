@@ -0,0 +1,39 @@
+//go:build go1.21
+// +build go1.21
+
+package ne
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/equinix/ne-go/internal/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSlogLogger(t *testing.T) {
+	//given
+	metro := "SV"
+	testHc := setupMockedClient("GET", fmt.Sprintf("%s/ne/v1/accounts/%s", baseURL, metro), 200, api.AccountResponse{})
+	defer httpmock.DeactivateAndReset()
+	buf := &bytes.Buffer{}
+	handler := slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+	//when
+	c := NewClient(context.Background(), baseURL, testHc).SetLogger(NewSlogLogger(slog.New(handler)))
+	_, err := c.GetAccounts(metro)
+	record := make(map[string]interface{})
+	decodeErr := json.Unmarshal(buf.Bytes(), &record)
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Nil(t, decodeErr, "Single record is logged")
+	assert.Equal(t, "INFO", record["level"], "Level matches")
+	assert.Equal(t, "GET", record["method"], "Method matches")
+	assert.Equal(t, "/ne/v1/accounts/"+metro, record["path"], "Path matches")
+	assert.Equal(t, float64(200), record["status"], "Status matches")
+	assert.NotContains(t, record, "response_body", "Body is not logged on info level")
+}
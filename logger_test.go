@@ -0,0 +1,126 @@
+package ne
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/equinix/ne-go/internal/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+type testLogRecord struct {
+	level LogLevel
+	msg   string
+	attrs map[string]interface{}
+}
+
+type testLogger struct {
+	level   LogLevel
+	mu      sync.Mutex
+	records []testLogRecord
+}
+
+func (l *testLogger) Enabled(ctx context.Context, level LogLevel) bool {
+	return level >= l.level
+}
+
+func (l *testLogger) Log(ctx context.Context, level LogLevel, msg string, attrs ...LogAttr) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	record := testLogRecord{level: level, msg: msg, attrs: make(map[string]interface{})}
+	for _, attr := range attrs {
+		record.attrs[attr.Key] = attr.Value
+	}
+	l.records = append(l.records, record)
+}
+
+func TestLogger_requestSummary(t *testing.T) {
+	//given
+	userID := "myUser"
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/sshUsers/%s", baseURL, userID),
+		func(r *http.Request) (*http.Response, error) {
+			resp, _ := httpmock.NewJsonResponse(404, api.ErrorResponse{ErrorCode: "IC-NE-404"})
+			resp.Header.Set("X-Request-Id", "myRequestID")
+			return resp, nil
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+	logger := &testLogger{level: LogLevelInfo}
+	//when
+	c := NewClient(context.Background(), baseURL, testHc).SetLogger(logger)
+	_, err := c.GetSSHUser(userID)
+	//then
+	assert.NotNil(t, err, "Error is returned")
+	assert.Equal(t, 1, len(logger.records), "One record is logged")
+	record := logger.records[0]
+	assert.Equal(t, LogLevelWarn, record.level, "Failed request is logged with warn level")
+	assert.Equal(t, http.MethodGet, record.attrs["method"], "Method matches")
+	assert.Equal(t, "/ne/v1/sshUsers/"+userID, record.attrs["path"], "Path matches")
+	assert.Equal(t, 404, record.attrs["status"], "Status matches")
+	assert.Equal(t, "myRequestID", record.attrs["request_id"], "Request ID matches")
+	assert.Contains(t, record.attrs, "latency", "Latency is logged")
+	assert.NotContains(t, record.attrs, "request_body", "Request body is not logged")
+}
+
+func TestLogger_redactedBodies(t *testing.T) {
+	//given
+	device := testDevice
+	device.VendorConfiguration = map[string]string{"adminPassword": "myAdminPass", "hostname": "myHost"}
+	testHc := setupMockedClient("POST", fmt.Sprintf("%s/ne/v1/devices", baseURL), 202,
+		api.DeviceRequestResponse{UUID: String("myDevice")})
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/ne/v1/bgp/%s", baseURL, "myBGP"),
+		httpmock.NewJsonResponderOrPanic(200, api.BGPConfigurationCreateResponse{UUID: String("myBGP")}))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/sshUsers/%s", baseURL, "myUser"),
+		httpmock.NewJsonResponderOrPanic(200, api.SSHUser{UUID: String("myUser"), Password: String("myUserPass")}))
+	defer httpmock.DeactivateAndReset()
+	logger := &testLogger{level: LogLevelDebug}
+	//when
+	c := NewClient(context.Background(), baseURL, testHc).SetLogger(logger)
+	_, createErr := c.CreateDevice(device)
+	bgpErr := c.NewBGPConfigurationUpdateRequest("myBGP").WithAuthenticationKey("myAuthKey").Execute()
+	_, userErr := c.GetSSHUser("myUser")
+	//then
+	assert.Nil(t, createErr, "Error is not returned")
+	assert.Nil(t, bgpErr, "Error is not returned")
+	assert.Nil(t, userErr, "Error is not returned")
+	assert.Equal(t, 6, len(logger.records), "Summary and bodies are logged for each request")
+	var bodies []string
+	for _, record := range logger.records {
+		if record.level == LogLevelDebug {
+			bodies = append(bodies, fmt.Sprint(record.attrs["request_body"]), fmt.Sprint(record.attrs["response_body"]))
+		}
+	}
+	logged := strings.Join(bodies, "\n")
+	for _, secret := range []string{StringValue(testDevice.LicenseToken), "myAdminPass", "myAuthKey", "myUserPass"} {
+		assert.NotContains(t, logged, secret, "Secret is not logged")
+	}
+	assert.Contains(t, logged, `"adminPassword":"[REDACTED]"`, "Admin password is redacted")
+	assert.Contains(t, logged, `"hostname":"myHost"`, "Vendor configuration is logged")
+	assert.Contains(t, logged, `"uuid":"myDevice"`, "Response body is logged")
+}
+
+func TestLogger_multipartUpload(t *testing.T) {
+	//given
+	content := "myLicenseFileContent"
+	testHc := setupMockedClient("POST", fmt.Sprintf("%s/ne/v1/devices/licenseFiles", baseURL), 200,
+		api.LicenseFileUploadResponse{FileID: String("myFile")})
+	defer httpmock.DeactivateAndReset()
+	logger := &testLogger{level: LogLevelDebug}
+	//when
+	c := NewClient(context.Background(), baseURL, testHc).SetLogger(logger)
+	_, err := c.UploadLicenseFile("SV", "CSR1000V", DeviceManagementTypeSelf, DeviceLicenseModeBYOL,
+		"license.lic", strings.NewReader(content))
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, 2, len(logger.records), "Summary and bodies are logged")
+	body := fmt.Sprint(logger.records[1].attrs["request_body"])
+	assert.NotContains(t, body, content, "File content is not logged")
+	assert.Contains(t, body, "metroCode=SV", "Form fields are logged")
+}
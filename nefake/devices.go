@@ -0,0 +1,611 @@
+package nefake
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/equinix/ne-go/internal/api"
+)
+
+const (
+	deviceStateInitializing           = "INITIALIZING"
+	deviceStateProvisioning           = "PROVISIONING"
+	deviceStateWaitingPrimary         = "WAITING_FOR_PRIMARY"
+	deviceStateWaitingSecondary       = "WAITING_FOR_SECONDARY"
+	deviceStateWaitingClusterNodes    = "WAITING_FOR_REPLICA_CLUSTER_NODES"
+	deviceStateClusterSetUpInProgress = "CLUSTER_SETUP_IN_PROGRESS"
+	deviceStateFailed                 = "FAILED"
+	deviceStateProvisioned            = "PROVISIONED"
+	deviceStateDeprovisioning         = "DEPROVISIONING"
+	deviceStateDeprovisioned          = "DEPROVISIONED"
+	deviceStateUpgradeInProgress      = "RESOURCE_UPGRADE_IN_PROGRESS"
+
+	licenseStateApplying          = "APPLYING_LICENSE"
+	licenseStateRegistered        = "REGISTERED"
+	licenseStateWaitingClusterSet = "WAITING_FOR_CLUSTER_SETUP"
+
+	provisioningStatusProvisioning = "PROVISIONING"
+	provisioningStatusProvisioned  = "PROVISIONED"
+	provisioningStatusDeprovision  = "DEPROVISIONING"
+	provisioningStatusRemoved      = "DEPROVISIONED"
+
+	deviceUpgradeStatusRequested  = "REQUESTED"
+	deviceUpgradeStatusInProgress = "IN_PROGRESS"
+	deviceUpgradeStatusCompleted  = "COMPLETED"
+
+	redundancyTypePrimary   = "PRIMARY"
+	redundancyTypeSecondary = "SECONDARY"
+)
+
+// SetDeviceState immediately sets state and license state of a device with a given UUID
+// and cancels its pending state transitions. Empty license state is left unchanged
+func (s *Server) SetDeviceState(uuid string, state string, licenseState string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	device, ok := s.devices[uuid]
+	if !ok {
+		return fmt.Errorf("device %q does not exist", uuid)
+	}
+	s.schedule(deviceKey(uuid))
+	device.Status = stringPtr(state)
+	if licenseState != "" {
+		device.LicenseStatus = stringPtr(licenseState)
+	}
+	return nil
+}
+
+// FailDevice replaces pending state transitions of a device with a given UUID
+// with a single transition to FAILED state
+func (s *Server) FailDevice(uuid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	device, ok := s.devices[uuid]
+	if !ok {
+		return fmt.Errorf("device %q does not exist", uuid)
+	}
+	s.schedule(deviceKey(uuid), func() { device.Status = stringPtr(deviceStateFailed) })
+	return nil
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Unexported package methods
+//_______________________________________________________________________
+
+func deviceKey(uuid string) string {
+	return "device/" + uuid
+}
+
+func deviceUpgradeKey(uuid string) string {
+	return "device/upgrade/" + uuid
+}
+
+func deviceACLKey(uuid string) string {
+	return "device/acl/" + uuid
+}
+
+func deviceBandwidthKey(uuid string) string {
+	return "device/bandwidth/" + uuid
+}
+
+func (s *Server) serveDevices(w http.ResponseWriter, r *http.Request, segments []string) bool {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		s.listDevices(w, r)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		s.createDevice(w, r)
+	case len(segments) == 1 && segments[0] == "licenseFiles" && r.Method == http.MethodPost:
+		s.uploadLicenseFile(w, r)
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		s.getDevice(w, segments[0])
+	case len(segments) == 1 && r.Method == http.MethodPatch:
+		s.updateDevice(w, r, segments[0])
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		s.deleteDevice(w, r, segments[0])
+	case len(segments) == 2 && segments[1] == "acl" && r.Method == http.MethodGet:
+		s.getDeviceACL(w, segments[0])
+	case len(segments) == 2 && segments[1] == "acl" && r.Method == http.MethodPatch:
+		s.updateDeviceACL(w, r, segments[0])
+	case len(segments) == 2 && segments[1] == "additionalBandwidths" && r.Method == http.MethodGet:
+		s.getDeviceBandwidth(w, segments[0])
+	case len(segments) == 2 && segments[1] == "additionalBandwidths" && r.Method == http.MethodPut:
+		s.updateDeviceBandwidth(w, r, segments[0])
+	case len(segments) == 2 && segments[1] == "upgrade" && r.Method == http.MethodGet:
+		s.getDeviceUpgrade(w, segments[0])
+	case len(segments) == 2 && segments[1] == "upgrade" && r.Method == http.MethodPost:
+		s.upgradeDevice(w, r, segments[0])
+	default:
+		return false
+	}
+	return true
+}
+
+func (s *Server) listDevices(w http.ResponseWriter, r *http.Request) {
+	var statuses []string
+	if value := r.URL.Query().Get("status"); value != "" {
+		statuses = strings.Split(value, ",")
+	}
+	metroCode := r.URL.Query().Get("metroCode")
+	searchText := strings.ToLower(r.URL.Query().Get("searchText"))
+	devices := make([]api.Device, 0, len(s.devices))
+	for _, uuid := range s.sortedDeviceUUIDs() {
+		device := s.devices[uuid]
+		if len(statuses) > 0 && !containsString(statuses, stringValue(device.Status)) {
+			continue
+		}
+		if metroCode != "" && stringValue(device.MetroCode) != metroCode {
+			continue
+		}
+		if searchText != "" && !strings.Contains(strings.ToLower(stringValue(device.Name)), searchText) &&
+			!strings.Contains(strings.ToLower(stringValue(device.UUID)), searchText) {
+			continue
+		}
+		devices = append(devices, *device)
+	}
+	start, end, pagination := paginate(r, len(devices))
+	writeJSON(w, http.StatusOK, api.DevicesResponse{Pagination: pagination, Data: devices[start:end]})
+	for _, device := range devices[start:end] {
+		s.advance(deviceKey(stringValue(device.UUID)))
+	}
+}
+
+func (s *Server) sortedDeviceUUIDs() []string {
+	uuids := make([]string, 0, len(s.devices))
+	for uuid := range s.devices {
+		uuids = append(uuids, uuid)
+	}
+	sort.Strings(uuids)
+	return uuids
+}
+
+func (s *Server) getDevice(w http.ResponseWriter, uuid string) {
+	device, ok := s.devices[uuid]
+	if !ok {
+		writeNotFound(w, "device", uuid)
+		return
+	}
+	writeJSON(w, http.StatusOK, device)
+	s.advance(deviceKey(uuid))
+}
+
+func (s *Server) createDevice(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeValidation, err.Error(), "")
+		return
+	}
+	secondaryReq := api.AddSecondaryRequest{}
+	if err := json.Unmarshal(body, &secondaryReq); err == nil && secondaryReq.PrimaryDeviceUUID != nil {
+		s.addSecondaryDevice(w, secondaryReq)
+		return
+	}
+	req := api.DeviceRequest{}
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeValidation, "invalid request body: "+err.Error(), "")
+		return
+	}
+	if !s.validateDeviceRequest(w, req) {
+		return
+	}
+	primary := s.newDevice(req)
+	resp := api.DeviceRequestResponse{UUID: primary.UUID}
+	switch {
+	case req.ClusterDetails != nil:
+		resp.ClusterID = s.setupCluster(primary, req.ClusterDetails)
+	case req.Secondary != nil:
+		secondary := s.newSecondaryDevice(*primary, *req.Secondary)
+		s.linkRedundantDevices(primary, secondary)
+		resp.SecondaryUUID = secondary.UUID
+	default:
+		s.scheduleProvisioning(primary)
+	}
+	writeJSON(w, http.StatusAccepted, resp)
+}
+
+func (s *Server) validateDeviceRequest(w http.ResponseWriter, req api.DeviceRequest) bool {
+	if !validateRequired(w, []string{"deviceTypeCode", "metroCode", "virtualDeviceName"},
+		[]*string{req.DeviceTypeCode, req.MetroCode, req.VirtualDeviceName}) {
+		return false
+	}
+	if len(s.deviceTypes) > 0 && s.findDeviceType(stringValue(req.DeviceTypeCode)) == nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeValidation,
+			fmt.Sprintf("device type %q is not supported", stringValue(req.DeviceTypeCode)), "deviceTypeCode")
+		return false
+	}
+	if req.Secondary != nil && stringValue(req.Secondary.MetroCode) == "" {
+		writeRequired(w, "secondary.metroCode")
+		return false
+	}
+	return true
+}
+
+func (s *Server) newDevice(req api.DeviceRequest) *api.Device {
+	uuid := s.newUUID("device")
+	device := &api.Device{
+		UUID:                  stringPtr(uuid),
+		Name:                  req.VirtualDeviceName,
+		DeviceTypeCode:        req.DeviceTypeCode,
+		Status:                stringPtr(deviceStateInitializing),
+		LicenseStatus:         stringPtr(licenseStateApplying),
+		MetroCode:             req.MetroCode,
+		Throughput:            req.Throughput,
+		ThroughputUnit:        req.ThroughputUnit,
+		HostName:              req.HostNamePrefix,
+		PackageCode:           req.PackageCode,
+		Version:               req.Version,
+		LicenseToken:          req.LicenseToken,
+		LicenseType:           req.LicenseMode,
+		LicenseFileID:         req.LicenseFileID,
+		CloudInitFileID:       req.CloudInitFileID,
+		ACLTemplateUUID:       req.ACLTemplateUUID,
+		MgmtAclTemplateUUID:   req.MgmtAclTemplateUUID,
+		AccountNumber:         req.AccountNumber,
+		Notifications:         req.Notifications,
+		PurchaseOrderNumber:   req.PurchaseOrderNumber,
+		AdditionalBandwidth:   req.AdditionalBandwidth,
+		OrderReference:        req.OrderReference,
+		InterfaceCount:        req.InterfaceCount,
+		DeviceManagementType:  req.DeviceManagementType,
+		SshInterfaceID:        req.SshInterfaceId,
+		VendorConfig:          req.VendorConfig,
+		Connectivity:          req.Connectivity,
+		ProjectID:             req.ProjectID,
+		DiverseFromDeviceUUID: req.DiverseFromDeviceUUID,
+	}
+	if req.TermLength != nil {
+		if termLength, err := strconv.Atoi(*req.TermLength); err == nil {
+			device.TermLength = intPtr(termLength)
+		}
+	}
+	if req.Core != nil {
+		device.Core = &api.DeviceCoreInformation{Core: req.Core}
+	}
+	if req.Tier != nil {
+		device.Tier = &api.DeviceCoreInformation{Tier: req.Tier}
+	}
+	if req.UserPublicKey != nil {
+		device.UserPublicKey = &api.DeviceUserPublicKey{
+			Username: req.UserPublicKey.Username,
+			KeyName:  req.UserPublicKey.KeyName,
+		}
+	}
+	s.addDevice(device)
+	return device
+}
+
+func (s *Server) newSecondaryDevice(primary api.Device, req api.SecondaryDeviceRequest) *api.Device {
+	secondary := primary
+	secondary.UUID = stringPtr(s.newUUID("device"))
+	secondary.Status = stringPtr(deviceStateInitializing)
+	secondary.LicenseStatus = stringPtr(licenseStateApplying)
+	secondary.MetroCode = req.MetroCode
+	secondary.LicenseToken = req.LicenseToken
+	secondary.LicenseFileID = req.LicenseFileID
+	secondary.CloudInitFileID = req.CloudInitFileID
+	secondary.Name = req.VirtualDeviceName
+	secondary.Notifications = req.Notifications
+	secondary.HostName = req.HostNamePrefix
+	secondary.AccountNumber = req.AccountNumber
+	secondary.AdditionalBandwidth = req.AdditionalBandwidth
+	secondary.SshInterfaceID = req.SshInterfaceID
+	secondary.ACLTemplateUUID = req.ACLTemplateUUID
+	secondary.MgmtAclTemplateUUID = req.MgmtAclTemplateUUID
+	secondary.VendorConfig = req.VendorConfig
+	secondary.UserPublicKey = nil
+	if req.UserPublicKey != nil {
+		secondary.UserPublicKey = &api.DeviceUserPublicKey{
+			Username: req.UserPublicKey.Username,
+			KeyName:  req.UserPublicKey.KeyName,
+		}
+	}
+	s.addDevice(&secondary)
+	return &secondary
+}
+
+func (s *Server) addDevice(device *api.Device) {
+	uuid := stringValue(device.UUID)
+	s.devices[uuid] = device
+	status := provisioningStatusProvisioned
+	s.deviceACLs[uuid] = &api.DeviceACLResponse{Status: &status}
+	bandwidthStatus := provisioningStatusProvisioned
+	s.deviceBandwidth[uuid] = &api.DeviceAdditionalBandwidthResponse{
+		AdditionalBandwidth: device.AdditionalBandwidth,
+		Status:              &bandwidthStatus,
+	}
+}
+
+func (s *Server) addSecondaryDevice(w http.ResponseWriter, req api.AddSecondaryRequest) {
+	primaryUUID := stringValue(req.PrimaryDeviceUUID)
+	primary, ok := s.devices[primaryUUID]
+	if !ok {
+		writeNotFound(w, "device", primaryUUID)
+		return
+	}
+	if primary.RedundantUUID != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeValidation,
+			fmt.Sprintf("device %q already has redundant device", primaryUUID), "primaryDeviceUuid")
+		return
+	}
+	if req.Secondary == nil || stringValue(req.Secondary.MetroCode) == "" {
+		writeRequired(w, "secondary.metroCode")
+		return
+	}
+	secondary := s.newSecondaryDevice(*primary, *req.Secondary)
+	s.linkRedundantDevices(primary, secondary)
+	writeJSON(w, http.StatusAccepted, api.DeviceRequestResponse{SecondaryUUID: secondary.UUID})
+}
+
+// linkRedundantDevices links devices into HA pair and schedules their provisioning.
+// Primary device waits for provisioning of secondary device
+func (s *Server) linkRedundantDevices(primary *api.Device, secondary *api.Device) {
+	primary.RedundancyType = stringPtr(redundancyTypePrimary)
+	primary.RedundantUUID = secondary.UUID
+	secondary.RedundancyType = stringPtr(redundancyTypeSecondary)
+	secondary.RedundantUUID = primary.UUID
+	if stringValue(primary.Status) == deviceStateProvisioned {
+		s.schedule(deviceKey(stringValue(primary.UUID)),
+			setDeviceState(primary, deviceStateWaitingSecondary, ""),
+			setDeviceState(primary, deviceStateWaitingSecondary, ""),
+			setDeviceState(primary, deviceStateProvisioned, ""))
+	} else {
+		s.schedule(deviceKey(stringValue(primary.UUID)),
+			setDeviceState(primary, deviceStateProvisioning, ""),
+			setDeviceState(primary, deviceStateWaitingSecondary, ""),
+			setDeviceState(primary, deviceStateProvisioned, licenseStateRegistered))
+	}
+	s.schedule(deviceKey(stringValue(secondary.UUID)),
+		setDeviceState(secondary, deviceStateProvisioning, ""),
+		setDeviceState(secondary, deviceStateWaitingPrimary, ""),
+		setDeviceState(secondary, deviceStateProvisioned, licenseStateRegistered))
+}
+
+func (s *Server) scheduleProvisioning(device *api.Device) {
+	s.schedule(deviceKey(stringValue(device.UUID)),
+		setDeviceState(device, deviceStateProvisioning, ""),
+		setDeviceState(device, deviceStateProvisioned, licenseStateRegistered))
+}
+
+// setupCluster creates cluster node devices and schedules cluster provisioning
+func (s *Server) setupCluster(device *api.Device, req *api.ClusterDetailsRequest) *string {
+	clusterID := stringPtr(s.newUUID("cluster"))
+	details := &api.ClusterDetails{
+		ClusterID:   clusterID,
+		ClusterName: req.ClusterName,
+		NumOfNodes:  intPtr(2),
+	}
+	for i := 0; i < 2; i++ {
+		nodeReq := req.ClusterNodeDetails[fmt.Sprintf("node%d", i)]
+		node := *device
+		node.UUID = stringPtr(s.newUUID("device"))
+		node.Name = stringPtr(fmt.Sprintf("%s-node%d", stringValue(device.Name), i))
+		node.LicenseFileID = nodeReq.LicenseFileID
+		node.LicenseToken = nodeReq.LicenseToken
+		node.VendorConfig = nodeReq.VendorConfiguration
+		node.LicenseStatus = stringPtr(licenseStateWaitingClusterSet)
+		node.ClusterDetails = details
+		s.addDevice(&node)
+		details.Nodes = append(details.Nodes, api.ClusterNode{
+			UUID:                node.UUID,
+			Name:                node.Name,
+			Node:                intPtr(i),
+			AdminPassword:       stringPtr(fmt.Sprintf("fake-admin-password-%d", i)),
+			VendorConfiguration: nodeReq.VendorConfiguration,
+		})
+		s.scheduleClusterProvisioning(&node)
+	}
+	device.ClusterDetails = details
+	device.LicenseStatus = stringPtr(licenseStateWaitingClusterSet)
+	s.scheduleClusterProvisioning(device)
+	return clusterID
+}
+
+func (s *Server) scheduleClusterProvisioning(device *api.Device) {
+	s.schedule(deviceKey(stringValue(device.UUID)),
+		setDeviceState(device, deviceStateProvisioning, ""),
+		setDeviceState(device, deviceStateWaitingClusterNodes, ""),
+		setDeviceState(device, deviceStateClusterSetUpInProgress, ""),
+		setDeviceState(device, deviceStateProvisioned, licenseStateRegistered))
+}
+
+func setDeviceState(device *api.Device, state string, licenseState string) func() {
+	return func() {
+		device.Status = stringPtr(state)
+		if licenseState != "" {
+			device.LicenseStatus = stringPtr(licenseState)
+		}
+	}
+}
+
+func (s *Server) updateDevice(w http.ResponseWriter, r *http.Request, uuid string) {
+	device, ok := s.devices[uuid]
+	if !ok {
+		writeNotFound(w, "device", uuid)
+		return
+	}
+	req := api.DeviceUpdateRequest{}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.VirtualDeviceName != nil {
+		device.Name = req.VirtualDeviceName
+	}
+	if req.TermLength != nil {
+		device.TermLength = req.TermLength
+	}
+	if req.Notifications != nil {
+		device.Notifications = req.Notifications
+	}
+	if req.ClusterName != nil && device.ClusterDetails != nil {
+		device.ClusterDetails.ClusterName = req.ClusterName
+	}
+	if req.Core != nil {
+		core := &api.DeviceCoreInformation{Core: req.Core}
+		if device.Core != nil {
+			updated := *device.Core
+			updated.Core = req.Core
+			core = &updated
+		}
+		s.schedule(deviceKey(uuid),
+			setDeviceState(device, deviceStateUpgradeInProgress, ""),
+			func() {
+				device.Core = core
+				device.Status = stringPtr(deviceStateProvisioned)
+			})
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteDevice(w http.ResponseWriter, r *http.Request, uuid string) {
+	device, ok := s.devices[uuid]
+	if !ok {
+		writeNotFound(w, "device", uuid)
+		return
+	}
+	uuids := []string{uuid}
+	if r.URL.Query().Get("deleteRedundantDevice") == "true" && device.RedundantUUID != nil {
+		uuids = append(uuids, stringValue(device.RedundantUUID))
+	}
+	for _, uuid := range uuids {
+		device, ok := s.devices[uuid]
+		if !ok {
+			continue
+		}
+		status := stringValue(device.Status)
+		if status == deviceStateDeprovisioning || status == deviceStateDeprovisioned {
+			writeError(w, http.StatusBadRequest, ErrorCodeDeviceRemoved,
+				fmt.Sprintf("device %q is already deprovisioning or deprovisioned", uuid), "uuid")
+			return
+		}
+	}
+	for _, uuid := range uuids {
+		device := s.devices[uuid]
+		device.Status = stringPtr(deviceStateDeprovisioning)
+		s.schedule(deviceKey(uuid), setDeviceState(device, deviceStateDeprovisioned, ""))
+		if redundant, ok := s.devices[stringValue(device.RedundantUUID)]; ok && len(uuids) == 1 {
+			redundant.RedundantUUID = nil
+			redundant.RedundancyType = nil
+			device.RedundantUUID = nil
+			device.RedundancyType = nil
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getDeviceACL(w http.ResponseWriter, uuid string) {
+	acl, ok := s.deviceACLs[uuid]
+	if !ok {
+		writeNotFound(w, "device", uuid)
+		return
+	}
+	writeJSON(w, http.StatusOK, acl)
+	s.advance(deviceACLKey(uuid))
+}
+
+func (s *Server) updateDeviceACL(w http.ResponseWriter, r *http.Request, uuid string) {
+	device, ok := s.devices[uuid]
+	if !ok {
+		writeNotFound(w, "device", uuid)
+		return
+	}
+	req := api.DeviceACLTemplateRequest{}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	for _, templateUUID := range []*string{req.TemplateUUID, req.MgmtAclTemplateUUID} {
+		if templateUUID == nil {
+			continue
+		}
+		if _, ok := s.aclTemplates[*templateUUID]; !ok {
+			writeNotFound(w, "ACL template", *templateUUID)
+			return
+		}
+	}
+	device.ACLTemplateUUID = req.TemplateUUID
+	device.MgmtAclTemplateUUID = req.MgmtAclTemplateUUID
+	acl := s.deviceACLs[uuid]
+	acl.Status = stringPtr(provisioningStatusProvisioning)
+	s.schedule(deviceACLKey(uuid), func() { acl.Status = stringPtr(provisioningStatusProvisioned) })
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getDeviceBandwidth(w http.ResponseWriter, uuid string) {
+	bandwidth, ok := s.deviceBandwidth[uuid]
+	if !ok {
+		writeNotFound(w, "device", uuid)
+		return
+	}
+	writeJSON(w, http.StatusOK, bandwidth)
+	s.advance(deviceBandwidthKey(uuid))
+}
+
+func (s *Server) updateDeviceBandwidth(w http.ResponseWriter, r *http.Request, uuid string) {
+	device, ok := s.devices[uuid]
+	if !ok {
+		writeNotFound(w, "device", uuid)
+		return
+	}
+	req := api.DeviceAdditionalBandwidthUpdateRequest{}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.AdditionalBandwidth == nil {
+		writeRequired(w, "additionalBandwidth")
+		return
+	}
+	device.AdditionalBandwidth = req.AdditionalBandwidth
+	bandwidth := s.deviceBandwidth[uuid]
+	bandwidth.AdditionalBandwidth = req.AdditionalBandwidth
+	bandwidth.Status = stringPtr(provisioningStatusProvisioning)
+	s.schedule(deviceBandwidthKey(uuid), func() { bandwidth.Status = stringPtr(provisioningStatusProvisioned) })
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getDeviceUpgrade(w http.ResponseWriter, uuid string) {
+	upgrade, ok := s.deviceUpgrades[uuid]
+	if !ok {
+		writeNotFound(w, "device software upgrade", uuid)
+		return
+	}
+	writeJSON(w, http.StatusOK, upgrade)
+	s.advance(deviceUpgradeKey(uuid))
+}
+
+func (s *Server) upgradeDevice(w http.ResponseWriter, r *http.Request, uuid string) {
+	device, ok := s.devices[uuid]
+	if !ok {
+		writeNotFound(w, "device", uuid)
+		return
+	}
+	req := api.DeviceSoftwareUpgradeRequest{}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if stringValue(req.Version) == "" {
+		writeRequired(w, "version")
+		return
+	}
+	if stringValue(device.Status) != deviceStateProvisioned {
+		writeError(w, http.StatusBadRequest, ErrorCodeValidation,
+			fmt.Sprintf("device %q is not provisioned", uuid), "uuid")
+		return
+	}
+	upgrade := &api.DeviceSoftwareUpgradeResponse{
+		Status:        stringPtr(deviceUpgradeStatusRequested),
+		SourceVersion: device.Version,
+		TargetVersion: req.Version,
+		RequestedDate: stringPtr(time.Now().UTC().Format(time.RFC3339)),
+	}
+	s.deviceUpgrades[uuid] = upgrade
+	s.schedule(deviceUpgradeKey(uuid),
+		func() { upgrade.Status = stringPtr(deviceUpgradeStatusInProgress) },
+		func() {
+			upgrade.Status = stringPtr(deviceUpgradeStatusCompleted)
+			device.Version = req.Version
+		})
+	w.WriteHeader(http.StatusAccepted)
+}
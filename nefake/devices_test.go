@@ -0,0 +1,373 @@
+package nefake
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/equinix/ne-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func testDevice() ne.Device {
+	return ne.Device{
+		Name:           ne.String("device"),
+		TypeCode:       ne.String("CSR1000V"),
+		MetroCode:      ne.String("SV"),
+		AccountNumber:  ne.String("123456"),
+		PackageCode:    ne.String("SEC"),
+		Version:        ne.String("16.09.05"),
+		TermLength:     ne.Int(12),
+		Notifications:  []string{"test@equinix.com"},
+		IsSelfManaged:  ne.Bool(false),
+		IsBYOL:         ne.Bool(false),
+		CoreCount:      ne.Int(2),
+		HostName:       ne.String("device"),
+		Throughput:     ne.Int(500),
+		ThroughputUnit: ne.String("Mbps"),
+	}
+}
+
+func TestDeviceLifecycle(t *testing.T) {
+	//given
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(s)
+	template, err := c.CreateACLTemplate(ne.ACLTemplate{Name: ne.String("acl"), MetroCode: ne.String("SV")})
+	assert.Nil(t, err, "ACL template is created")
+	var observed []string
+	opts := testWaitOptions
+	opts.OnProgress = func(progress ne.WaitProgress) {
+		observed = append(observed, progress.Status)
+	}
+	//when
+	uuid, createErr := c.CreateDevice(testDevice())
+	_, waitErr := c.WaitForDeviceState(context.Background(), ne.StringValue(uuid), []string{ne.DeviceStateProvisioned}, &opts)
+	updateErr := c.NewDeviceUpdateRequest(ne.StringValue(uuid)).
+		WithDeviceName("renamed").
+		WithTermLength(24).
+		WithAdditionalBandwidth(100).
+		WithACLTemplate(ne.StringValue(template)).
+		Execute()
+	device, getErr := c.GetDevice(ne.StringValue(uuid))
+	acl, aclErr := c.WaitForDeviceACLStatus(context.Background(), ne.StringValue(uuid), []string{ne.ACLDeviceStatusProvisioned}, &testWaitOptions)
+	deleteErr := c.DeleteDevice(ne.StringValue(uuid))
+	_, deletedWaitErr := c.WaitForDeviceState(context.Background(), ne.StringValue(uuid), []string{ne.DeviceStateDeprovisioned}, &testWaitOptions)
+	secondDeleteErr := c.DeleteDevice(ne.StringValue(uuid))
+	//then
+	assert.Nil(t, createErr, "Device is created")
+	assert.Nil(t, waitErr, "Device is provisioned")
+	assert.Equal(t, []string{ne.DeviceStateInitializing, ne.DeviceStateProvisioning, ne.DeviceStateProvisioned}, observed, "Device went through provisioning states")
+	assert.Nil(t, updateErr, "Device is updated")
+	assert.Nil(t, getErr, "Device is fetched")
+	assert.Equal(t, "renamed", ne.StringValue(device.Name), "Device name is updated")
+	assert.Equal(t, 24, ne.IntValue(device.TermLength), "Device term length is updated")
+	assert.Equal(t, 100, ne.IntValue(device.AdditionalBandwidth), "Device additional bandwidth is updated")
+	assert.Equal(t, ne.StringValue(template), ne.StringValue(device.ACLTemplateUUID), "Device ACL template is updated")
+	assert.Nil(t, aclErr, "Device ACL is provisioned")
+	assert.Equal(t, ne.ACLDeviceStatusProvisioned, ne.StringValue(acl.Status), "Device ACL status matches")
+	assert.Nil(t, deleteErr, "Device is deleted")
+	assert.Nil(t, deletedWaitErr, "Device is deprovisioned")
+	assert.True(t, ne.IsDeviceRemoved(secondDeleteErr), "Second delete fails with device removed error")
+}
+
+func TestDeviceValidation(t *testing.T) {
+	//given
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(s)
+	device := testDevice()
+	device.MetroCode = nil
+	//when
+	_, err := c.CreateDevice(device)
+	//then
+	apiErr := ne.APIError{}
+	assert.ErrorAs(t, err, &apiErr, "APIError is returned")
+	assert.True(t, ne.IsValidationError(err), "Error is validation error")
+	assert.Equal(t, "metroCode", apiErr.Errors[0].Property, "Error property matches")
+}
+
+func TestRedundantDevice(t *testing.T) {
+	//given
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(s)
+	secondary := testDevice()
+	secondary.Name = ne.String("secondary")
+	secondary.MetroCode = ne.String("DC")
+	//when
+	primaryUUID, _, createErr := c.CreateRedundantDevice(testDevice(), secondary)
+	_, waitErr := c.WaitForDeviceState(context.Background(), ne.StringValue(primaryUUID), []string{ne.DeviceStateProvisioned}, &testWaitOptions)
+	singleUUID, _ := c.CreateDevice(testDevice())
+	_, singleWaitErr := c.WaitForDeviceState(context.Background(), ne.StringValue(singleUUID), []string{ne.DeviceStateProvisioned}, &testWaitOptions)
+	redundantUUID, addErr := c.AddSecondaryAndWait(context.Background(), ne.StringValue(singleUUID), secondary, &testWaitOptions)
+	//then
+	assert.Nil(t, createErr, "Redundant device is created")
+	assert.Nil(t, waitErr, "Primary device is provisioned")
+	assert.Nil(t, singleWaitErr, "Single device is provisioned")
+	assert.Nil(t, addErr, "Secondary device is added")
+	secondaryDevice, err := c.GetDevice(ne.StringValue(redundantUUID))
+	assert.Nil(t, err, "Secondary device is fetched")
+	assert.Equal(t, "DC", ne.StringValue(secondaryDevice.MetroCode), "Secondary metro code matches")
+	assert.Equal(t, ne.StringValue(singleUUID), ne.StringValue(secondaryDevice.RedundantUUID), "Secondary device is linked with primary")
+}
+
+func TestClusterDevice(t *testing.T) {
+	//given
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(s)
+	device := testDevice()
+	device.ClusterDetails = &ne.ClusterDetails{
+		ClusterName: ne.String("cluster"),
+		Node0:       &ne.ClusterNodeDetail{LicenseToken: ne.String("token0")},
+		Node1:       &ne.ClusterNodeDetail{LicenseToken: ne.String("token1")},
+	}
+	//when
+	uuid, err := c.CreateDevice(device)
+	cluster, waitErr := c.WaitForDeviceState(context.Background(), ne.StringValue(uuid), []string{ne.DeviceStateProvisioned}, &testWaitOptions)
+	//then
+	assert.Nil(t, err, "Cluster device is created")
+	assert.Nil(t, waitErr, "Cluster device is provisioned")
+	assert.Equal(t, "cluster", ne.StringValue(cluster.ClusterDetails.ClusterName), "Cluster name matches")
+	node, err := c.GetDevice(ne.StringValue(cluster.ClusterDetails.Node1.UUID))
+	assert.Nil(t, err, "Cluster node device is fetched")
+	assert.Equal(t, ne.StringValue(cluster.ClusterDetails.ClusterId), ne.StringValue(node.ClusterDetails.ClusterId), "Node cluster ID matches")
+}
+
+func TestClusterLifecycle(t *testing.T) {
+	//given
+	s := NewServer()
+	defer s.Close()
+	s.AddDeviceType(ne.DeviceType{Code: ne.String("CSR1000V"), MetroCodes: []string{"SV"},
+		IsClusteringSupported: ne.Bool(true), MaxClusterNodes: ne.Int(2)}, nil, nil)
+	c := newTestClient(s)
+	spec := ne.ClusterSpec{
+		Device:      testDevice(),
+		ClusterName: "cluster",
+		Nodes: []ne.ClusterNodeSpec{
+			{VendorConfiguration: map[string]string{"hostname": "node0"}, LicenseToken: "token0"},
+			{VendorConfiguration: map[string]string{"hostname": "node1"}, LicenseToken: "token1"},
+		},
+	}
+	//when
+	uuid, err := c.CreateClusterDevice(context.Background(), spec)
+	cluster, waitErr := c.WaitForCluster(context.Background(), ne.StringValue(uuid), &testWaitOptions)
+	//then
+	assert.Nil(t, err, "Cluster device is created")
+	assert.Nil(t, waitErr, "Cluster is provisioned")
+	assert.Equal(t, "cluster", ne.StringValue(cluster.Name), "Cluster name matches")
+	assert.Equal(t, ne.DeviceStateProvisioned, ne.StringValue(cluster.Device.Status), "Cluster device is provisioned")
+	assert.Equal(t, 2, len(cluster.Nodes), "Both node devices are returned")
+	assert.Equal(t, "node1", cluster.Nodes[1].VendorConfiguration["hostname"], "Node devices are ordered")
+	assert.Equal(t, "token0", ne.StringValue(cluster.Nodes[0].LicenseToken), "Node license matches")
+}
+
+func TestIdempotentCreateDevice(t *testing.T) {
+	//given
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(s).SetIdempotentCreates(true)
+	byReference := testDevice()
+	byReference.Name = ne.String("referenced")
+	byReference.OrderReference = ne.String("order-1")
+	//when
+	first, firstErr := c.CreateDevice(testDevice())
+	retried, retriedErr := c.CreateDevice(testDevice())
+	referenced, _ := c.CreateDevice(byReference)
+	byReference.Name = ne.String("renamed")
+	referencedRetried, _ := c.CreateDevice(byReference)
+	_ = c.DeleteDevice(ne.StringValue(first))
+	_, _ = c.WaitForDeviceState(context.Background(), ne.StringValue(first), []string{ne.DeviceStateDeprovisioned}, &testWaitOptions)
+	recreated, recreatedErr := c.CreateDevice(testDevice())
+	//then
+	assert.Nil(t, firstErr, "Device is created")
+	assert.Nil(t, retriedErr, "Retried device creation succeeds")
+	assert.Equal(t, ne.StringValue(first), ne.StringValue(retried), "Existing device is matched by name and metro")
+	assert.Equal(t, ne.StringValue(referenced), ne.StringValue(referencedRetried), "Existing device is matched by order reference")
+	assert.Nil(t, recreatedErr, "Device is created again")
+	assert.NotEqual(t, ne.StringValue(first), ne.StringValue(recreated), "Deprovisioned device is not matched")
+}
+
+func TestEnsureDeviceDeleted(t *testing.T) {
+	//given
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(s)
+	uuid, _ := c.CreateDevice(testDevice())
+	//when
+	deleteErr := c.EnsureDeviceDeleted(context.Background(), ne.StringValue(uuid))
+	secondDeleteErr := c.EnsureDeviceDeleted(context.Background(), ne.StringValue(uuid))
+	waitErr := c.WaitForDeviceDeleted(context.Background(), ne.StringValue(uuid), &testWaitOptions)
+	deletedErr := c.EnsureDeviceDeleted(context.Background(), ne.StringValue(uuid))
+	missingErr := c.EnsureDeviceDeleted(context.Background(), "missing")
+	//then
+	assert.Nil(t, deleteErr, "Device is deleted")
+	assert.Nil(t, secondDeleteErr, "Deprovisioning device is treated as deleted")
+	assert.Nil(t, waitErr, "Device is deprovisioned")
+	assert.Nil(t, deletedErr, "Deprovisioned device is treated as deleted")
+	assert.Nil(t, missingErr, "Missing device is treated as deleted")
+}
+
+func TestTeardownDevice(t *testing.T) {
+	//given
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(s)
+	acl, _ := c.CreateACLTemplate(ne.ACLTemplate{Name: ne.String("acl")})
+	sharedACL, _ := c.CreateACLTemplate(ne.ACLTemplate{Name: ne.String("shared")})
+	primary := testDevice()
+	primary.ACLTemplateUUID = acl
+	primary.MgmtAclTemplateUuid = sharedACL
+	secondary := testDevice()
+	secondary.Name = ne.String("secondary")
+	primaryUUID, secondaryUUID, _ := c.CreateRedundantDevice(primary, secondary)
+	other := testDevice()
+	other.Name = ne.String("other")
+	other.ACLTemplateUUID = sharedACL
+	otherUUID, _ := c.CreateDevice(other)
+	other.Name = ne.String("another")
+	anotherUUID, _ := c.CreateDevice(other)
+	ownUser, _ := c.CreateSSHUser("own", "secret", ne.StringValue(primaryUUID))
+	sharedUser, _ := c.CreateSSHUser("shared", "secret", ne.StringValue(otherUUID))
+	_ = c.NewSSHUserUpdateRequest(ne.StringValue(sharedUser)).WithDeviceChange(nil, []string{ne.StringValue(secondaryUUID)}).Execute()
+	_, _ = c.CreateDeviceLinkGroup(ne.DeviceLinkGroup{Name: ne.String("pair"), Subnet: ne.String("10.1.1.0/29"),
+		Devices: []ne.DeviceLinkGroupDevice{{DeviceID: primaryUUID}, {DeviceID: otherUUID}}})
+	meshGroup, _ := c.CreateDeviceLinkGroup(ne.DeviceLinkGroup{Name: ne.String("mesh"), Subnet: ne.String("10.1.2.0/29"),
+		Devices: []ne.DeviceLinkGroupDevice{{DeviceID: primaryUUID}, {DeviceID: secondaryUUID}, {DeviceID: otherUUID}, {DeviceID: anotherUUID}}})
+	opts := &ne.TeardownOptions{DeleteACLTemplates: true, WaitOptions: &testWaitOptions}
+	//when
+	plan, planErr := c.PlanDeviceTeardown(context.Background(), ne.StringValue(primaryUUID), opts)
+	report, teardownErr := c.TeardownDevice(context.Background(), ne.StringValue(primaryUUID), opts)
+	_, ownUserErr := c.GetSSHUser(ne.StringValue(ownUser))
+	fetchedSharedUser, _ := c.GetSSHUser(ne.StringValue(sharedUser))
+	fetchedMeshGroup, _ := c.GetDeviceLinkGroup(ne.StringValue(meshGroup))
+	_, aclErr := c.GetACLTemplate(ne.StringValue(acl))
+	_, sharedACLErr := c.GetACLTemplate(ne.StringValue(sharedACL))
+	//then
+	assert.Nil(t, planErr, "Teardown is planned")
+	var actions []string
+	for _, step := range plan.Steps {
+		actions = append(actions, step.Action+" "+step.ResourceType)
+	}
+	assert.Equal(t, []string{"Unassociate sshUser", "Update deviceLinkGroup", "Delete device", "Delete sshUser",
+		"Delete deviceLinkGroup", "Wait device", "Wait device", "Delete aclTemplate"}, actions, "Teardown steps are planned in order")
+	assert.Equal(t, 1, len(plan.Retained), "Shared ACL template is retained")
+	assert.Equal(t, ne.StringValue(sharedACL), plan.Retained[0].ResourceID, "Retained ACL template matches")
+	assert.Nil(t, teardownErr, "Device is torn down")
+	assert.True(t, report.Complete(), "No leftovers are reported")
+	assert.True(t, ne.IsNotFound(ownUserErr), "SSH user of a device is deleted")
+	assert.Equal(t, []string{ne.StringValue(otherUUID)}, fetchedSharedUser.DeviceUUIDs, "Shared SSH user is unassociated")
+	assert.Equal(t, 2, len(fetchedMeshGroup.Devices), "Devices are removed from shared link group")
+	assert.True(t, ne.IsNotFound(aclErr), "ACL template of a device is deleted")
+	assert.Nil(t, sharedACLErr, "Shared ACL template is not deleted")
+}
+
+func TestUpgradeDeviceResources(t *testing.T) {
+	//given
+	s := NewServer()
+	defer s.Close()
+	platform := func(flavor string, cores int) ne.DevicePlatform {
+		return ne.DevicePlatform{Flavor: ne.String(flavor), CoreCount: ne.Int(cores), PackageCodes: []string{"SEC"},
+			ManagementTypes: []string{"EQUINIX-CONFIGURED"}, LicenseOptions: []string{"Sub"}}
+	}
+	s.AddDeviceType(ne.DeviceType{Code: ne.String("CSR1000V"), MetroCodes: []string{"SV"}},
+		[]ne.DevicePlatform{platform("small", 2), platform("medium", 4)}, nil)
+	c := newTestClient(s)
+	secondary := testDevice()
+	secondary.Name = ne.String("secondary")
+	primaryUUID, secondaryUUID, _ := c.CreateRedundantDevice(testDevice(), secondary)
+	_, _ = c.WaitForDeviceState(context.Background(), ne.StringValue(primaryUUID), []string{ne.DeviceStateProvisioned}, &testWaitOptions)
+	_, _ = c.WaitForDeviceState(context.Background(), ne.StringValue(secondaryUUID), []string{ne.DeviceStateProvisioned}, &testWaitOptions)
+	//when
+	invalidErr := c.UpgradeDeviceResources(context.Background(), ne.StringValue(primaryUUID), ne.DeviceResources{CoreCount: 8}, &testWaitOptions)
+	upgradeErr := c.UpgradeDeviceResources(context.Background(), ne.StringValue(primaryUUID), ne.DeviceResources{CoreCount: 4}, &testWaitOptions)
+	primary, _ := c.GetDevice(ne.StringValue(primaryUUID))
+	upgradedSecondary, _ := c.GetDevice(ne.StringValue(secondaryUUID))
+	//then
+	assert.True(t, ne.IsValidationError(invalidErr), "Core count not offered by device type is rejected")
+	assert.Nil(t, upgradeErr, "Device resources are upgraded")
+	assert.Equal(t, 4, ne.IntValue(primary.CoreCount), "Primary device core count is upgraded")
+	assert.Equal(t, 4, ne.IntValue(upgradedSecondary.CoreCount), "Secondary device core count is upgraded")
+	var patched []string
+	for _, req := range s.Requests() {
+		if req.Method == http.MethodPatch {
+			patched = append(patched, req.Path)
+		}
+	}
+	assert.Equal(t, []string{"/ne/v1/devices/" + ne.StringValue(secondaryUUID), "/ne/v1/devices/" + ne.StringValue(primaryUUID)},
+		patched, "Secondary device is upgraded first")
+}
+
+func TestUpgradeDeviceSoftware(t *testing.T) {
+	//given
+	s := NewServer()
+	defer s.Close()
+	s.AddDeviceType(ne.DeviceType{Code: ne.String("CSR1000V"), MetroCodes: []string{"SV"}}, nil,
+		[]ne.DeviceSoftwareVersion{
+			{Version: ne.String("16.09.05"), PackageCodes: []string{"SEC"}},
+			{Version: ne.String("17.03.01"), PackageCodes: []string{"SEC"}, IsStable: ne.Bool(true)},
+			{Version: ne.String("17.06.01"), PackageCodes: []string{"APPX"}},
+		})
+	c := newTestClient(s)
+	uuid, _ := c.CreateDevice(testDevice())
+	_, _ = c.WaitForDeviceState(context.Background(), ne.StringValue(uuid), []string{ne.DeviceStateProvisioned}, &testWaitOptions)
+	//when
+	upgrades, upgradesErr := c.GetDeviceSoftwareUpgrades(context.Background(), ne.StringValue(uuid))
+	invalidErr := c.UpgradeDeviceSoftware(context.Background(), ne.StringValue(uuid), "17.06.01")
+	submitted := time.Now()
+	upgradeErr := c.UpgradeDeviceSoftware(context.Background(), ne.StringValue(uuid), "17.03.01")
+	details, waitErr := c.WaitForDeviceSoftwareUpgrade(context.Background(), ne.StringValue(uuid), "17.03.01", submitted, &testWaitOptions)
+	device, _ := c.GetDevice(ne.StringValue(uuid))
+	//then
+	assert.Nil(t, upgradesErr, "Software upgrades are fetched")
+	assert.Equal(t, 1, len(upgrades), "Number of software upgrades matches")
+	assert.Equal(t, "17.03.01", ne.StringValue(upgrades[0].Version), "Software upgrade version matches")
+	assert.True(t, ne.IsValidationError(invalidErr), "Version not offered for package code is rejected")
+	assert.Nil(t, upgradeErr, "Software upgrade is requested")
+	assert.Nil(t, waitErr, "Software upgrade is completed")
+	assert.Equal(t, ne.DeviceSoftwareUpgradeStatusCompleted, ne.StringValue(details.Status), "Software upgrade status matches")
+	assert.Equal(t, "16.09.05", ne.StringValue(details.SourceVersion), "Software upgrade source version matches")
+	assert.Equal(t, "17.03.01", ne.StringValue(device.Version), "Device version is upgraded")
+}
+
+func TestFailDevice(t *testing.T) {
+	//given
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(s)
+	uuid, _ := c.CreateDevice(testDevice())
+	//when
+	failErr := s.FailDevice(ne.StringValue(uuid))
+	_, waitErr := c.WaitForDeviceState(context.Background(), ne.StringValue(uuid), []string{ne.DeviceStateProvisioned}, &testWaitOptions)
+	//then
+	assert.Nil(t, failErr, "Device failure is scheduled")
+	stateErr := ne.DeviceStateError{}
+	assert.ErrorAs(t, waitErr, &stateErr, "DeviceStateError is returned")
+	assert.Equal(t, ne.DeviceStateFailed, stateErr.State, "Device state matches")
+	assert.NotNil(t, s.FailDevice("missing"), "Error is returned for missing device")
+}
+
+func TestGetDevices(t *testing.T) {
+	//given
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(s)
+	c.PageSize = 2
+	var uuids []string
+	for i := 0; i < 5; i++ {
+		uuid, _ := c.CreateDevice(testDevice())
+		uuids = append(uuids, ne.StringValue(uuid))
+	}
+	assert.Nil(t, s.SetDeviceState(uuids[0], ne.DeviceStateProvisioned, ne.DeviceLicenseStateRegistered), "Device state is set")
+	//when
+	all, allErr := c.GetDevices(nil)
+	provisioned, provisionedErr := c.GetDevices([]string{ne.DeviceStateProvisioned})
+	//then
+	assert.Nil(t, allErr, "Devices are listed")
+	assert.Equal(t, 5, len(all), "All devices are listed across pages")
+	assert.Nil(t, provisionedErr, "Devices are listed with status filter")
+	assert.Equal(t, 1, len(provisioned), "Devices are filtered by status")
+	assert.Equal(t, uuids[0], ne.StringValue(provisioned[0].UUID), "Filtered device UUID matches")
+}
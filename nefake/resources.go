@@ -0,0 +1,789 @@
+package nefake
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/equinix/ne-go"
+	"github.com/equinix/ne-go/internal/api"
+)
+
+const (
+	bgpStateConnect                    = "Connect"
+	bgpStateEstablished                = "Established"
+	bgpProvisioningStatusPendingUpdate = "PENDING_UPDATE"
+
+	fileStatusUploaded = "UPLOADED"
+	processTypeLicense = "LICENSE"
+
+	mgmtTypeSelfConfigured = "SELF-CONFIGURED"
+	licenseModeBYOL        = "BYOL"
+)
+
+// AddAccount adds billing account that is available in a given metro
+func (s *Server) AddAccount(metroCode string, account ne.Account) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accounts[metroCode] = append(s.accounts[metroCode], api.Account{
+		Name:      account.Name,
+		Number:    account.Number,
+		UCMID:     account.UCMID,
+		Status:    account.Status,
+		ProjectID: account.ProjectID,
+	})
+}
+
+// AddDeviceType adds device type along with its platforms and software versions.
+// Once any device type is added, device creation requests for other types are rejected
+func (s *Server) AddDeviceType(deviceType ne.DeviceType, platforms []ne.DevicePlatform, versions []ne.DeviceSoftwareVersion) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	apiType := api.DeviceType{
+		Code:        deviceType.Code,
+		Name:        deviceType.Name,
+		Description: deviceType.Description,
+		Vendor:      deviceType.Vendor,
+		Category:    deviceType.Category,
+	}
+	for _, metro := range deviceType.MetroCodes {
+		apiType.AvailableMetros = append(apiType.AvailableMetros, api.DeviceTypeAvailableMetro{Code: stringPtr(metro)})
+	}
+	apiType.SoftwarePackages = mapSoftwarePackages(versions)
+	apiType.DeviceManagementTypes = mapManagementTypes(platforms)
+	if deviceType.IsClusteringSupported != nil || deviceType.MaxClusterNodes != nil {
+		apiType.ClusteringDetails = &api.DeviceTypeClusteringDetails{
+			ClusteringEnabled: deviceType.IsClusteringSupported,
+			MaxAllowedNodes:   deviceType.MaxClusterNodes,
+		}
+	}
+	s.deviceTypes = append(s.deviceTypes, apiType)
+}
+
+// FileContent returns content of uploaded file with a given UUID
+func (s *Server) FileContent(uuid string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	content, ok := s.fileContents[uuid]
+	return content, ok
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Unexported package methods
+//_______________________________________________________________________
+
+func mapSoftwarePackages(versions []ne.DeviceSoftwareVersion) []api.DeviceTypeSoftwarePackage {
+	packages := make(map[string]*api.DeviceTypeSoftwarePackage)
+	var codes []string
+	for _, version := range versions {
+		for _, code := range version.PackageCodes {
+			pkg, ok := packages[code]
+			if !ok {
+				pkg = &api.DeviceTypeSoftwarePackage{Code: stringPtr(code), Name: stringPtr(code)}
+				packages[code] = pkg
+				codes = append(codes, code)
+			}
+			pkg.VersionDetails = append(pkg.VersionDetails, api.DeviceTypeVersionDetails{
+				Version:          version.Version,
+				ImageName:        version.ImageName,
+				Date:             version.Date,
+				Status:           version.Status,
+				IsStable:         version.IsStable,
+				ReleaseNotesLink: version.ReleaseNotesLink,
+			})
+		}
+	}
+	transformed := make([]api.DeviceTypeSoftwarePackage, len(codes))
+	for i, code := range codes {
+		transformed[i] = *packages[code]
+	}
+	return transformed
+}
+
+func mapManagementTypes(platforms []ne.DevicePlatform) api.DeviceManagementTypes {
+	mgmtTypes := api.DeviceManagementTypes{
+		EquinixConfigured: api.DeviceManagementType{Type: "EQUINIX-CONFIGURED"},
+		SelfConfigured:    api.DeviceManagementType{Type: mgmtTypeSelfConfigured},
+	}
+	for _, platform := range platforms {
+		supported := true
+		core := api.DeviceCore{
+			Core:        platform.CoreCount,
+			Memory:      platform.Memory,
+			Unit:        platform.MemoryUnit,
+			Flavor:      platform.Flavor,
+			IsSupported: &supported,
+		}
+		for _, code := range platform.PackageCodes {
+			core.PackageCodes = append(core.PackageCodes, api.DevicePackageCode{PackageCode: stringPtr(code), IsSupported: &supported})
+		}
+		for _, mgmtType := range platform.ManagementTypes {
+			target := &mgmtTypes.EquinixConfigured
+			if strings.EqualFold(mgmtType, mgmtTypeSelfConfigured) {
+				target = &mgmtTypes.SelfConfigured
+			}
+			target.IsSupported = true
+			for _, licenseMode := range platform.LicenseOptions {
+				option := &target.LicenseOptions.Sub
+				if strings.EqualFold(licenseMode, licenseModeBYOL) {
+					option = &target.LicenseOptions.BYOL
+				}
+				option.Type = stringPtr(licenseMode)
+				option.IsSupported = &supported
+				option.Cores = append(option.Cores, core)
+			}
+		}
+	}
+	return mgmtTypes
+}
+
+func (s *Server) findDeviceType(code string) *api.DeviceType {
+	for i := range s.deviceTypes {
+		if stringValue(s.deviceTypes[i].Code) == code {
+			return &s.deviceTypes[i]
+		}
+	}
+	return nil
+}
+
+func (s *Server) serveDeviceTypes(w http.ResponseWriter, r *http.Request, segments []string) bool {
+	if len(segments) != 0 || r.Method != http.MethodGet {
+		return false
+	}
+	deviceTypes := s.deviceTypes
+	if code := r.URL.Query().Get("deviceTypeCode"); code != "" {
+		deviceTypes = []api.DeviceType{}
+		if deviceType := s.findDeviceType(code); deviceType != nil {
+			deviceTypes = append(deviceTypes, *deviceType)
+		}
+	}
+	start, end, pagination := paginate(r, len(deviceTypes))
+	writeJSON(w, http.StatusOK, api.DeviceTypeResponse{Pagination: pagination, Data: deviceTypes[start:end]})
+	return true
+}
+
+func (s *Server) serveAccounts(w http.ResponseWriter, r *http.Request, segments []string) bool {
+	if len(segments) != 1 || r.Method != http.MethodGet {
+		return false
+	}
+	accounts := s.accounts[segments[0]]
+	if accounts == nil {
+		accounts = []api.Account{}
+	}
+	writeJSON(w, http.StatusOK, api.AccountResponse{Accounts: accounts})
+	return true
+}
+
+func (s *Server) serveSSHUsers(w http.ResponseWriter, r *http.Request, segments []string) bool {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		s.listSSHUsers(w, r)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		s.createSSHUser(w, r)
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		s.getSSHUser(w, segments[0])
+	case len(segments) == 1 && r.Method == http.MethodPut:
+		s.updateSSHUser(w, r, segments[0])
+	case len(segments) == 3 && segments[1] == "devices" &&
+		(r.Method == http.MethodPost || r.Method == http.MethodDelete):
+		s.changeSSHUserAssociation(w, r.Method, segments[0], segments[2])
+	default:
+		return false
+	}
+	return true
+}
+
+func (s *Server) listSSHUsers(w http.ResponseWriter, r *http.Request) {
+	uuids := make([]string, 0, len(s.sshUsers))
+	for uuid := range s.sshUsers {
+		uuids = append(uuids, uuid)
+	}
+	sort.Strings(uuids)
+	users := make([]api.SSHUser, len(uuids))
+	for i, uuid := range uuids {
+		users[i] = sshUserResponse(*s.sshUsers[uuid])
+	}
+	start, end, pagination := paginate(r, len(users))
+	writeJSON(w, http.StatusOK, api.SSHUsersResponse{Pagination: pagination, Data: users[start:end]})
+}
+
+func (s *Server) createSSHUser(w http.ResponseWriter, r *http.Request) {
+	req := api.SSHUserRequest{}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if !validateRequired(w, []string{"username", "password", "deviceUuid"},
+		[]*string{req.Username, req.Password, req.DeviceUUID}) {
+		return
+	}
+	if _, ok := s.devices[*req.DeviceUUID]; !ok {
+		writeNotFound(w, "device", *req.DeviceUUID)
+		return
+	}
+	for _, user := range s.sshUsers {
+		if stringValue(user.Username) == *req.Username {
+			writeError(w, http.StatusConflict, ErrorCodeValidation,
+				fmt.Sprintf("SSH user %q already exists", *req.Username), "username")
+			return
+		}
+	}
+	uuid := s.newUUID("sshuser")
+	s.sshUsers[uuid] = &api.SSHUser{
+		UUID:        stringPtr(uuid),
+		Username:    req.Username,
+		Password:    req.Password,
+		DeviceUUIDs: []string{*req.DeviceUUID},
+	}
+	writeCreated(w, r, uuid)
+}
+
+func (s *Server) getSSHUser(w http.ResponseWriter, uuid string) {
+	user, ok := s.sshUsers[uuid]
+	if !ok {
+		writeNotFound(w, "SSH user", uuid)
+		return
+	}
+	writeJSON(w, http.StatusOK, sshUserResponse(*user))
+}
+
+func (s *Server) updateSSHUser(w http.ResponseWriter, r *http.Request, uuid string) {
+	user, ok := s.sshUsers[uuid]
+	if !ok {
+		writeNotFound(w, "SSH user", uuid)
+		return
+	}
+	req := api.SSHUserUpdateRequest{}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if stringValue(req.Password) == "" {
+		writeRequired(w, "password")
+		return
+	}
+	user.Password = req.Password
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// changeSSHUserAssociation associates SSH user with a device or removes association.
+// SSH user without associated devices is removed
+func (s *Server) changeSSHUserAssociation(w http.ResponseWriter, method string, uuid string, deviceUUID string) {
+	user, ok := s.sshUsers[uuid]
+	if !ok {
+		writeNotFound(w, "SSH user", uuid)
+		return
+	}
+	if _, ok := s.devices[deviceUUID]; !ok {
+		writeNotFound(w, "device", deviceUUID)
+		return
+	}
+	if method == http.MethodPost {
+		if !containsString(user.DeviceUUIDs, deviceUUID) {
+			user.DeviceUUIDs = append(user.DeviceUUIDs, deviceUUID)
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	devices := make([]string, 0, len(user.DeviceUUIDs))
+	for _, dev := range user.DeviceUUIDs {
+		if dev != deviceUUID {
+			devices = append(devices, dev)
+		}
+	}
+	user.DeviceUUIDs = devices
+	if len(devices) == 0 {
+		delete(s.sshUsers, uuid)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func sshUserResponse(user api.SSHUser) api.SSHUser {
+	user.Password = nil
+	return user
+}
+
+func bgpKey(uuid string) string {
+	return "bgp/" + uuid
+}
+
+func (s *Server) serveBGP(w http.ResponseWriter, r *http.Request, segments []string) bool {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		s.listBGPConfigurations(w, r)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		s.createBGPConfiguration(w, r)
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		s.getBGPConfiguration(w, segments[0])
+	case len(segments) == 1 && r.Method == http.MethodPut:
+		s.updateBGPConfiguration(w, r, segments[0])
+	case len(segments) == 2 && segments[0] == "connection" && r.Method == http.MethodGet:
+		s.getBGPConfigurationForConnection(w, segments[1])
+	default:
+		return false
+	}
+	return true
+}
+
+func (s *Server) listBGPConfigurations(w http.ResponseWriter, r *http.Request) {
+	deviceUUID := r.URL.Query().Get("virtualDeviceUuid")
+	uuids := make([]string, 0, len(s.bgpConfigs))
+	for uuid, config := range s.bgpConfigs {
+		if deviceUUID == "" || stringValue(config.VirtualDeviceUUID) == deviceUUID {
+			uuids = append(uuids, uuid)
+		}
+	}
+	sort.Strings(uuids)
+	configs := make([]api.BGPConfiguration, len(uuids))
+	for i, uuid := range uuids {
+		configs[i] = *s.bgpConfigs[uuid]
+	}
+	start, end, pagination := paginate(r, len(configs))
+	writeJSON(w, http.StatusOK, api.BGPConfigurationsResponse{Pagination: pagination, Data: configs[start:end]})
+}
+
+func (s *Server) createBGPConfiguration(w http.ResponseWriter, r *http.Request) {
+	req := api.BGPConfiguration{}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if stringValue(req.ConnectionUUID) == "" {
+		writeRequired(w, "connectionUuid")
+		return
+	}
+	for _, config := range s.bgpConfigs {
+		if stringValue(config.ConnectionUUID) == *req.ConnectionUUID {
+			writeError(w, http.StatusConflict, ErrorCodeValidation,
+				fmt.Sprintf("BGP configuration for connection %q already exists", *req.ConnectionUUID), "connectionUuid")
+			return
+		}
+	}
+	uuid := s.newUUID("bgp")
+	config := req
+	config.UUID = stringPtr(uuid)
+	config.State = stringPtr(bgpStateConnect)
+	config.ProvisioningStatus = stringPtr(provisioningStatusProvisioning)
+	s.bgpConfigs[uuid] = &config
+	s.scheduleBGPProvisioning(&config)
+	writeJSON(w, http.StatusAccepted, api.BGPConfigurationCreateResponse{UUID: config.UUID})
+}
+
+func (s *Server) scheduleBGPProvisioning(config *api.BGPConfiguration) {
+	s.schedule(bgpKey(stringValue(config.UUID)),
+		func() { config.ProvisioningStatus = stringPtr(provisioningStatusProvisioned) },
+		func() { config.State = stringPtr(bgpStateEstablished) })
+}
+
+func (s *Server) getBGPConfiguration(w http.ResponseWriter, uuid string) {
+	config, ok := s.bgpConfigs[uuid]
+	if !ok {
+		writeNotFound(w, "BGP configuration", uuid)
+		return
+	}
+	writeJSON(w, http.StatusOK, config)
+	s.advance(bgpKey(uuid))
+}
+
+func (s *Server) getBGPConfigurationForConnection(w http.ResponseWriter, connectionUUID string) {
+	for uuid, config := range s.bgpConfigs {
+		if stringValue(config.ConnectionUUID) == connectionUUID {
+			writeJSON(w, http.StatusOK, config)
+			s.advance(bgpKey(uuid))
+			return
+		}
+	}
+	writeNotFound(w, "BGP configuration for connection", connectionUUID)
+}
+
+func (s *Server) updateBGPConfiguration(w http.ResponseWriter, r *http.Request, uuid string) {
+	config, ok := s.bgpConfigs[uuid]
+	if !ok {
+		writeNotFound(w, "BGP configuration", uuid)
+		return
+	}
+	req := api.BGPConfiguration{}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.LocalIPAddress != nil {
+		config.LocalIPAddress = req.LocalIPAddress
+	}
+	if req.LocalASN != nil {
+		config.LocalASN = req.LocalASN
+	}
+	if req.RemoteIPAddress != nil {
+		config.RemoteIPAddress = req.RemoteIPAddress
+	}
+	if req.RemoteASN != nil {
+		config.RemoteASN = req.RemoteASN
+	}
+	if req.AuthenticationKey != nil {
+		config.AuthenticationKey = req.AuthenticationKey
+	}
+	config.ProvisioningStatus = stringPtr(bgpProvisioningStatusPendingUpdate)
+	config.State = stringPtr(bgpStateConnect)
+	s.scheduleBGPProvisioning(config)
+	writeJSON(w, http.StatusAccepted, api.BGPConfigurationCreateResponse{UUID: config.UUID})
+}
+
+func (s *Server) serveACLTemplates(w http.ResponseWriter, r *http.Request, segments []string) bool {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		s.listACLTemplates(w, r)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		s.createACLTemplate(w, r)
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		s.getACLTemplate(w, segments[0])
+	case len(segments) == 1 && r.Method == http.MethodPut:
+		s.replaceACLTemplate(w, r, segments[0])
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		s.deleteACLTemplate(w, segments[0])
+	default:
+		return false
+	}
+	return true
+}
+
+func (s *Server) listACLTemplates(w http.ResponseWriter, r *http.Request) {
+	uuids := make([]string, 0, len(s.aclTemplates))
+	for uuid := range s.aclTemplates {
+		uuids = append(uuids, uuid)
+	}
+	sort.Strings(uuids)
+	templates := make([]api.ACLTemplate, len(uuids))
+	for i, uuid := range uuids {
+		templates[i] = s.aclTemplateResponse(uuid)
+	}
+	start, end, pagination := paginate(r, len(templates))
+	writeJSON(w, http.StatusOK, api.ACLTemplatesResponse{Pagination: pagination, Data: templates[start:end]})
+}
+
+func (s *Server) createACLTemplate(w http.ResponseWriter, r *http.Request) {
+	req := api.ACLTemplate{}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if stringValue(req.Name) == "" {
+		writeRequired(w, "name")
+		return
+	}
+	uuid := s.newUUID("acl")
+	template := req
+	template.UUID = stringPtr(uuid)
+	s.aclTemplates[uuid] = &template
+	writeCreated(w, r, uuid)
+}
+
+func (s *Server) getACLTemplate(w http.ResponseWriter, uuid string) {
+	if _, ok := s.aclTemplates[uuid]; !ok {
+		writeNotFound(w, "ACL template", uuid)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.aclTemplateResponse(uuid))
+}
+
+// aclTemplateResponse returns ACL template along with details of devices that use it
+func (s *Server) aclTemplateResponse(uuid string) api.ACLTemplate {
+	template := *s.aclTemplates[uuid]
+	template.DeviceDetails = nil
+	for _, deviceUUID := range s.devicesUsingACLTemplate(uuid) {
+		device := s.devices[deviceUUID]
+		template.DeviceDetails = append(template.DeviceDetails, api.ACLTemplateDeviceDetails{
+			UUID:      device.UUID,
+			Name:      device.Name,
+			ACLStatus: s.deviceACLs[deviceUUID].Status,
+		})
+	}
+	return template
+}
+
+func (s *Server) devicesUsingACLTemplate(uuid string) []string {
+	var uuids []string
+	for _, deviceUUID := range s.sortedDeviceUUIDs() {
+		device := s.devices[deviceUUID]
+		if stringValue(device.Status) == deviceStateDeprovisioned {
+			continue
+		}
+		if stringValue(device.ACLTemplateUUID) == uuid || stringValue(device.MgmtAclTemplateUUID) == uuid {
+			uuids = append(uuids, deviceUUID)
+		}
+	}
+	return uuids
+}
+
+func (s *Server) replaceACLTemplate(w http.ResponseWriter, r *http.Request, uuid string) {
+	template, ok := s.aclTemplates[uuid]
+	if !ok {
+		writeNotFound(w, "ACL template", uuid)
+		return
+	}
+	req := api.ACLTemplate{}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if stringValue(req.Name) == "" {
+		writeRequired(w, "name")
+		return
+	}
+	template.Name = req.Name
+	template.Description = req.Description
+	template.MetroCode = req.MetroCode
+	template.InboundRules = req.InboundRules
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteACLTemplate(w http.ResponseWriter, uuid string) {
+	if _, ok := s.aclTemplates[uuid]; !ok {
+		writeNotFound(w, "ACL template", uuid)
+		return
+	}
+	if devices := s.devicesUsingACLTemplate(uuid); len(devices) > 0 {
+		writeError(w, http.StatusBadRequest, ErrorCodeValidation,
+			fmt.Sprintf("ACL template %q is used by devices: %s", uuid, strings.Join(devices, ", ")), "uuid")
+		return
+	}
+	delete(s.aclTemplates, uuid)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func linkGroupKey(uuid string) string {
+	return "link/" + uuid
+}
+
+func (s *Server) serveLinkGroups(w http.ResponseWriter, r *http.Request, segments []string) bool {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		s.listLinkGroups(w, r)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		s.createLinkGroup(w, r)
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		s.getLinkGroup(w, segments[0])
+	case len(segments) == 1 && r.Method == http.MethodPatch:
+		s.updateLinkGroup(w, r, segments[0])
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		s.deleteLinkGroup(w, segments[0])
+	default:
+		return false
+	}
+	return true
+}
+
+func (s *Server) listLinkGroups(w http.ResponseWriter, r *http.Request) {
+	uuids := make([]string, 0, len(s.linkGroups))
+	for uuid := range s.linkGroups {
+		uuids = append(uuids, uuid)
+	}
+	sort.Strings(uuids)
+	linkGroups := make([]api.DeviceLinkGroup, len(uuids))
+	for i, uuid := range uuids {
+		linkGroups[i] = *s.linkGroups[uuid]
+	}
+	start, end, pagination := paginate(r, len(linkGroups))
+	writeJSON(w, http.StatusOK, api.DeviceLinkGroupsGetResponse{Pagination: pagination, Data: linkGroups[start:end]})
+	for _, uuid := range uuids[start:end] {
+		s.advance(linkGroupKey(uuid))
+	}
+}
+
+func (s *Server) createLinkGroup(w http.ResponseWriter, r *http.Request) {
+	req := api.DeviceLinkGroup{}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if stringValue(req.GroupName) == "" {
+		writeRequired(w, "groupName")
+		return
+	}
+	for _, device := range req.Devices {
+		if _, ok := s.devices[stringValue(device.DeviceUUID)]; !ok {
+			writeNotFound(w, "device", stringValue(device.DeviceUUID))
+			return
+		}
+	}
+	uuid := s.newUUID("link")
+	linkGroup := req
+	linkGroup.UUID = stringPtr(uuid)
+	s.linkGroups[uuid] = &linkGroup
+	s.scheduleLinkGroupProvisioning(&linkGroup)
+	writeJSON(w, http.StatusAccepted, api.DeviceLinkGroupCreateResponse{UUID: linkGroup.UUID})
+}
+
+func (s *Server) scheduleLinkGroupProvisioning(linkGroup *api.DeviceLinkGroup) {
+	linkGroup.Status = stringPtr(provisioningStatusProvisioning)
+	s.schedule(linkGroupKey(stringValue(linkGroup.UUID)),
+		func() { linkGroup.Status = stringPtr(provisioningStatusProvisioned) })
+}
+
+func (s *Server) getLinkGroup(w http.ResponseWriter, uuid string) {
+	linkGroup, ok := s.linkGroups[uuid]
+	if !ok {
+		writeNotFound(w, "device link group", uuid)
+		return
+	}
+	writeJSON(w, http.StatusOK, linkGroup)
+	s.advance(linkGroupKey(uuid))
+}
+
+func (s *Server) updateLinkGroup(w http.ResponseWriter, r *http.Request, uuid string) {
+	linkGroup, ok := s.linkGroups[uuid]
+	if !ok {
+		writeNotFound(w, "device link group", uuid)
+		return
+	}
+	req := api.DeviceLinkGroupUpdateRequest{}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.GroupName != nil {
+		linkGroup.GroupName = req.GroupName
+	}
+	if req.Subnet != nil {
+		linkGroup.Subnet = req.Subnet
+	}
+	if req.Devices != nil {
+		linkGroup.Devices = req.Devices
+	}
+	if req.Links != nil {
+		linkGroup.Links = req.Links
+	}
+	if req.MetroLinks != nil {
+		linkGroup.MetroLinks = req.MetroLinks
+	}
+	if req.RedundancyType != nil {
+		linkGroup.RedundancyType = req.RedundancyType
+	}
+	s.scheduleLinkGroupProvisioning(linkGroup)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) deleteLinkGroup(w http.ResponseWriter, uuid string) {
+	linkGroup, ok := s.linkGroups[uuid]
+	if !ok {
+		writeNotFound(w, "device link group", uuid)
+		return
+	}
+	linkGroup.Status = stringPtr(provisioningStatusDeprovision)
+	s.schedule(linkGroupKey(uuid),
+		func() { linkGroup.Status = stringPtr(provisioningStatusRemoved) },
+		func() { delete(s.linkGroups, uuid) })
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) serveFiles(w http.ResponseWriter, r *http.Request, segments []string) bool {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		uuid, ok := s.storeFile(w, r)
+		if ok {
+			writeJSON(w, http.StatusOK, api.FileUploadResponse{FileUUID: &uuid})
+		}
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		file, ok := s.files[segments[0]]
+		if !ok {
+			writeNotFound(w, "file", segments[0])
+			return true
+		}
+		writeJSON(w, http.StatusOK, file)
+	default:
+		return false
+	}
+	return true
+}
+
+func (s *Server) uploadLicenseFile(w http.ResponseWriter, r *http.Request) {
+	if uuid, ok := s.storeFile(w, r); ok {
+		writeJSON(w, http.StatusOK, api.LicenseFileUploadResponse{FileID: &uuid})
+	}
+}
+
+// storeFile stores file uploaded with multipart request
+func (s *Server) storeFile(w http.ResponseWriter, r *http.Request) (string, bool) {
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeValidation, "file is required: "+err.Error(), "file")
+		return "", false
+	}
+	defer file.Close()
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeValidation, err.Error(), "file")
+		return "", false
+	}
+	for _, property := range []string{"metroCode", "deviceTypeCode"} {
+		if r.FormValue(property) == "" {
+			writeRequired(w, property)
+			return "", false
+		}
+	}
+	processType := r.FormValue("processType")
+	if processType == "" {
+		processType = processTypeLicense
+	}
+	uuid := s.newUUID("file")
+	s.files[uuid] = &api.File{
+		UUID:           stringPtr(uuid),
+		FileName:       stringPtr(header.Filename),
+		MetroCode:      stringPtr(r.FormValue("metroCode")),
+		DeviceTypeCode: stringPtr(r.FormValue("deviceTypeCode")),
+		ProcessType:    stringPtr(processType),
+		Status:         stringPtr(fileStatusUploaded),
+	}
+	s.fileContents[uuid] = content
+	return uuid, true
+}
+
+func (s *Server) servePublicKeys(w http.ResponseWriter, r *http.Request, segments []string) bool {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		uuids := make([]string, 0, len(s.publicKeys))
+		for uuid := range s.publicKeys {
+			uuids = append(uuids, uuid)
+		}
+		sort.Strings(uuids)
+		keys := make([]api.SSHPublicKey, len(uuids))
+		for i, uuid := range uuids {
+			keys[i] = *s.publicKeys[uuid]
+		}
+		writeJSON(w, http.StatusOK, keys)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		s.createPublicKey(w, r)
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		key, ok := s.publicKeys[segments[0]]
+		if !ok {
+			writeNotFound(w, "SSH public key", segments[0])
+			return true
+		}
+		writeJSON(w, http.StatusOK, key)
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		if _, ok := s.publicKeys[segments[0]]; !ok {
+			writeNotFound(w, "SSH public key", segments[0])
+			return true
+		}
+		delete(s.publicKeys, segments[0])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		return false
+	}
+	return true
+}
+
+func (s *Server) createPublicKey(w http.ResponseWriter, r *http.Request) {
+	req := api.SSHPublicKey{}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if !validateRequired(w, []string{"keyName", "keyValue"}, []*string{req.KeyName, req.KeyValue}) {
+		return
+	}
+	for _, key := range s.publicKeys {
+		if stringValue(key.KeyName) == *req.KeyName {
+			writeError(w, http.StatusConflict, ErrorCodeValidation,
+				fmt.Sprintf("SSH public key %q already exists", *req.KeyName), "keyName")
+			return
+		}
+	}
+	uuid := s.newUUID("key")
+	key := req
+	key.UUID = stringPtr(uuid)
+	s.publicKeys[uuid] = &key
+	writeCreated(w, r, uuid)
+}
@@ -0,0 +1,248 @@
+package nefake
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/equinix/ne-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSSHUserLifecycle(t *testing.T) {
+	//given
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(s)
+	first, _ := c.CreateDevice(testDevice())
+	second, _ := c.CreateDevice(testDevice())
+	//when
+	uuid, createErr := c.CreateSSHUser("user", "secret", ne.StringValue(first))
+	_, duplicateErr := c.CreateSSHUser("user", "secret", ne.StringValue(second))
+	updateErr := c.NewSSHUserUpdateRequest(ne.StringValue(uuid)).
+		WithNewPassword("newSecret").
+		WithDeviceChange([]string{ne.StringValue(first)}, []string{ne.StringValue(second)}).
+		Execute()
+	user, getErr := c.GetSSHUser(ne.StringValue(uuid))
+	users, listErr := c.GetSSHUsers()
+	deleteErr := c.DeleteSSHUser(ne.StringValue(uuid))
+	_, deletedErr := c.GetSSHUser(ne.StringValue(uuid))
+	//then
+	assert.Nil(t, createErr, "SSH user is created")
+	assert.True(t, ne.IsConflict(duplicateErr), "Duplicated SSH user is rejected")
+	assert.Nil(t, updateErr, "SSH user is updated")
+	assert.Nil(t, getErr, "SSH user is fetched")
+	assert.Equal(t, []string{ne.StringValue(second)}, user.DeviceUUIDs, "SSH user devices are updated")
+	assert.Nil(t, listErr, "SSH users are listed")
+	assert.Equal(t, 1, len(users), "Number of SSH users matches")
+	assert.Nil(t, deleteErr, "SSH user is deleted")
+	assert.True(t, ne.IsNotFound(deletedErr), "Deleted SSH user is not found")
+}
+
+func TestIdempotentCreateResources(t *testing.T) {
+	//given
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(s).SetIdempotentCreates(true)
+	device, _ := c.CreateDevice(testDevice())
+	template := ne.ACLTemplate{Name: ne.String("acl"), MetroCode: ne.String("SV")}
+	key := ne.SSHPublicKey{Name: ne.String("key"), Value: ne.String("ssh-rsa AAAA")}
+	//when
+	user, userErr := c.CreateSSHUser("user", "secret", ne.StringValue(device))
+	retriedUser, retriedUserErr := c.CreateSSHUser("user", "secret", ne.StringValue(device))
+	acl, aclErr := c.CreateACLTemplate(template)
+	retriedACL, retriedACLErr := c.CreateACLTemplate(template)
+	publicKey, keyErr := c.CreateSSHPublicKey(key)
+	retriedKey, retriedKeyErr := c.CreateSSHPublicKey(key)
+	templates, _ := c.GetACLTemplates()
+	keys, _ := c.GetSSHPublicKeys()
+	//then
+	assert.Nil(t, userErr, "SSH user is created")
+	assert.Nil(t, retriedUserErr, "Retried SSH user creation succeeds")
+	assert.Equal(t, ne.StringValue(user), ne.StringValue(retriedUser), "Existing SSH user is returned")
+	assert.Nil(t, aclErr, "ACL template is created")
+	assert.Nil(t, retriedACLErr, "Retried ACL template creation succeeds")
+	assert.Equal(t, ne.StringValue(acl), ne.StringValue(retriedACL), "Existing ACL template is returned")
+	assert.Equal(t, 1, len(templates), "Single ACL template exists")
+	assert.Nil(t, keyErr, "SSH public key is created")
+	assert.Nil(t, retriedKeyErr, "Retried SSH public key creation succeeds")
+	assert.Equal(t, ne.StringValue(publicKey), ne.StringValue(retriedKey), "Existing SSH public key is returned")
+	assert.Equal(t, 1, len(keys), "Single SSH public key exists")
+}
+
+func TestBGPConfigurationLifecycle(t *testing.T) {
+	//given
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(s)
+	config := ne.BGPConfiguration{
+		ConnectionUUID:    ne.String("connection"),
+		LocalIPAddress:    ne.String("1.1.1.1/32"),
+		LocalASN:          ne.Int(65000),
+		RemoteIPAddress:   ne.String("2.2.2.2"),
+		RemoteASN:         ne.Int(65001),
+		AuthenticationKey: ne.String("key"),
+	}
+	//when
+	uuid, createErr := c.CreateBGPConfiguration(config)
+	established, waitErr := c.WaitForBGPSessionState(context.Background(), ne.StringValue(uuid), []string{ne.BGPStateEstablished}, &testWaitOptions)
+	updateErr := c.NewBGPConfigurationUpdateRequest(ne.StringValue(uuid)).WithRemoteASN(65002).Execute()
+	updated, updatedErr := c.GetBGPConfigurationForConnection("connection")
+	//then
+	assert.Nil(t, createErr, "BGP configuration is created")
+	assert.Nil(t, waitErr, "BGP session is established")
+	assert.Equal(t, ne.BGPProvisioningStatusProvisioned, ne.StringValue(established.ProvisioningStatus), "BGP configuration is provisioned")
+	assert.Nil(t, updateErr, "BGP configuration is updated")
+	assert.Nil(t, updatedErr, "BGP configuration is fetched by connection")
+	assert.Equal(t, 65002, ne.IntValue(updated.RemoteASN), "Remote ASN is updated")
+	assert.Equal(t, ne.BGPProvisioningStatusPendingUpdate, ne.StringValue(updated.ProvisioningStatus), "BGP configuration is pending update")
+}
+
+func TestACLTemplateLifecycle(t *testing.T) {
+	//given
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(s)
+	template := ne.ACLTemplate{
+		Name:      ne.String("acl"),
+		MetroCode: ne.String("SV"),
+		InboundRules: []ne.ACLTemplateInboundRule{
+			{Subnet: ne.String("10.0.0.0/24"), Protocol: ne.String("TCP"), SrcPort: ne.String("any"), DstPort: ne.String("22")},
+		},
+	}
+	uuid, createErr := c.CreateACLTemplate(template)
+	device := testDevice()
+	device.ACLTemplateUUID = uuid
+	deviceUUID, _ := c.CreateDevice(device)
+	//when
+	template.Name = ne.String("renamed")
+	replaceErr := c.ReplaceACLTemplate(ne.StringValue(uuid), template)
+	fetched, getErr := c.GetACLTemplate(ne.StringValue(uuid))
+	inUseErr := c.DeleteACLTemplate(ne.StringValue(uuid))
+	_ = c.DeleteDevice(ne.StringValue(deviceUUID))
+	_, _ = c.WaitForDeviceState(context.Background(), ne.StringValue(deviceUUID), []string{ne.DeviceStateDeprovisioned}, &testWaitOptions)
+	deleteErr := c.DeleteACLTemplate(ne.StringValue(uuid))
+	templates, listErr := c.GetACLTemplates()
+	//then
+	assert.Nil(t, createErr, "ACL template is created")
+	assert.Nil(t, replaceErr, "ACL template is replaced")
+	assert.Nil(t, getErr, "ACL template is fetched")
+	assert.Equal(t, "renamed", ne.StringValue(fetched.Name), "ACL template name is replaced")
+	assert.Equal(t, 1, len(fetched.DeviceDetails), "ACL template device details are returned")
+	assert.True(t, ne.IsValidationError(inUseErr), "ACL template used by device is not deleted")
+	assert.Nil(t, deleteErr, "ACL template is deleted")
+	assert.Nil(t, listErr, "ACL templates are listed")
+	assert.Empty(t, templates, "No ACL templates are left")
+}
+
+func TestDeviceLinkGroupLifecycle(t *testing.T) {
+	//given
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(s)
+	deviceUUID, _ := c.CreateDevice(testDevice())
+	linkGroup := ne.DeviceLinkGroup{
+		Name:    ne.String("links"),
+		Subnet:  ne.String("10.1.1.0/29"),
+		Devices: []ne.DeviceLinkGroupDevice{{DeviceID: deviceUUID, ASN: ne.Int(65000), InterfaceID: ne.Int(5)}},
+	}
+	//when
+	uuid, createErr := c.CreateDeviceLinkGroup(linkGroup)
+	_, waitErr := c.WaitForDeviceLinkGroupStatus(context.Background(), ne.StringValue(uuid), []string{ne.DeviceLinkGroupStatusProvisioned}, &testWaitOptions)
+	updateErr := c.NewDeviceLinkGroupUpdateRequest(ne.StringValue(uuid)).WithGroupName("renamed").Execute()
+	linkGroups, listErr := c.GetDeviceLinkGroups()
+	deleteErr := c.DeleteDeviceLinkGroup(ne.StringValue(uuid))
+	_, deletedWaitErr := c.WaitForDeviceLinkGroupStatus(context.Background(), ne.StringValue(uuid), []string{ne.DeviceLinkGroupStatusDeprovisioned}, &testWaitOptions)
+	//then
+	assert.Nil(t, createErr, "Link group is created")
+	assert.Nil(t, waitErr, "Link group is provisioned")
+	assert.Nil(t, updateErr, "Link group is updated")
+	assert.Nil(t, listErr, "Link groups are listed")
+	assert.Equal(t, "renamed", ne.StringValue(linkGroups[0].Name), "Link group name is updated")
+	assert.Nil(t, deleteErr, "Link group is deleted")
+	assert.Nil(t, deletedWaitErr, "Link group is deprovisioned")
+}
+
+func TestFiles(t *testing.T) {
+	//given
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(s)
+	//when
+	fileUUID, uploadErr := c.UploadFile("SV", "CSR1000V", ne.ProcessTypeCloudInit, "SELF-CONFIGURED", "BYOL", "init.txt", strings.NewReader("#cloud-config"))
+	file, getErr := c.GetFile(ne.StringValue(fileUUID))
+	licenseUUID, licenseErr := c.UploadLicenseFile("SV", "CSR1000V", "SELF-CONFIGURED", "BYOL", "license.lic", strings.NewReader("license"))
+	//then
+	assert.Nil(t, uploadErr, "File is uploaded")
+	assert.Nil(t, getErr, "File is fetched")
+	assert.Equal(t, "init.txt", ne.StringValue(file.FileName), "File name matches")
+	assert.Equal(t, ne.ProcessTypeCloudInit, ne.StringValue(file.ProcessType), "Process type matches")
+	content, ok := s.FileContent(ne.StringValue(fileUUID))
+	assert.True(t, ok, "File content is stored")
+	assert.Equal(t, "#cloud-config", string(content), "File content matches")
+	assert.Nil(t, licenseErr, "License file is uploaded")
+	licenseContent, _ := s.FileContent(ne.StringValue(licenseUUID))
+	assert.Equal(t, "license", string(licenseContent), "License file content matches")
+}
+
+func TestSSHPublicKeyLifecycle(t *testing.T) {
+	//given
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(s)
+	key := ne.SSHPublicKey{Name: ne.String("key"), Value: ne.String("ssh-rsa AAAA"), Type: ne.String("RSA")}
+	//when
+	uuid, createErr := c.CreateSSHPublicKey(key)
+	_, duplicateErr := c.CreateSSHPublicKey(key)
+	fetched, getErr := c.GetSSHPublicKey(ne.StringValue(uuid))
+	deleteErr := c.DeleteSSHPublicKey(ne.StringValue(uuid))
+	keys, listErr := c.GetSSHPublicKeys()
+	//then
+	assert.Nil(t, createErr, "SSH public key is created")
+	assert.True(t, ne.IsConflict(duplicateErr), "Duplicated SSH public key is rejected")
+	assert.Nil(t, getErr, "SSH public key is fetched")
+	assert.Equal(t, "ssh-rsa AAAA", ne.StringValue(fetched.Value), "SSH public key value matches")
+	assert.Nil(t, deleteErr, "SSH public key is deleted")
+	assert.Nil(t, listErr, "SSH public keys are listed")
+	assert.Empty(t, keys, "No SSH public keys are left")
+}
+
+func TestAccountsAndDeviceTypes(t *testing.T) {
+	//given
+	s := NewServer()
+	defer s.Close()
+	s.AddAccount("SV", ne.Account{Name: ne.String("account"), Number: ne.String("123456"), Status: ne.String("Active")})
+	s.AddDeviceType(ne.DeviceType{Code: ne.String("CSR1000V"), Name: ne.String("CSR 1000v"), MetroCodes: []string{"SV", "DC"}},
+		[]ne.DevicePlatform{{
+			Flavor:          ne.String("small"),
+			CoreCount:       ne.Int(2),
+			Memory:          ne.Int(4),
+			MemoryUnit:      ne.String("GB"),
+			PackageCodes:    []string{"SEC"},
+			ManagementTypes: []string{"EQUINIX-CONFIGURED"},
+			LicenseOptions:  []string{"Sub"},
+		}},
+		[]ne.DeviceSoftwareVersion{{Version: ne.String("16.09.05"), PackageCodes: []string{"SEC"}}})
+	c := newTestClient(s)
+	//when
+	accounts, accountsErr := c.GetAccounts("SV")
+	noAccounts, _ := c.GetAccounts("DC")
+	types, typesErr := c.GetDeviceTypes()
+	platforms, platformsErr := c.GetDevicePlatforms("CSR1000V")
+	versions, versionsErr := c.GetDeviceSoftwareVersions("CSR1000V")
+	unknownType := testDevice()
+	unknownType.TypeCode = ne.String("UNKNOWN")
+	_, createErr := c.CreateDevice(unknownType)
+	//then
+	assert.Nil(t, accountsErr, "Accounts are fetched")
+	assert.Equal(t, "123456", ne.StringValue(accounts[0].Number), "Account number matches")
+	assert.Empty(t, noAccounts, "No accounts are returned for other metro")
+	assert.Nil(t, typesErr, "Device types are fetched")
+	assert.Equal(t, []string{"SV", "DC"}, types[0].MetroCodes, "Device type metros match")
+	assert.Nil(t, platformsErr, "Device platforms are fetched")
+	assert.Equal(t, 2, ne.IntValue(platforms[0].CoreCount), "Platform core count matches")
+	assert.Equal(t, []string{"EQUINIX-CONFIGURED"}, platforms[0].ManagementTypes, "Platform management types match")
+	assert.Nil(t, versionsErr, "Software versions are fetched")
+	assert.Equal(t, "16.09.05", ne.StringValue(versions[0].Version), "Software version matches")
+	assert.True(t, ne.IsValidationError(createErr), "Device with unknown type is rejected")
+}
@@ -0,0 +1,347 @@
+// Package nefake implements stateful, in-memory fake of Network Edge REST API
+// that can be used to test Network Edge client workflows without network access
+package nefake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/equinix/ne-go/internal/api"
+)
+
+const (
+	//ErrorCodeNotFound is an error code returned when requested resource does not exist
+	ErrorCodeNotFound = "EQ-FAKE-404"
+	//ErrorCodeValidation is an error code returned when request does not pass validation
+	ErrorCodeValidation = "EQ-FAKE-400"
+	//ErrorCodeDeviceRemoved is an error code returned on attempt to remove device that
+	//is deprovisioning or already deprovisioned
+	ErrorCodeDeviceRemoved = "EQ-4006103"
+
+	defaultPageLimit = 20
+)
+
+// Server is a stateful, in-process fake of Network Edge REST API serving /ne/v1/* endpoints.
+// Created resources go through the same asynchronous state transitions as on a real
+// platform, i.e. device goes from INITIALIZING through PROVISIONING to PROVISIONED.
+// Each read of a resource returns its current state and moves it to the next state,
+// no sooner than transition delay after previous transition. With zero delay, each
+// read moves resource by one state.
+type Server struct {
+	*httptest.Server
+	mu              sync.Mutex
+	transitionDelay time.Duration
+	sequence        int
+	devices         map[string]*api.Device
+	deviceACLs      map[string]*api.DeviceACLResponse
+	deviceBandwidth map[string]*api.DeviceAdditionalBandwidthResponse
+	deviceUpgrades  map[string]*api.DeviceSoftwareUpgradeResponse
+	sshUsers        map[string]*api.SSHUser
+	bgpConfigs      map[string]*api.BGPConfiguration
+	aclTemplates    map[string]*api.ACLTemplate
+	linkGroups      map[string]*api.DeviceLinkGroup
+	files           map[string]*api.File
+	fileContents    map[string][]byte
+	publicKeys      map[string]*api.SSHPublicKey
+	accounts        map[string][]api.Account
+	deviceTypes     []api.DeviceType
+	transitions     map[string]*transition
+	failures        []*injectedFailure
+	requests        []Request
+}
+
+// Failure describes error response that is returned for matching requests
+// instead of regular processing
+type Failure struct {
+	//Method is HTTP method of matching requests. Empty value matches all methods
+	Method string
+	//Path is a pattern of matching request paths, as in path.Match,
+	//i.e. /ne/v1/devices/*
+	Path string
+	//StatusCode is HTTP status code of error response
+	StatusCode int
+	//ErrorCode is application error code of error response
+	ErrorCode string
+	//ErrorMessage is application error message of error response
+	ErrorMessage string
+	//Property is a request property related with an error
+	Property string
+	//Header is additional header of error response, i.e. Retry-After
+	Header http.Header
+	//Times limits number of requests that fail. Zero means no limit
+	Times int
+}
+
+// Request describes request received by fake server
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+}
+
+type injectedFailure struct {
+	Failure
+	remaining int
+}
+
+// transition describes pending, sequential state changes of a resource
+type transition struct {
+	steps   []func()
+	readyAt time.Time
+}
+
+// NewServer creates and starts new fake Network Edge API server.
+// Server has to be closed after use
+func NewServer() *Server {
+	s := NewUnstartedServer()
+	s.Start()
+	return s
+}
+
+// NewUnstartedServer creates new fake Network Edge API server that is not started
+func NewUnstartedServer() *Server {
+	s := &Server{
+		devices:         make(map[string]*api.Device),
+		deviceACLs:      make(map[string]*api.DeviceACLResponse),
+		deviceBandwidth: make(map[string]*api.DeviceAdditionalBandwidthResponse),
+		deviceUpgrades:  make(map[string]*api.DeviceSoftwareUpgradeResponse),
+		sshUsers:        make(map[string]*api.SSHUser),
+		bgpConfigs:      make(map[string]*api.BGPConfiguration),
+		aclTemplates:    make(map[string]*api.ACLTemplate),
+		linkGroups:      make(map[string]*api.DeviceLinkGroup),
+		files:           make(map[string]*api.File),
+		fileContents:    make(map[string][]byte),
+		publicKeys:      make(map[string]*api.SSHPublicKey),
+		accounts:        make(map[string][]api.Account),
+		transitions:     make(map[string]*transition),
+	}
+	s.Server = httptest.NewUnstartedServer(s)
+	return s
+}
+
+// SetTransitionDelay sets minimum time between consecutive asynchronous
+// state transitions of a resource
+func (s *Server) SetTransitionDelay(delay time.Duration) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.transitionDelay = delay
+	return s
+}
+
+// InjectFailure registers failure that is returned for matching requests
+func (s *Server) InjectFailure(failure Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, &injectedFailure{Failure: failure, remaining: failure.Times})
+}
+
+// ClearFailures removes all registered failures
+func (s *Server) ClearFailures() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = nil
+}
+
+// Requests returns all requests received by server so far
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	requests := make([]Request, len(s.requests))
+	copy(requests, s.requests)
+	return requests
+}
+
+// ServeHTTP handles Network Edge API request
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery})
+	if s.serveFailure(w, r) {
+		return
+	}
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(segments) < 3 || segments[0] != "ne" || segments[1] != "v1" {
+		writeError(w, http.StatusNotFound, ErrorCodeNotFound, "unknown endpoint", "")
+		return
+	}
+	var handled bool
+	switch segments[2] {
+	case "devices":
+		handled = s.serveDevices(w, r, segments[3:])
+	case "sshUsers":
+		handled = s.serveSSHUsers(w, r, segments[3:])
+	case "bgp":
+		handled = s.serveBGP(w, r, segments[3:])
+	case "aclTemplates":
+		handled = s.serveACLTemplates(w, r, segments[3:])
+	case "links":
+		handled = s.serveLinkGroups(w, r, segments[3:])
+	case "files":
+		handled = s.serveFiles(w, r, segments[3:])
+	case "publicKeys":
+		handled = s.servePublicKeys(w, r, segments[3:])
+	case "accounts":
+		handled = s.serveAccounts(w, r, segments[3:])
+	case "deviceTypes":
+		handled = s.serveDeviceTypes(w, r, segments[3:])
+	}
+	if !handled {
+		writeError(w, http.StatusNotFound, ErrorCodeNotFound, "unknown endpoint", "")
+	}
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Unexported package methods
+//_______________________________________________________________________
+
+func (s *Server) serveFailure(w http.ResponseWriter, r *http.Request) bool {
+	for i, f := range s.failures {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if matched, _ := path.Match(f.Path, r.URL.Path); !matched {
+			continue
+		}
+		if f.Times > 0 {
+			f.remaining--
+			if f.remaining <= 0 {
+				s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			}
+		}
+		for k, v := range f.Header {
+			w.Header()[k] = v
+		}
+		writeError(w, f.StatusCode, f.ErrorCode, f.ErrorMessage, f.Property)
+		return true
+	}
+	return false
+}
+
+func (s *Server) newUUID(prefix string) string {
+	s.sequence++
+	return fmt.Sprintf("%s-%08d-0000-4000-8000-000000000000", prefix, s.sequence)
+}
+
+// schedule replaces pending transitions of a resource with a given key
+func (s *Server) schedule(key string, steps ...func()) {
+	if len(steps) == 0 {
+		delete(s.transitions, key)
+		return
+	}
+	s.transitions[key] = &transition{steps: steps, readyAt: time.Now().Add(s.transitionDelay)}
+}
+
+// advance applies next pending transition of a resource with a given key,
+// if transition delay has passed
+func (s *Server) advance(key string) {
+	t, ok := s.transitions[key]
+	if !ok || time.Now().Before(t.readyAt) {
+		return
+	}
+	t.steps[0]()
+	t.steps = t.steps[1:]
+	t.readyAt = time.Now().Add(s.transitionDelay)
+	if len(t.steps) == 0 {
+		delete(s.transitions, key)
+	}
+}
+
+// paginate returns bounds of requested page of a collection with a given size
+func paginate(r *http.Request, total int) (int, int, api.Pagination) {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = defaultPageLimit
+	}
+	if offset < 0 || offset > total {
+		offset = total
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	return offset, end, api.Pagination{Offset: offset, Limit: limit, Total: total}
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, target interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(target); err != nil {
+		writeError(w, http.StatusBadRequest, ErrorCodeValidation, "invalid request body: "+err.Error(), "")
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if body != nil {
+		_ = json.NewEncoder(w).Encode(body)
+	}
+}
+
+func writeCreated(w http.ResponseWriter, r *http.Request, uuid string) {
+	w.Header().Set("Location", strings.TrimSuffix(r.URL.Path, "/")+"/"+uuid)
+	w.WriteHeader(http.StatusCreated)
+}
+
+func writeError(w http.ResponseWriter, status int, code string, message string, property string) {
+	if message == "" {
+		message = http.StatusText(status)
+	}
+	writeJSON(w, status, api.ErrorResponses{{
+		ErrorCode:    code,
+		ErrorMessage: message,
+		Property:     property,
+	}})
+}
+
+func writeNotFound(w http.ResponseWriter, resourceType string, uuid string) {
+	writeError(w, http.StatusNotFound, ErrorCodeNotFound,
+		fmt.Sprintf("%s %q does not exist", resourceType, uuid), "uuid")
+}
+
+func writeRequired(w http.ResponseWriter, property string) {
+	writeError(w, http.StatusBadRequest, ErrorCodeValidation, property+" is required", property)
+}
+
+// validateRequired writes validation error for first of given properties with empty value
+func validateRequired(w http.ResponseWriter, properties []string, values []*string) bool {
+	for i := range properties {
+		if stringValue(values[i]) == "" {
+			writeRequired(w, properties[i])
+			return false
+		}
+	}
+	return true
+}
+
+func stringValue(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
+func stringPtr(v string) *string {
+	return &v
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func containsString(values []string, value string) bool {
+	for i := range values {
+		if values[i] == value {
+			return true
+		}
+	}
+	return false
+}
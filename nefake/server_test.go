@@ -0,0 +1,108 @@
+package nefake
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/equinix/ne-go"
+	"github.com/stretchr/testify/assert"
+)
+
+var testWaitOptions = ne.WaitOptions{
+	PollInterval:    time.Millisecond,
+	MaxPollInterval: 2 * time.Millisecond,
+	Timeout:         5 * time.Second,
+}
+
+func newTestClient(s *Server) *ne.RestClient {
+	return ne.NewClient(context.Background(), s.URL, s.Client())
+}
+
+func TestInjectFailure(t *testing.T) {
+	//given
+	s := NewServer()
+	defer s.Close()
+	s.InjectFailure(Failure{
+		Method:       http.MethodGet,
+		Path:         "/ne/v1/publicKeys",
+		StatusCode:   http.StatusInternalServerError,
+		ErrorCode:    "EQ-500",
+		ErrorMessage: "internal error",
+		Times:        1,
+	})
+	c := newTestClient(s)
+	//when
+	_, firstErr := c.GetSSHPublicKeys()
+	keys, secondErr := c.GetSSHPublicKeys()
+	//then
+	apiErr := ne.APIError{}
+	assert.ErrorAs(t, firstErr, &apiErr, "First request fails with APIError")
+	assert.Equal(t, http.StatusInternalServerError, apiErr.HTTPCode, "HTTP code matches")
+	assert.Equal(t, "EQ-500", apiErr.Errors[0].ErrorCode, "Error code matches")
+	assert.Nil(t, secondErr, "Second request succeeds")
+	assert.Empty(t, keys, "No keys are returned")
+}
+
+func TestInjectFailure_retried(t *testing.T) {
+	//given
+	s := NewServer()
+	defer s.Close()
+	s.InjectFailure(Failure{
+		Path:       "/ne/v1/devices/*",
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"0"}},
+		Times:      2,
+	})
+	c := newTestClient(s)
+	c.SetRetryPolicy(ne.DefaultRetryPolicy())
+	//when
+	_, err := c.GetDevice("missing")
+	//then
+	assert.True(t, ne.IsNotFound(err), "Request is retried until server returns not found")
+	assert.Equal(t, 3, len(s.Requests()), "Three requests were received")
+}
+
+func TestInjectFailure_pattern(t *testing.T) {
+	//given
+	s := NewServer()
+	defer s.Close()
+	s.InjectFailure(Failure{Method: http.MethodDelete, Path: "/ne/v1/devices/*", StatusCode: http.StatusConflict})
+	c := newTestClient(s)
+	//when
+	deleteErr := c.DeleteDevice("someDevice")
+	_, getErr := c.GetDevice("someDevice")
+	s.ClearFailures()
+	clearedErr := c.DeleteDevice("someDevice")
+	//then
+	assert.True(t, ne.IsConflict(deleteErr), "Matching request fails with injected error")
+	assert.True(t, ne.IsNotFound(getErr), "Request with different method is processed")
+	assert.True(t, ne.IsNotFound(clearedErr), "Request is processed after failures are cleared")
+}
+
+func TestServer_unknownEndpoint(t *testing.T) {
+	//given
+	s := NewServer()
+	defer s.Close()
+	//when
+	resp, err := s.Client().Get(s.URL + "/ne/v1/unknown")
+	//then
+	assert.Nil(t, err, "Request is sent")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Not found status is returned")
+	assert.Equal(t, []Request{{Method: http.MethodGet, Path: "/ne/v1/unknown"}}, s.Requests(), "Request is recorded")
+}
+
+func TestServer_transitionDelay(t *testing.T) {
+	//given
+	s := NewServer().SetTransitionDelay(time.Hour)
+	defer s.Close()
+	c := newTestClient(s)
+	uuid, err := c.CreateDevice(testDevice())
+	assert.Nil(t, err, "Device is created")
+	//when
+	device, err := c.GetDevice(ne.StringValue(uuid))
+	//then
+	assert.Nil(t, err, "Device is fetched")
+	assert.Equal(t, ne.DeviceStateInitializing, ne.StringValue(device.Status), "Device state is not changed before delay passes")
+}
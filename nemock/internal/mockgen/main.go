@@ -0,0 +1,357 @@
+// Command mockgen generates nemock package mocks from Network Edge client
+// interfaces declared in ne package source file
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// mocks lists generated mock types with interfaces they implement.
+// Methods of all listed interfaces are merged into a single mock
+var mocks = []struct {
+	name       string
+	interfaces []string
+}{
+	{"Client", []string{"Client", "ClientWithContext"}},
+	{"DeviceUpdateRequest", []string{"DeviceUpdateRequest", "DeviceUpdateRequestWithContext"}},
+	{"SSHUserUpdateRequest", []string{"SSHUserUpdateRequest", "SSHUserUpdateRequestWithContext"}},
+	{"BGPUpdateRequest", []string{"BGPUpdateRequest", "BGPUpdateRequestWithContext"}},
+	{"DeviceLinkUpdateRequest", []string{"DeviceLinkUpdateRequest", "DeviceLinkUpdateRequestWithContext"}},
+}
+
+type param struct {
+	name     string
+	typ      string
+	variadic bool
+}
+
+type method struct {
+	name    string
+	params  []param
+	results []string
+}
+
+type generator struct {
+	buf        bytes.Buffer
+	imports    map[string]bool
+	interfaces map[string]*ast.InterfaceType
+}
+
+func main() {
+	source := flag.String("source", "../client.go", "ne package source file with client interfaces")
+	output := flag.String("output", "mock.go", "generated file")
+	flag.Parse()
+	file, err := parser.ParseFile(token.NewFileSet(), *source, nil, 0)
+	if err != nil {
+		log.Fatalf("failed to parse %s: %s", *source, err)
+	}
+	g := &generator{
+		imports:    map[string]bool{"github.com/equinix/ne-go": true},
+		interfaces: make(map[string]*ast.InterfaceType),
+	}
+	ast.Inspect(file, func(n ast.Node) bool {
+		if spec, ok := n.(*ast.TypeSpec); ok {
+			if iface, ok := spec.Type.(*ast.InterfaceType); ok {
+				g.interfaces[spec.Name.Name] = iface
+			}
+		}
+		return true
+	})
+	var body bytes.Buffer
+	for _, mock := range mocks {
+		methods, err := g.methods(mock.interfaces)
+		if err != nil {
+			log.Fatal(err)
+		}
+		g.writeMock(&body, mock.name, mock.interfaces, methods)
+	}
+	g.writeHeader()
+	g.buf.Write(body.Bytes())
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		log.Fatalf("failed to format generated code: %s", err)
+	}
+	if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		log.Fatalf("failed to write %s: %s", *output, err)
+	}
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Unexported package methods
+//_______________________________________________________________________
+
+// methods collects methods of given interfaces, including embedded ones,
+// skipping duplicates
+func (g *generator) methods(names []string) ([]method, error) {
+	var methods []method
+	seen := make(map[string]bool)
+	var collect func(name string) error
+	collect = func(name string) error {
+		iface, ok := g.interfaces[name]
+		if !ok {
+			return fmt.Errorf("interface %s not found", name)
+		}
+		for _, field := range iface.Methods.List {
+			if embedded, ok := field.Type.(*ast.Ident); ok {
+				if err := collect(embedded.Name); err != nil {
+					return err
+				}
+				continue
+			}
+			fn := field.Type.(*ast.FuncType)
+			for _, n := range field.Names {
+				if seen[n.Name] {
+					continue
+				}
+				seen[n.Name] = true
+				methods = append(methods, g.method(n.Name, fn))
+			}
+		}
+		return nil
+	}
+	for _, name := range names {
+		if err := collect(name); err != nil {
+			return nil, err
+		}
+	}
+	return methods, nil
+}
+
+func (g *generator) method(name string, fn *ast.FuncType) method {
+	m := method{name: name}
+	for _, field := range fn.Params.List {
+		typ := field.Type
+		variadic := false
+		if ellipsis, ok := typ.(*ast.Ellipsis); ok {
+			typ = ellipsis.Elt
+			variadic = true
+		}
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("arg%d", len(m.params)))}
+		}
+		for _, n := range names {
+			m.params = append(m.params, param{name: n.Name, typ: g.typeString(typ), variadic: variadic})
+		}
+	}
+	if fn.Results != nil {
+		for _, field := range fn.Results.List {
+			count := len(field.Names)
+			if count == 0 {
+				count = 1
+			}
+			for i := 0; i < count; i++ {
+				m.results = append(m.results, g.typeString(field.Type))
+			}
+		}
+	}
+	return m
+}
+
+func (g *generator) typeString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if unicode.IsUpper(rune(t.Name[0])) {
+			return "ne." + t.Name
+		}
+		return t.Name
+	case *ast.StarExpr:
+		return "*" + g.typeString(t.X)
+	case *ast.ArrayType:
+		return "[]" + g.typeString(t.Elt)
+	case *ast.MapType:
+		return "map[" + g.typeString(t.Key) + "]" + g.typeString(t.Value)
+	case *ast.SelectorExpr:
+		pkg := t.X.(*ast.Ident).Name
+		g.imports[pkg] = true
+		return pkg + "." + t.Sel.Name
+	case *ast.InterfaceType:
+		return "interface{}"
+	}
+	log.Fatalf("unsupported type expression %T", expr)
+	return ""
+}
+
+func (g *generator) writeHeader() {
+	fmt.Fprintf(&g.buf, "// Code generated by mockgen from ne package interfaces. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&g.buf, "package nemock\n\nimport (\n")
+	var std, external []string
+	for imp := range g.imports {
+		if strings.Contains(imp, ".") {
+			external = append(external, imp)
+		} else {
+			std = append(std, imp)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(external)
+	for _, group := range [][]string{std, external} {
+		for _, imp := range group {
+			fmt.Fprintf(&g.buf, "\t%q\n", imp)
+		}
+		fmt.Fprintf(&g.buf, "\n")
+	}
+	fmt.Fprintf(&g.buf, ")\n\n")
+}
+
+func (g *generator) writeMock(w *bytes.Buffer, name string, interfaces []string, methods []method) {
+	iface := interfaces[len(interfaces)-1]
+	byName := make(map[string]method, len(methods))
+	for _, m := range methods {
+		byName[m.name] = m
+	}
+	fmt.Fprintf(w, "var _ ne.%s = (*%s)(nil)\n\n", iface, name)
+	fmt.Fprintf(w, "// %s is a mock implementation of ne.%s interface.\n", name, iface)
+	fmt.Fprintf(w, "// Responses are programmed by setting function fields. Context accepting methods\n")
+	fmt.Fprintf(w, "// fall back to function of their context-less variant. Methods without programmed\n")
+	fmt.Fprintf(w, "// response return zero values with ErrNotProgrammed, or new mock of a builder\n")
+	fmt.Fprintf(w, "type %s struct {\n\trecorder\n", name)
+	for _, m := range methods {
+		if g.isChained(m, interfaces) {
+			continue
+		}
+		fmt.Fprintf(w, "\t%sFunc func(%s) %s\n", m.name, g.signatureParams(m), g.signatureResults(m))
+	}
+	fmt.Fprintf(w, "}\n\n")
+	for _, m := range methods {
+		g.writeMethod(w, name, interfaces, m, byName)
+	}
+}
+
+func (g *generator) writeMethod(w *bytes.Buffer, name string, interfaces []string, m method, byName map[string]method) {
+	if g.isChained(m, interfaces) {
+		fmt.Fprintf(w, "// %s records a call and returns the mock\n", m.name)
+	} else {
+		fmt.Fprintf(w, "// %s records a call and returns programmed response\n", m.name)
+	}
+	fmt.Fprintf(w, "func (m *%s) %s(%s) %s {\n", name, m.name, g.signatureParams(m), g.signatureResults(m))
+	fmt.Fprintf(w, "\tm.record(%q%s)\n", m.name, g.recordArgs(m))
+	if g.isChained(m, interfaces) {
+		fmt.Fprintf(w, "\treturn m\n}\n\n")
+		return
+	}
+	fmt.Fprintf(w, "\tif m.%sFunc != nil {\n\t\treturn m.%sFunc(%s)\n\t}\n", m.name, m.name, g.callArgs(m.params))
+	if base, ok := g.contextlessVariant(m, byName); ok {
+		fmt.Fprintf(w, "\tif m.%sFunc != nil {\n\t\treturn m.%sFunc(%s)\n\t}\n", base.name, base.name, g.callArgs(m.params[1:]))
+	}
+	fmt.Fprintf(w, "\treturn %s\n}\n\n", g.defaultResults(m))
+}
+
+// isChained verifies if method is a builder method that returns one of interfaces
+// implemented by the mock
+func (g *generator) isChained(m method, interfaces []string) bool {
+	if len(m.results) != 1 {
+		return false
+	}
+	for _, iface := range interfaces {
+		if m.results[0] == "ne."+iface {
+			return true
+		}
+	}
+	return false
+}
+
+// contextlessVariant returns method that has the same name and parameters
+// except of WithContext suffix and leading context parameter
+func (g *generator) contextlessVariant(m method, byName map[string]method) (method, bool) {
+	if !strings.HasSuffix(m.name, "WithContext") || len(m.params) == 0 || m.params[0].typ != "context.Context" {
+		return method{}, false
+	}
+	base, ok := byName[strings.TrimSuffix(m.name, "WithContext")]
+	if !ok || len(base.params) != len(m.params)-1 || strings.Join(base.results, ",") != strings.Join(m.results, ",") {
+		return method{}, false
+	}
+	for i := range base.params {
+		if base.params[i].typ != m.params[i+1].typ || base.params[i].variadic != m.params[i+1].variadic {
+			return method{}, false
+		}
+	}
+	return base, true
+}
+
+func (g *generator) signatureParams(m method) string {
+	params := make([]string, len(m.params))
+	for i, p := range m.params {
+		if p.variadic {
+			params[i] = p.name + " ..." + p.typ
+		} else {
+			params[i] = p.name + " " + p.typ
+		}
+	}
+	return strings.Join(params, ", ")
+}
+
+func (g *generator) signatureResults(m method) string {
+	if len(m.results) < 2 {
+		return strings.Join(m.results, "")
+	}
+	return "(" + strings.Join(m.results, ", ") + ")"
+}
+
+func (g *generator) recordArgs(m method) string {
+	var args string
+	for _, p := range m.params {
+		args += ", " + p.name
+	}
+	return args
+}
+
+func (g *generator) callArgs(params []param) string {
+	args := make([]string, len(params))
+	for i, p := range params {
+		args[i] = p.name
+		if p.variadic {
+			args[i] += "..."
+		}
+	}
+	return strings.Join(args, ", ")
+}
+
+// defaultResults returns zero values of method results, with ErrNotProgrammed
+// for an error and new mock for a mocked builder interface
+func (g *generator) defaultResults(m method) string {
+	results := make([]string, len(m.results))
+	for i, r := range m.results {
+		switch {
+		case r == "error":
+			results[i] = "ErrNotProgrammed"
+		case strings.HasPrefix(r, "*") || strings.HasPrefix(r, "[]") || strings.HasPrefix(r, "map["):
+			results[i] = "nil"
+		case r == "string":
+			results[i] = `""`
+		case r == "int" || r == "int64" || r == "float64":
+			results[i] = "0"
+		case r == "bool":
+			results[i] = "false"
+		case g.mockOf(r) != "":
+			results[i] = "&" + g.mockOf(r) + "{}"
+		default:
+			results[i] = "nil"
+		}
+	}
+	return strings.Join(results, ", ")
+}
+
+// mockOf returns name of a mock that implements given interface type,
+// or empty string when interface is not mocked
+func (g *generator) mockOf(typ string) string {
+	for _, mock := range mocks {
+		for _, iface := range mock.interfaces {
+			if "ne."+iface == typ {
+				return mock.name
+			}
+		}
+	}
+	return ""
+}
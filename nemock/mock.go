@@ -0,0 +1,1226 @@
+// Code generated by mockgen from ne package interfaces. DO NOT EDIT.
+
+package nemock
+
+import (
+	"context"
+	"io"
+
+	"github.com/equinix/ne-go"
+)
+
+var _ ne.ClientWithContext = (*Client)(nil)
+
+// Client is a mock implementation of ne.ClientWithContext interface.
+// Responses are programmed by setting function fields. Context accepting methods
+// fall back to function of their context-less variant. Methods without programmed
+// response return zero values with ErrNotProgrammed, or new mock of a builder
+type Client struct {
+	recorder
+	GetAccountsFunc                                    func(metroCode string) ([]ne.Account, error)
+	GetDeviceTypesFunc                                 func() ([]ne.DeviceType, error)
+	GetDevicePlatformsFunc                             func(deviceTypeCode string) ([]ne.DevicePlatform, error)
+	GetDeviceSoftwareVersionsFunc                      func(deviceTypeCode string) ([]ne.DeviceSoftwareVersion, error)
+	CreateDeviceFunc                                   func(device ne.Device) (*string, error)
+	CreateRedundantDeviceFunc                          func(primary ne.Device, secondary ne.Device) (*string, *string, error)
+	AddSecondaryFunc                                   func(primaryUuid string, secondary ne.Device) (*string, error)
+	GetDeviceFunc                                      func(uuid string) (*ne.Device, error)
+	GetDevicesFunc                                     func(statuses []string) ([]ne.Device, error)
+	GetDeviceAdditionalBandwidthDetailsFunc            func(uuid string) (*ne.DeviceAdditionalBandwidthDetails, error)
+	GetDeviceACLDetailsFunc                            func(uuid string) (*ne.DeviceACLDetails, error)
+	NewDeviceUpdateRequestFunc                         func(uuid string) ne.DeviceUpdateRequest
+	DeleteDeviceFunc                                   func(uuid string) error
+	DeleteSecondaryDeviceFunc                          func(uuid string) error
+	CreateSSHUserFunc                                  func(username string, password string, device string) (*string, error)
+	GetSSHUsersFunc                                    func() ([]ne.SSHUser, error)
+	GetSSHUserFunc                                     func(uuid string) (*ne.SSHUser, error)
+	NewSSHUserUpdateRequestFunc                        func(uuid string) ne.SSHUserUpdateRequest
+	DeleteSSHUserFunc                                  func(uuid string) error
+	CreateBGPConfigurationFunc                         func(config ne.BGPConfiguration) (*string, error)
+	GetBGPConfigurationFunc                            func(uuid string) (*ne.BGPConfiguration, error)
+	NewBGPConfigurationUpdateRequestFunc               func(uuid string) ne.BGPUpdateRequest
+	GetBGPConfigurationForConnectionFunc               func(uuid string) (*ne.BGPConfiguration, error)
+	GetSSHPublicKeysFunc                               func() ([]ne.SSHPublicKey, error)
+	GetSSHPublicKeyFunc                                func(uuid string) (*ne.SSHPublicKey, error)
+	CreateSSHPublicKeyFunc                             func(key ne.SSHPublicKey) (*string, error)
+	DeleteSSHPublicKeyFunc                             func(uuid string) error
+	CreateACLTemplateFunc                              func(template ne.ACLTemplate) (*string, error)
+	GetACLTemplatesFunc                                func() ([]ne.ACLTemplate, error)
+	GetACLTemplateFunc                                 func(uuid string) (*ne.ACLTemplate, error)
+	ReplaceACLTemplateFunc                             func(uuid string, template ne.ACLTemplate) error
+	DeleteACLTemplateFunc                              func(uuid string) error
+	UploadLicenseFileFunc                              func(metroCode string, deviceTypeCode string, deviceManagementMode string, licenseMode string, fileName string, reader io.Reader) (*string, error)
+	UploadFileFunc                                     func(metroCode string, deviceTypeCode string, processType string, deviceManagementMode string, licenseMode string, fileName string, reader io.Reader) (*string, error)
+	GetFileFunc                                        func(uuid string) (*ne.File, error)
+	GetDeviceLinkGroupsFunc                            func() ([]ne.DeviceLinkGroup, error)
+	GetDeviceLinkGroupFunc                             func(uuid string) (*ne.DeviceLinkGroup, error)
+	CreateDeviceLinkGroupFunc                          func(linkGroup ne.DeviceLinkGroup) (*string, error)
+	NewDeviceLinkGroupUpdateRequestFunc                func(uuid string) ne.DeviceLinkUpdateRequest
+	DeleteDeviceLinkGroupFunc                          func(uuid string) error
+	GetAccountsWithContextFunc                         func(ctx context.Context, metroCode string) ([]ne.Account, error)
+	GetDeviceTypesWithContextFunc                      func(ctx context.Context) ([]ne.DeviceType, error)
+	GetDevicePlatformsWithContextFunc                  func(ctx context.Context, deviceTypeCode string) ([]ne.DevicePlatform, error)
+	GetDeviceSoftwareVersionsWithContextFunc           func(ctx context.Context, deviceTypeCode string) ([]ne.DeviceSoftwareVersion, error)
+	CreateDeviceWithContextFunc                        func(ctx context.Context, device ne.Device) (*string, error)
+	CreateRedundantDeviceWithContextFunc               func(ctx context.Context, primary ne.Device, secondary ne.Device) (*string, *string, error)
+	AddSecondaryWithContextFunc                        func(ctx context.Context, primaryUuid string, secondary ne.Device) (*string, error)
+	GetDeviceWithContextFunc                           func(ctx context.Context, uuid string) (*ne.Device, error)
+	GetDevicesWithContextFunc                          func(ctx context.Context, statuses []string) ([]ne.Device, error)
+	GetDeviceAdditionalBandwidthDetailsWithContextFunc func(ctx context.Context, uuid string) (*ne.DeviceAdditionalBandwidthDetails, error)
+	GetDeviceACLDetailsWithContextFunc                 func(ctx context.Context, uuid string) (*ne.DeviceACLDetails, error)
+	NewDeviceUpdateRequestWithContextFunc              func(uuid string) ne.DeviceUpdateRequestWithContext
+	DeleteDeviceWithContextFunc                        func(ctx context.Context, uuid string) error
+	DeleteSecondaryDeviceWithContextFunc               func(ctx context.Context, uuid string) error
+	CreateSSHUserWithContextFunc                       func(ctx context.Context, username string, password string, device string) (*string, error)
+	GetSSHUsersWithContextFunc                         func(ctx context.Context) ([]ne.SSHUser, error)
+	GetSSHUserWithContextFunc                          func(ctx context.Context, uuid string) (*ne.SSHUser, error)
+	NewSSHUserUpdateRequestWithContextFunc             func(uuid string) ne.SSHUserUpdateRequestWithContext
+	DeleteSSHUserWithContextFunc                       func(ctx context.Context, uuid string) error
+	CreateBGPConfigurationWithContextFunc              func(ctx context.Context, config ne.BGPConfiguration) (*string, error)
+	GetBGPConfigurationWithContextFunc                 func(ctx context.Context, uuid string) (*ne.BGPConfiguration, error)
+	NewBGPConfigurationUpdateRequestWithContextFunc    func(uuid string) ne.BGPUpdateRequestWithContext
+	GetBGPConfigurationForConnectionWithContextFunc    func(ctx context.Context, uuid string) (*ne.BGPConfiguration, error)
+	GetSSHPublicKeysWithContextFunc                    func(ctx context.Context) ([]ne.SSHPublicKey, error)
+	GetSSHPublicKeyWithContextFunc                     func(ctx context.Context, uuid string) (*ne.SSHPublicKey, error)
+	CreateSSHPublicKeyWithContextFunc                  func(ctx context.Context, key ne.SSHPublicKey) (*string, error)
+	DeleteSSHPublicKeyWithContextFunc                  func(ctx context.Context, uuid string) error
+	CreateACLTemplateWithContextFunc                   func(ctx context.Context, template ne.ACLTemplate) (*string, error)
+	GetACLTemplatesWithContextFunc                     func(ctx context.Context) ([]ne.ACLTemplate, error)
+	GetACLTemplateWithContextFunc                      func(ctx context.Context, uuid string) (*ne.ACLTemplate, error)
+	ReplaceACLTemplateWithContextFunc                  func(ctx context.Context, uuid string, template ne.ACLTemplate) error
+	DeleteACLTemplateWithContextFunc                   func(ctx context.Context, uuid string) error
+	UploadLicenseFileWithContextFunc                   func(ctx context.Context, metroCode string, deviceTypeCode string, deviceManagementMode string, licenseMode string, fileName string, reader io.Reader) (*string, error)
+	UploadFileWithContextFunc                          func(ctx context.Context, metroCode string, deviceTypeCode string, processType string, deviceManagementMode string, licenseMode string, fileName string, reader io.Reader) (*string, error)
+	GetFileWithContextFunc                             func(ctx context.Context, uuid string) (*ne.File, error)
+	GetDeviceLinkGroupsWithContextFunc                 func(ctx context.Context) ([]ne.DeviceLinkGroup, error)
+	GetDeviceLinkGroupWithContextFunc                  func(ctx context.Context, uuid string) (*ne.DeviceLinkGroup, error)
+	CreateDeviceLinkGroupWithContextFunc               func(ctx context.Context, linkGroup ne.DeviceLinkGroup) (*string, error)
+	NewDeviceLinkGroupUpdateRequestWithContextFunc     func(uuid string) ne.DeviceLinkUpdateRequestWithContext
+	DeleteDeviceLinkGroupWithContextFunc               func(ctx context.Context, uuid string) error
+}
+
+// GetAccounts records a call and returns programmed response
+func (m *Client) GetAccounts(metroCode string) ([]ne.Account, error) {
+	m.record("GetAccounts", metroCode)
+	if m.GetAccountsFunc != nil {
+		return m.GetAccountsFunc(metroCode)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetDeviceTypes records a call and returns programmed response
+func (m *Client) GetDeviceTypes() ([]ne.DeviceType, error) {
+	m.record("GetDeviceTypes")
+	if m.GetDeviceTypesFunc != nil {
+		return m.GetDeviceTypesFunc()
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetDevicePlatforms records a call and returns programmed response
+func (m *Client) GetDevicePlatforms(deviceTypeCode string) ([]ne.DevicePlatform, error) {
+	m.record("GetDevicePlatforms", deviceTypeCode)
+	if m.GetDevicePlatformsFunc != nil {
+		return m.GetDevicePlatformsFunc(deviceTypeCode)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetDeviceSoftwareVersions records a call and returns programmed response
+func (m *Client) GetDeviceSoftwareVersions(deviceTypeCode string) ([]ne.DeviceSoftwareVersion, error) {
+	m.record("GetDeviceSoftwareVersions", deviceTypeCode)
+	if m.GetDeviceSoftwareVersionsFunc != nil {
+		return m.GetDeviceSoftwareVersionsFunc(deviceTypeCode)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// CreateDevice records a call and returns programmed response
+func (m *Client) CreateDevice(device ne.Device) (*string, error) {
+	m.record("CreateDevice", device)
+	if m.CreateDeviceFunc != nil {
+		return m.CreateDeviceFunc(device)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// CreateRedundantDevice records a call and returns programmed response
+func (m *Client) CreateRedundantDevice(primary ne.Device, secondary ne.Device) (*string, *string, error) {
+	m.record("CreateRedundantDevice", primary, secondary)
+	if m.CreateRedundantDeviceFunc != nil {
+		return m.CreateRedundantDeviceFunc(primary, secondary)
+	}
+	return nil, nil, ErrNotProgrammed
+}
+
+// AddSecondary records a call and returns programmed response
+func (m *Client) AddSecondary(primaryUuid string, secondary ne.Device) (*string, error) {
+	m.record("AddSecondary", primaryUuid, secondary)
+	if m.AddSecondaryFunc != nil {
+		return m.AddSecondaryFunc(primaryUuid, secondary)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetDevice records a call and returns programmed response
+func (m *Client) GetDevice(uuid string) (*ne.Device, error) {
+	m.record("GetDevice", uuid)
+	if m.GetDeviceFunc != nil {
+		return m.GetDeviceFunc(uuid)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetDevices records a call and returns programmed response
+func (m *Client) GetDevices(statuses []string) ([]ne.Device, error) {
+	m.record("GetDevices", statuses)
+	if m.GetDevicesFunc != nil {
+		return m.GetDevicesFunc(statuses)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetDeviceAdditionalBandwidthDetails records a call and returns programmed response
+func (m *Client) GetDeviceAdditionalBandwidthDetails(uuid string) (*ne.DeviceAdditionalBandwidthDetails, error) {
+	m.record("GetDeviceAdditionalBandwidthDetails", uuid)
+	if m.GetDeviceAdditionalBandwidthDetailsFunc != nil {
+		return m.GetDeviceAdditionalBandwidthDetailsFunc(uuid)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetDeviceACLDetails records a call and returns programmed response
+func (m *Client) GetDeviceACLDetails(uuid string) (*ne.DeviceACLDetails, error) {
+	m.record("GetDeviceACLDetails", uuid)
+	if m.GetDeviceACLDetailsFunc != nil {
+		return m.GetDeviceACLDetailsFunc(uuid)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// NewDeviceUpdateRequest records a call and returns programmed response
+func (m *Client) NewDeviceUpdateRequest(uuid string) ne.DeviceUpdateRequest {
+	m.record("NewDeviceUpdateRequest", uuid)
+	if m.NewDeviceUpdateRequestFunc != nil {
+		return m.NewDeviceUpdateRequestFunc(uuid)
+	}
+	return &DeviceUpdateRequest{}
+}
+
+// DeleteDevice records a call and returns programmed response
+func (m *Client) DeleteDevice(uuid string) error {
+	m.record("DeleteDevice", uuid)
+	if m.DeleteDeviceFunc != nil {
+		return m.DeleteDeviceFunc(uuid)
+	}
+	return ErrNotProgrammed
+}
+
+// DeleteSecondaryDevice records a call and returns programmed response
+func (m *Client) DeleteSecondaryDevice(uuid string) error {
+	m.record("DeleteSecondaryDevice", uuid)
+	if m.DeleteSecondaryDeviceFunc != nil {
+		return m.DeleteSecondaryDeviceFunc(uuid)
+	}
+	return ErrNotProgrammed
+}
+
+// CreateSSHUser records a call and returns programmed response
+func (m *Client) CreateSSHUser(username string, password string, device string) (*string, error) {
+	m.record("CreateSSHUser", username, password, device)
+	if m.CreateSSHUserFunc != nil {
+		return m.CreateSSHUserFunc(username, password, device)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetSSHUsers records a call and returns programmed response
+func (m *Client) GetSSHUsers() ([]ne.SSHUser, error) {
+	m.record("GetSSHUsers")
+	if m.GetSSHUsersFunc != nil {
+		return m.GetSSHUsersFunc()
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetSSHUser records a call and returns programmed response
+func (m *Client) GetSSHUser(uuid string) (*ne.SSHUser, error) {
+	m.record("GetSSHUser", uuid)
+	if m.GetSSHUserFunc != nil {
+		return m.GetSSHUserFunc(uuid)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// NewSSHUserUpdateRequest records a call and returns programmed response
+func (m *Client) NewSSHUserUpdateRequest(uuid string) ne.SSHUserUpdateRequest {
+	m.record("NewSSHUserUpdateRequest", uuid)
+	if m.NewSSHUserUpdateRequestFunc != nil {
+		return m.NewSSHUserUpdateRequestFunc(uuid)
+	}
+	return &SSHUserUpdateRequest{}
+}
+
+// DeleteSSHUser records a call and returns programmed response
+func (m *Client) DeleteSSHUser(uuid string) error {
+	m.record("DeleteSSHUser", uuid)
+	if m.DeleteSSHUserFunc != nil {
+		return m.DeleteSSHUserFunc(uuid)
+	}
+	return ErrNotProgrammed
+}
+
+// CreateBGPConfiguration records a call and returns programmed response
+func (m *Client) CreateBGPConfiguration(config ne.BGPConfiguration) (*string, error) {
+	m.record("CreateBGPConfiguration", config)
+	if m.CreateBGPConfigurationFunc != nil {
+		return m.CreateBGPConfigurationFunc(config)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetBGPConfiguration records a call and returns programmed response
+func (m *Client) GetBGPConfiguration(uuid string) (*ne.BGPConfiguration, error) {
+	m.record("GetBGPConfiguration", uuid)
+	if m.GetBGPConfigurationFunc != nil {
+		return m.GetBGPConfigurationFunc(uuid)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// NewBGPConfigurationUpdateRequest records a call and returns programmed response
+func (m *Client) NewBGPConfigurationUpdateRequest(uuid string) ne.BGPUpdateRequest {
+	m.record("NewBGPConfigurationUpdateRequest", uuid)
+	if m.NewBGPConfigurationUpdateRequestFunc != nil {
+		return m.NewBGPConfigurationUpdateRequestFunc(uuid)
+	}
+	return &BGPUpdateRequest{}
+}
+
+// GetBGPConfigurationForConnection records a call and returns programmed response
+func (m *Client) GetBGPConfigurationForConnection(uuid string) (*ne.BGPConfiguration, error) {
+	m.record("GetBGPConfigurationForConnection", uuid)
+	if m.GetBGPConfigurationForConnectionFunc != nil {
+		return m.GetBGPConfigurationForConnectionFunc(uuid)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetSSHPublicKeys records a call and returns programmed response
+func (m *Client) GetSSHPublicKeys() ([]ne.SSHPublicKey, error) {
+	m.record("GetSSHPublicKeys")
+	if m.GetSSHPublicKeysFunc != nil {
+		return m.GetSSHPublicKeysFunc()
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetSSHPublicKey records a call and returns programmed response
+func (m *Client) GetSSHPublicKey(uuid string) (*ne.SSHPublicKey, error) {
+	m.record("GetSSHPublicKey", uuid)
+	if m.GetSSHPublicKeyFunc != nil {
+		return m.GetSSHPublicKeyFunc(uuid)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// CreateSSHPublicKey records a call and returns programmed response
+func (m *Client) CreateSSHPublicKey(key ne.SSHPublicKey) (*string, error) {
+	m.record("CreateSSHPublicKey", key)
+	if m.CreateSSHPublicKeyFunc != nil {
+		return m.CreateSSHPublicKeyFunc(key)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// DeleteSSHPublicKey records a call and returns programmed response
+func (m *Client) DeleteSSHPublicKey(uuid string) error {
+	m.record("DeleteSSHPublicKey", uuid)
+	if m.DeleteSSHPublicKeyFunc != nil {
+		return m.DeleteSSHPublicKeyFunc(uuid)
+	}
+	return ErrNotProgrammed
+}
+
+// CreateACLTemplate records a call and returns programmed response
+func (m *Client) CreateACLTemplate(template ne.ACLTemplate) (*string, error) {
+	m.record("CreateACLTemplate", template)
+	if m.CreateACLTemplateFunc != nil {
+		return m.CreateACLTemplateFunc(template)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetACLTemplates records a call and returns programmed response
+func (m *Client) GetACLTemplates() ([]ne.ACLTemplate, error) {
+	m.record("GetACLTemplates")
+	if m.GetACLTemplatesFunc != nil {
+		return m.GetACLTemplatesFunc()
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetACLTemplate records a call and returns programmed response
+func (m *Client) GetACLTemplate(uuid string) (*ne.ACLTemplate, error) {
+	m.record("GetACLTemplate", uuid)
+	if m.GetACLTemplateFunc != nil {
+		return m.GetACLTemplateFunc(uuid)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// ReplaceACLTemplate records a call and returns programmed response
+func (m *Client) ReplaceACLTemplate(uuid string, template ne.ACLTemplate) error {
+	m.record("ReplaceACLTemplate", uuid, template)
+	if m.ReplaceACLTemplateFunc != nil {
+		return m.ReplaceACLTemplateFunc(uuid, template)
+	}
+	return ErrNotProgrammed
+}
+
+// DeleteACLTemplate records a call and returns programmed response
+func (m *Client) DeleteACLTemplate(uuid string) error {
+	m.record("DeleteACLTemplate", uuid)
+	if m.DeleteACLTemplateFunc != nil {
+		return m.DeleteACLTemplateFunc(uuid)
+	}
+	return ErrNotProgrammed
+}
+
+// UploadLicenseFile records a call and returns programmed response
+func (m *Client) UploadLicenseFile(metroCode string, deviceTypeCode string, deviceManagementMode string, licenseMode string, fileName string, reader io.Reader) (*string, error) {
+	m.record("UploadLicenseFile", metroCode, deviceTypeCode, deviceManagementMode, licenseMode, fileName, reader)
+	if m.UploadLicenseFileFunc != nil {
+		return m.UploadLicenseFileFunc(metroCode, deviceTypeCode, deviceManagementMode, licenseMode, fileName, reader)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// UploadFile records a call and returns programmed response
+func (m *Client) UploadFile(metroCode string, deviceTypeCode string, processType string, deviceManagementMode string, licenseMode string, fileName string, reader io.Reader) (*string, error) {
+	m.record("UploadFile", metroCode, deviceTypeCode, processType, deviceManagementMode, licenseMode, fileName, reader)
+	if m.UploadFileFunc != nil {
+		return m.UploadFileFunc(metroCode, deviceTypeCode, processType, deviceManagementMode, licenseMode, fileName, reader)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetFile records a call and returns programmed response
+func (m *Client) GetFile(uuid string) (*ne.File, error) {
+	m.record("GetFile", uuid)
+	if m.GetFileFunc != nil {
+		return m.GetFileFunc(uuid)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetDeviceLinkGroups records a call and returns programmed response
+func (m *Client) GetDeviceLinkGroups() ([]ne.DeviceLinkGroup, error) {
+	m.record("GetDeviceLinkGroups")
+	if m.GetDeviceLinkGroupsFunc != nil {
+		return m.GetDeviceLinkGroupsFunc()
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetDeviceLinkGroup records a call and returns programmed response
+func (m *Client) GetDeviceLinkGroup(uuid string) (*ne.DeviceLinkGroup, error) {
+	m.record("GetDeviceLinkGroup", uuid)
+	if m.GetDeviceLinkGroupFunc != nil {
+		return m.GetDeviceLinkGroupFunc(uuid)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// CreateDeviceLinkGroup records a call and returns programmed response
+func (m *Client) CreateDeviceLinkGroup(linkGroup ne.DeviceLinkGroup) (*string, error) {
+	m.record("CreateDeviceLinkGroup", linkGroup)
+	if m.CreateDeviceLinkGroupFunc != nil {
+		return m.CreateDeviceLinkGroupFunc(linkGroup)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// NewDeviceLinkGroupUpdateRequest records a call and returns programmed response
+func (m *Client) NewDeviceLinkGroupUpdateRequest(uuid string) ne.DeviceLinkUpdateRequest {
+	m.record("NewDeviceLinkGroupUpdateRequest", uuid)
+	if m.NewDeviceLinkGroupUpdateRequestFunc != nil {
+		return m.NewDeviceLinkGroupUpdateRequestFunc(uuid)
+	}
+	return &DeviceLinkUpdateRequest{}
+}
+
+// DeleteDeviceLinkGroup records a call and returns programmed response
+func (m *Client) DeleteDeviceLinkGroup(uuid string) error {
+	m.record("DeleteDeviceLinkGroup", uuid)
+	if m.DeleteDeviceLinkGroupFunc != nil {
+		return m.DeleteDeviceLinkGroupFunc(uuid)
+	}
+	return ErrNotProgrammed
+}
+
+// GetAccountsWithContext records a call and returns programmed response
+func (m *Client) GetAccountsWithContext(ctx context.Context, metroCode string) ([]ne.Account, error) {
+	m.record("GetAccountsWithContext", ctx, metroCode)
+	if m.GetAccountsWithContextFunc != nil {
+		return m.GetAccountsWithContextFunc(ctx, metroCode)
+	}
+	if m.GetAccountsFunc != nil {
+		return m.GetAccountsFunc(metroCode)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetDeviceTypesWithContext records a call and returns programmed response
+func (m *Client) GetDeviceTypesWithContext(ctx context.Context) ([]ne.DeviceType, error) {
+	m.record("GetDeviceTypesWithContext", ctx)
+	if m.GetDeviceTypesWithContextFunc != nil {
+		return m.GetDeviceTypesWithContextFunc(ctx)
+	}
+	if m.GetDeviceTypesFunc != nil {
+		return m.GetDeviceTypesFunc()
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetDevicePlatformsWithContext records a call and returns programmed response
+func (m *Client) GetDevicePlatformsWithContext(ctx context.Context, deviceTypeCode string) ([]ne.DevicePlatform, error) {
+	m.record("GetDevicePlatformsWithContext", ctx, deviceTypeCode)
+	if m.GetDevicePlatformsWithContextFunc != nil {
+		return m.GetDevicePlatformsWithContextFunc(ctx, deviceTypeCode)
+	}
+	if m.GetDevicePlatformsFunc != nil {
+		return m.GetDevicePlatformsFunc(deviceTypeCode)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetDeviceSoftwareVersionsWithContext records a call and returns programmed response
+func (m *Client) GetDeviceSoftwareVersionsWithContext(ctx context.Context, deviceTypeCode string) ([]ne.DeviceSoftwareVersion, error) {
+	m.record("GetDeviceSoftwareVersionsWithContext", ctx, deviceTypeCode)
+	if m.GetDeviceSoftwareVersionsWithContextFunc != nil {
+		return m.GetDeviceSoftwareVersionsWithContextFunc(ctx, deviceTypeCode)
+	}
+	if m.GetDeviceSoftwareVersionsFunc != nil {
+		return m.GetDeviceSoftwareVersionsFunc(deviceTypeCode)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// CreateDeviceWithContext records a call and returns programmed response
+func (m *Client) CreateDeviceWithContext(ctx context.Context, device ne.Device) (*string, error) {
+	m.record("CreateDeviceWithContext", ctx, device)
+	if m.CreateDeviceWithContextFunc != nil {
+		return m.CreateDeviceWithContextFunc(ctx, device)
+	}
+	if m.CreateDeviceFunc != nil {
+		return m.CreateDeviceFunc(device)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// CreateRedundantDeviceWithContext records a call and returns programmed response
+func (m *Client) CreateRedundantDeviceWithContext(ctx context.Context, primary ne.Device, secondary ne.Device) (*string, *string, error) {
+	m.record("CreateRedundantDeviceWithContext", ctx, primary, secondary)
+	if m.CreateRedundantDeviceWithContextFunc != nil {
+		return m.CreateRedundantDeviceWithContextFunc(ctx, primary, secondary)
+	}
+	if m.CreateRedundantDeviceFunc != nil {
+		return m.CreateRedundantDeviceFunc(primary, secondary)
+	}
+	return nil, nil, ErrNotProgrammed
+}
+
+// AddSecondaryWithContext records a call and returns programmed response
+func (m *Client) AddSecondaryWithContext(ctx context.Context, primaryUuid string, secondary ne.Device) (*string, error) {
+	m.record("AddSecondaryWithContext", ctx, primaryUuid, secondary)
+	if m.AddSecondaryWithContextFunc != nil {
+		return m.AddSecondaryWithContextFunc(ctx, primaryUuid, secondary)
+	}
+	if m.AddSecondaryFunc != nil {
+		return m.AddSecondaryFunc(primaryUuid, secondary)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetDeviceWithContext records a call and returns programmed response
+func (m *Client) GetDeviceWithContext(ctx context.Context, uuid string) (*ne.Device, error) {
+	m.record("GetDeviceWithContext", ctx, uuid)
+	if m.GetDeviceWithContextFunc != nil {
+		return m.GetDeviceWithContextFunc(ctx, uuid)
+	}
+	if m.GetDeviceFunc != nil {
+		return m.GetDeviceFunc(uuid)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetDevicesWithContext records a call and returns programmed response
+func (m *Client) GetDevicesWithContext(ctx context.Context, statuses []string) ([]ne.Device, error) {
+	m.record("GetDevicesWithContext", ctx, statuses)
+	if m.GetDevicesWithContextFunc != nil {
+		return m.GetDevicesWithContextFunc(ctx, statuses)
+	}
+	if m.GetDevicesFunc != nil {
+		return m.GetDevicesFunc(statuses)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetDeviceAdditionalBandwidthDetailsWithContext records a call and returns programmed response
+func (m *Client) GetDeviceAdditionalBandwidthDetailsWithContext(ctx context.Context, uuid string) (*ne.DeviceAdditionalBandwidthDetails, error) {
+	m.record("GetDeviceAdditionalBandwidthDetailsWithContext", ctx, uuid)
+	if m.GetDeviceAdditionalBandwidthDetailsWithContextFunc != nil {
+		return m.GetDeviceAdditionalBandwidthDetailsWithContextFunc(ctx, uuid)
+	}
+	if m.GetDeviceAdditionalBandwidthDetailsFunc != nil {
+		return m.GetDeviceAdditionalBandwidthDetailsFunc(uuid)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetDeviceACLDetailsWithContext records a call and returns programmed response
+func (m *Client) GetDeviceACLDetailsWithContext(ctx context.Context, uuid string) (*ne.DeviceACLDetails, error) {
+	m.record("GetDeviceACLDetailsWithContext", ctx, uuid)
+	if m.GetDeviceACLDetailsWithContextFunc != nil {
+		return m.GetDeviceACLDetailsWithContextFunc(ctx, uuid)
+	}
+	if m.GetDeviceACLDetailsFunc != nil {
+		return m.GetDeviceACLDetailsFunc(uuid)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// NewDeviceUpdateRequestWithContext records a call and returns programmed response
+func (m *Client) NewDeviceUpdateRequestWithContext(uuid string) ne.DeviceUpdateRequestWithContext {
+	m.record("NewDeviceUpdateRequestWithContext", uuid)
+	if m.NewDeviceUpdateRequestWithContextFunc != nil {
+		return m.NewDeviceUpdateRequestWithContextFunc(uuid)
+	}
+	return &DeviceUpdateRequest{}
+}
+
+// DeleteDeviceWithContext records a call and returns programmed response
+func (m *Client) DeleteDeviceWithContext(ctx context.Context, uuid string) error {
+	m.record("DeleteDeviceWithContext", ctx, uuid)
+	if m.DeleteDeviceWithContextFunc != nil {
+		return m.DeleteDeviceWithContextFunc(ctx, uuid)
+	}
+	if m.DeleteDeviceFunc != nil {
+		return m.DeleteDeviceFunc(uuid)
+	}
+	return ErrNotProgrammed
+}
+
+// DeleteSecondaryDeviceWithContext records a call and returns programmed response
+func (m *Client) DeleteSecondaryDeviceWithContext(ctx context.Context, uuid string) error {
+	m.record("DeleteSecondaryDeviceWithContext", ctx, uuid)
+	if m.DeleteSecondaryDeviceWithContextFunc != nil {
+		return m.DeleteSecondaryDeviceWithContextFunc(ctx, uuid)
+	}
+	if m.DeleteSecondaryDeviceFunc != nil {
+		return m.DeleteSecondaryDeviceFunc(uuid)
+	}
+	return ErrNotProgrammed
+}
+
+// CreateSSHUserWithContext records a call and returns programmed response
+func (m *Client) CreateSSHUserWithContext(ctx context.Context, username string, password string, device string) (*string, error) {
+	m.record("CreateSSHUserWithContext", ctx, username, password, device)
+	if m.CreateSSHUserWithContextFunc != nil {
+		return m.CreateSSHUserWithContextFunc(ctx, username, password, device)
+	}
+	if m.CreateSSHUserFunc != nil {
+		return m.CreateSSHUserFunc(username, password, device)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetSSHUsersWithContext records a call and returns programmed response
+func (m *Client) GetSSHUsersWithContext(ctx context.Context) ([]ne.SSHUser, error) {
+	m.record("GetSSHUsersWithContext", ctx)
+	if m.GetSSHUsersWithContextFunc != nil {
+		return m.GetSSHUsersWithContextFunc(ctx)
+	}
+	if m.GetSSHUsersFunc != nil {
+		return m.GetSSHUsersFunc()
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetSSHUserWithContext records a call and returns programmed response
+func (m *Client) GetSSHUserWithContext(ctx context.Context, uuid string) (*ne.SSHUser, error) {
+	m.record("GetSSHUserWithContext", ctx, uuid)
+	if m.GetSSHUserWithContextFunc != nil {
+		return m.GetSSHUserWithContextFunc(ctx, uuid)
+	}
+	if m.GetSSHUserFunc != nil {
+		return m.GetSSHUserFunc(uuid)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// NewSSHUserUpdateRequestWithContext records a call and returns programmed response
+func (m *Client) NewSSHUserUpdateRequestWithContext(uuid string) ne.SSHUserUpdateRequestWithContext {
+	m.record("NewSSHUserUpdateRequestWithContext", uuid)
+	if m.NewSSHUserUpdateRequestWithContextFunc != nil {
+		return m.NewSSHUserUpdateRequestWithContextFunc(uuid)
+	}
+	return &SSHUserUpdateRequest{}
+}
+
+// DeleteSSHUserWithContext records a call and returns programmed response
+func (m *Client) DeleteSSHUserWithContext(ctx context.Context, uuid string) error {
+	m.record("DeleteSSHUserWithContext", ctx, uuid)
+	if m.DeleteSSHUserWithContextFunc != nil {
+		return m.DeleteSSHUserWithContextFunc(ctx, uuid)
+	}
+	if m.DeleteSSHUserFunc != nil {
+		return m.DeleteSSHUserFunc(uuid)
+	}
+	return ErrNotProgrammed
+}
+
+// CreateBGPConfigurationWithContext records a call and returns programmed response
+func (m *Client) CreateBGPConfigurationWithContext(ctx context.Context, config ne.BGPConfiguration) (*string, error) {
+	m.record("CreateBGPConfigurationWithContext", ctx, config)
+	if m.CreateBGPConfigurationWithContextFunc != nil {
+		return m.CreateBGPConfigurationWithContextFunc(ctx, config)
+	}
+	if m.CreateBGPConfigurationFunc != nil {
+		return m.CreateBGPConfigurationFunc(config)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetBGPConfigurationWithContext records a call and returns programmed response
+func (m *Client) GetBGPConfigurationWithContext(ctx context.Context, uuid string) (*ne.BGPConfiguration, error) {
+	m.record("GetBGPConfigurationWithContext", ctx, uuid)
+	if m.GetBGPConfigurationWithContextFunc != nil {
+		return m.GetBGPConfigurationWithContextFunc(ctx, uuid)
+	}
+	if m.GetBGPConfigurationFunc != nil {
+		return m.GetBGPConfigurationFunc(uuid)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// NewBGPConfigurationUpdateRequestWithContext records a call and returns programmed response
+func (m *Client) NewBGPConfigurationUpdateRequestWithContext(uuid string) ne.BGPUpdateRequestWithContext {
+	m.record("NewBGPConfigurationUpdateRequestWithContext", uuid)
+	if m.NewBGPConfigurationUpdateRequestWithContextFunc != nil {
+		return m.NewBGPConfigurationUpdateRequestWithContextFunc(uuid)
+	}
+	return &BGPUpdateRequest{}
+}
+
+// GetBGPConfigurationForConnectionWithContext records a call and returns programmed response
+func (m *Client) GetBGPConfigurationForConnectionWithContext(ctx context.Context, uuid string) (*ne.BGPConfiguration, error) {
+	m.record("GetBGPConfigurationForConnectionWithContext", ctx, uuid)
+	if m.GetBGPConfigurationForConnectionWithContextFunc != nil {
+		return m.GetBGPConfigurationForConnectionWithContextFunc(ctx, uuid)
+	}
+	if m.GetBGPConfigurationForConnectionFunc != nil {
+		return m.GetBGPConfigurationForConnectionFunc(uuid)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetSSHPublicKeysWithContext records a call and returns programmed response
+func (m *Client) GetSSHPublicKeysWithContext(ctx context.Context) ([]ne.SSHPublicKey, error) {
+	m.record("GetSSHPublicKeysWithContext", ctx)
+	if m.GetSSHPublicKeysWithContextFunc != nil {
+		return m.GetSSHPublicKeysWithContextFunc(ctx)
+	}
+	if m.GetSSHPublicKeysFunc != nil {
+		return m.GetSSHPublicKeysFunc()
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetSSHPublicKeyWithContext records a call and returns programmed response
+func (m *Client) GetSSHPublicKeyWithContext(ctx context.Context, uuid string) (*ne.SSHPublicKey, error) {
+	m.record("GetSSHPublicKeyWithContext", ctx, uuid)
+	if m.GetSSHPublicKeyWithContextFunc != nil {
+		return m.GetSSHPublicKeyWithContextFunc(ctx, uuid)
+	}
+	if m.GetSSHPublicKeyFunc != nil {
+		return m.GetSSHPublicKeyFunc(uuid)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// CreateSSHPublicKeyWithContext records a call and returns programmed response
+func (m *Client) CreateSSHPublicKeyWithContext(ctx context.Context, key ne.SSHPublicKey) (*string, error) {
+	m.record("CreateSSHPublicKeyWithContext", ctx, key)
+	if m.CreateSSHPublicKeyWithContextFunc != nil {
+		return m.CreateSSHPublicKeyWithContextFunc(ctx, key)
+	}
+	if m.CreateSSHPublicKeyFunc != nil {
+		return m.CreateSSHPublicKeyFunc(key)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// DeleteSSHPublicKeyWithContext records a call and returns programmed response
+func (m *Client) DeleteSSHPublicKeyWithContext(ctx context.Context, uuid string) error {
+	m.record("DeleteSSHPublicKeyWithContext", ctx, uuid)
+	if m.DeleteSSHPublicKeyWithContextFunc != nil {
+		return m.DeleteSSHPublicKeyWithContextFunc(ctx, uuid)
+	}
+	if m.DeleteSSHPublicKeyFunc != nil {
+		return m.DeleteSSHPublicKeyFunc(uuid)
+	}
+	return ErrNotProgrammed
+}
+
+// CreateACLTemplateWithContext records a call and returns programmed response
+func (m *Client) CreateACLTemplateWithContext(ctx context.Context, template ne.ACLTemplate) (*string, error) {
+	m.record("CreateACLTemplateWithContext", ctx, template)
+	if m.CreateACLTemplateWithContextFunc != nil {
+		return m.CreateACLTemplateWithContextFunc(ctx, template)
+	}
+	if m.CreateACLTemplateFunc != nil {
+		return m.CreateACLTemplateFunc(template)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetACLTemplatesWithContext records a call and returns programmed response
+func (m *Client) GetACLTemplatesWithContext(ctx context.Context) ([]ne.ACLTemplate, error) {
+	m.record("GetACLTemplatesWithContext", ctx)
+	if m.GetACLTemplatesWithContextFunc != nil {
+		return m.GetACLTemplatesWithContextFunc(ctx)
+	}
+	if m.GetACLTemplatesFunc != nil {
+		return m.GetACLTemplatesFunc()
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetACLTemplateWithContext records a call and returns programmed response
+func (m *Client) GetACLTemplateWithContext(ctx context.Context, uuid string) (*ne.ACLTemplate, error) {
+	m.record("GetACLTemplateWithContext", ctx, uuid)
+	if m.GetACLTemplateWithContextFunc != nil {
+		return m.GetACLTemplateWithContextFunc(ctx, uuid)
+	}
+	if m.GetACLTemplateFunc != nil {
+		return m.GetACLTemplateFunc(uuid)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// ReplaceACLTemplateWithContext records a call and returns programmed response
+func (m *Client) ReplaceACLTemplateWithContext(ctx context.Context, uuid string, template ne.ACLTemplate) error {
+	m.record("ReplaceACLTemplateWithContext", ctx, uuid, template)
+	if m.ReplaceACLTemplateWithContextFunc != nil {
+		return m.ReplaceACLTemplateWithContextFunc(ctx, uuid, template)
+	}
+	if m.ReplaceACLTemplateFunc != nil {
+		return m.ReplaceACLTemplateFunc(uuid, template)
+	}
+	return ErrNotProgrammed
+}
+
+// DeleteACLTemplateWithContext records a call and returns programmed response
+func (m *Client) DeleteACLTemplateWithContext(ctx context.Context, uuid string) error {
+	m.record("DeleteACLTemplateWithContext", ctx, uuid)
+	if m.DeleteACLTemplateWithContextFunc != nil {
+		return m.DeleteACLTemplateWithContextFunc(ctx, uuid)
+	}
+	if m.DeleteACLTemplateFunc != nil {
+		return m.DeleteACLTemplateFunc(uuid)
+	}
+	return ErrNotProgrammed
+}
+
+// UploadLicenseFileWithContext records a call and returns programmed response
+func (m *Client) UploadLicenseFileWithContext(ctx context.Context, metroCode string, deviceTypeCode string, deviceManagementMode string, licenseMode string, fileName string, reader io.Reader) (*string, error) {
+	m.record("UploadLicenseFileWithContext", ctx, metroCode, deviceTypeCode, deviceManagementMode, licenseMode, fileName, reader)
+	if m.UploadLicenseFileWithContextFunc != nil {
+		return m.UploadLicenseFileWithContextFunc(ctx, metroCode, deviceTypeCode, deviceManagementMode, licenseMode, fileName, reader)
+	}
+	if m.UploadLicenseFileFunc != nil {
+		return m.UploadLicenseFileFunc(metroCode, deviceTypeCode, deviceManagementMode, licenseMode, fileName, reader)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// UploadFileWithContext records a call and returns programmed response
+func (m *Client) UploadFileWithContext(ctx context.Context, metroCode string, deviceTypeCode string, processType string, deviceManagementMode string, licenseMode string, fileName string, reader io.Reader) (*string, error) {
+	m.record("UploadFileWithContext", ctx, metroCode, deviceTypeCode, processType, deviceManagementMode, licenseMode, fileName, reader)
+	if m.UploadFileWithContextFunc != nil {
+		return m.UploadFileWithContextFunc(ctx, metroCode, deviceTypeCode, processType, deviceManagementMode, licenseMode, fileName, reader)
+	}
+	if m.UploadFileFunc != nil {
+		return m.UploadFileFunc(metroCode, deviceTypeCode, processType, deviceManagementMode, licenseMode, fileName, reader)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetFileWithContext records a call and returns programmed response
+func (m *Client) GetFileWithContext(ctx context.Context, uuid string) (*ne.File, error) {
+	m.record("GetFileWithContext", ctx, uuid)
+	if m.GetFileWithContextFunc != nil {
+		return m.GetFileWithContextFunc(ctx, uuid)
+	}
+	if m.GetFileFunc != nil {
+		return m.GetFileFunc(uuid)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetDeviceLinkGroupsWithContext records a call and returns programmed response
+func (m *Client) GetDeviceLinkGroupsWithContext(ctx context.Context) ([]ne.DeviceLinkGroup, error) {
+	m.record("GetDeviceLinkGroupsWithContext", ctx)
+	if m.GetDeviceLinkGroupsWithContextFunc != nil {
+		return m.GetDeviceLinkGroupsWithContextFunc(ctx)
+	}
+	if m.GetDeviceLinkGroupsFunc != nil {
+		return m.GetDeviceLinkGroupsFunc()
+	}
+	return nil, ErrNotProgrammed
+}
+
+// GetDeviceLinkGroupWithContext records a call and returns programmed response
+func (m *Client) GetDeviceLinkGroupWithContext(ctx context.Context, uuid string) (*ne.DeviceLinkGroup, error) {
+	m.record("GetDeviceLinkGroupWithContext", ctx, uuid)
+	if m.GetDeviceLinkGroupWithContextFunc != nil {
+		return m.GetDeviceLinkGroupWithContextFunc(ctx, uuid)
+	}
+	if m.GetDeviceLinkGroupFunc != nil {
+		return m.GetDeviceLinkGroupFunc(uuid)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// CreateDeviceLinkGroupWithContext records a call and returns programmed response
+func (m *Client) CreateDeviceLinkGroupWithContext(ctx context.Context, linkGroup ne.DeviceLinkGroup) (*string, error) {
+	m.record("CreateDeviceLinkGroupWithContext", ctx, linkGroup)
+	if m.CreateDeviceLinkGroupWithContextFunc != nil {
+		return m.CreateDeviceLinkGroupWithContextFunc(ctx, linkGroup)
+	}
+	if m.CreateDeviceLinkGroupFunc != nil {
+		return m.CreateDeviceLinkGroupFunc(linkGroup)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// NewDeviceLinkGroupUpdateRequestWithContext records a call and returns programmed response
+func (m *Client) NewDeviceLinkGroupUpdateRequestWithContext(uuid string) ne.DeviceLinkUpdateRequestWithContext {
+	m.record("NewDeviceLinkGroupUpdateRequestWithContext", uuid)
+	if m.NewDeviceLinkGroupUpdateRequestWithContextFunc != nil {
+		return m.NewDeviceLinkGroupUpdateRequestWithContextFunc(uuid)
+	}
+	return &DeviceLinkUpdateRequest{}
+}
+
+// DeleteDeviceLinkGroupWithContext records a call and returns programmed response
+func (m *Client) DeleteDeviceLinkGroupWithContext(ctx context.Context, uuid string) error {
+	m.record("DeleteDeviceLinkGroupWithContext", ctx, uuid)
+	if m.DeleteDeviceLinkGroupWithContextFunc != nil {
+		return m.DeleteDeviceLinkGroupWithContextFunc(ctx, uuid)
+	}
+	if m.DeleteDeviceLinkGroupFunc != nil {
+		return m.DeleteDeviceLinkGroupFunc(uuid)
+	}
+	return ErrNotProgrammed
+}
+
+var _ ne.DeviceUpdateRequestWithContext = (*DeviceUpdateRequest)(nil)
+
+// DeviceUpdateRequest is a mock implementation of ne.DeviceUpdateRequestWithContext interface.
+// Responses are programmed by setting function fields. Context accepting methods
+// fall back to function of their context-less variant. Methods without programmed
+// response return zero values with ErrNotProgrammed, or new mock of a builder
+type DeviceUpdateRequest struct {
+	recorder
+	ExecuteFunc             func() error
+	ExecuteWithContextFunc  func(ctx context.Context) error
+	ExecuteWithResultFunc   func(ctx context.Context, opts *ne.UpdateOptions) (*ne.UpdateResult, error)
+	ExecuteWithRollbackFunc func(ctx context.Context) (*ne.DeviceUpdateReport, error)
+}
+
+// WithDeviceName records a call and returns the mock
+func (m *DeviceUpdateRequest) WithDeviceName(deviceName string) ne.DeviceUpdateRequest {
+	m.record("WithDeviceName", deviceName)
+	return m
+}
+
+// WithTermLength records a call and returns the mock
+func (m *DeviceUpdateRequest) WithTermLength(termLength int) ne.DeviceUpdateRequest {
+	m.record("WithTermLength", termLength)
+	return m
+}
+
+// WithNotifications records a call and returns the mock
+func (m *DeviceUpdateRequest) WithNotifications(notifications []string) ne.DeviceUpdateRequest {
+	m.record("WithNotifications", notifications)
+	return m
+}
+
+// WithCore records a call and returns the mock
+func (m *DeviceUpdateRequest) WithCore(core int) ne.DeviceUpdateRequest {
+	m.record("WithCore", core)
+	return m
+}
+
+// WithAdditionalBandwidth records a call and returns the mock
+func (m *DeviceUpdateRequest) WithAdditionalBandwidth(additionalBandwidth int) ne.DeviceUpdateRequest {
+	m.record("WithAdditionalBandwidth", additionalBandwidth)
+	return m
+}
+
+// WithACLTemplate records a call and returns the mock
+func (m *DeviceUpdateRequest) WithACLTemplate(templateID string) ne.DeviceUpdateRequest {
+	m.record("WithACLTemplate", templateID)
+	return m
+}
+
+// WithMgmtAclTemplate records a call and returns the mock
+func (m *DeviceUpdateRequest) WithMgmtAclTemplate(mgmtAclTemplateUuid string) ne.DeviceUpdateRequest {
+	m.record("WithMgmtAclTemplate", mgmtAclTemplateUuid)
+	return m
+}
+
+// WithClusterName records a call and returns the mock
+func (m *DeviceUpdateRequest) WithClusterName(clusterName string) ne.DeviceUpdateRequest {
+	m.record("WithClusterName", clusterName)
+	return m
+}
+
+// Execute records a call and returns programmed response
+func (m *DeviceUpdateRequest) Execute() error {
+	m.record("Execute")
+	if m.ExecuteFunc != nil {
+		return m.ExecuteFunc()
+	}
+	return ErrNotProgrammed
+}
+
+// ExecuteWithContext records a call and returns programmed response
+func (m *DeviceUpdateRequest) ExecuteWithContext(ctx context.Context) error {
+	m.record("ExecuteWithContext", ctx)
+	if m.ExecuteWithContextFunc != nil {
+		return m.ExecuteWithContextFunc(ctx)
+	}
+	if m.ExecuteFunc != nil {
+		return m.ExecuteFunc()
+	}
+	return ErrNotProgrammed
+}
+
+// ExecuteWithResult records a call and returns programmed response
+func (m *DeviceUpdateRequest) ExecuteWithResult(ctx context.Context, opts *ne.UpdateOptions) (*ne.UpdateResult, error) {
+	m.record("ExecuteWithResult", ctx, opts)
+	if m.ExecuteWithResultFunc != nil {
+		return m.ExecuteWithResultFunc(ctx, opts)
+	}
+	return nil, ErrNotProgrammed
+}
+
+// ExecuteWithRollback records a call and returns programmed response
+func (m *DeviceUpdateRequest) ExecuteWithRollback(ctx context.Context) (*ne.DeviceUpdateReport, error) {
+	m.record("ExecuteWithRollback", ctx)
+	if m.ExecuteWithRollbackFunc != nil {
+		return m.ExecuteWithRollbackFunc(ctx)
+	}
+	return nil, ErrNotProgrammed
+}
+
+var _ ne.SSHUserUpdateRequestWithContext = (*SSHUserUpdateRequest)(nil)
+
+// SSHUserUpdateRequest is a mock implementation of ne.SSHUserUpdateRequestWithContext interface.
+// Responses are programmed by setting function fields. Context accepting methods
+// fall back to function of their context-less variant. Methods without programmed
+// response return zero values with ErrNotProgrammed, or new mock of a builder
+type SSHUserUpdateRequest struct {
+	recorder
+	ExecuteFunc            func() error
+	ExecuteWithContextFunc func(ctx context.Context) error
+	ExecuteWithResultFunc  func(ctx context.Context, opts *ne.UpdateOptions) (*ne.UpdateResult, error)
+}
+
+// WithNewPassword records a call and returns the mock
+func (m *SSHUserUpdateRequest) WithNewPassword(password string) ne.SSHUserUpdateRequest {
+	m.record("WithNewPassword", password)
+	return m
+}
+
+// WithDeviceChange records a call and returns the mock
+func (m *SSHUserUpdateRequest) WithDeviceChange(old []string, new []string) ne.SSHUserUpdateRequest {
+	m.record("WithDeviceChange", old, new)
+	return m
+}
+
+// Execute records a call and returns programmed response
+func (m *SSHUserUpdateRequest) Execute() error {
+	m.record("Execute")
+	if m.ExecuteFunc != nil {
+		return m.ExecuteFunc()
+	}
+	return ErrNotProgrammed
+}
+
+// ExecuteWithContext records a call and returns programmed response
+func (m *SSHUserUpdateRequest) ExecuteWithContext(ctx context.Context) error {
+	m.record("ExecuteWithContext", ctx)
+	if m.ExecuteWithContextFunc != nil {
+		return m.ExecuteWithContextFunc(ctx)
+	}
+	if m.ExecuteFunc != nil {
+		return m.ExecuteFunc()
+	}
+	return ErrNotProgrammed
+}
+
+// ExecuteWithResult records a call and returns programmed response
+func (m *SSHUserUpdateRequest) ExecuteWithResult(ctx context.Context, opts *ne.UpdateOptions) (*ne.UpdateResult, error) {
+	m.record("ExecuteWithResult", ctx, opts)
+	if m.ExecuteWithResultFunc != nil {
+		return m.ExecuteWithResultFunc(ctx, opts)
+	}
+	return nil, ErrNotProgrammed
+}
+
+var _ ne.BGPUpdateRequestWithContext = (*BGPUpdateRequest)(nil)
+
+// BGPUpdateRequest is a mock implementation of ne.BGPUpdateRequestWithContext interface.
+// Responses are programmed by setting function fields. Context accepting methods
+// fall back to function of their context-less variant. Methods without programmed
+// response return zero values with ErrNotProgrammed, or new mock of a builder
+type BGPUpdateRequest struct {
+	recorder
+	ExecuteFunc            func() error
+	ExecuteWithContextFunc func(ctx context.Context) error
+}
+
+// WithLocalIPAddress records a call and returns the mock
+func (m *BGPUpdateRequest) WithLocalIPAddress(localIPAddress string) ne.BGPUpdateRequest {
+	m.record("WithLocalIPAddress", localIPAddress)
+	return m
+}
+
+// WithLocalASN records a call and returns the mock
+func (m *BGPUpdateRequest) WithLocalASN(localASN int) ne.BGPUpdateRequest {
+	m.record("WithLocalASN", localASN)
+	return m
+}
+
+// WithRemoteASN records a call and returns the mock
+func (m *BGPUpdateRequest) WithRemoteASN(remoteASN int) ne.BGPUpdateRequest {
+	m.record("WithRemoteASN", remoteASN)
+	return m
+}
+
+// WithRemoteIPAddress records a call and returns the mock
+func (m *BGPUpdateRequest) WithRemoteIPAddress(remoteIPAddress string) ne.BGPUpdateRequest {
+	m.record("WithRemoteIPAddress", remoteIPAddress)
+	return m
+}
+
+// WithAuthenticationKey records a call and returns the mock
+func (m *BGPUpdateRequest) WithAuthenticationKey(authenticationKey string) ne.BGPUpdateRequest {
+	m.record("WithAuthenticationKey", authenticationKey)
+	return m
+}
+
+// Execute records a call and returns programmed response
+func (m *BGPUpdateRequest) Execute() error {
+	m.record("Execute")
+	if m.ExecuteFunc != nil {
+		return m.ExecuteFunc()
+	}
+	return ErrNotProgrammed
+}
+
+// ExecuteWithContext records a call and returns programmed response
+func (m *BGPUpdateRequest) ExecuteWithContext(ctx context.Context) error {
+	m.record("ExecuteWithContext", ctx)
+	if m.ExecuteWithContextFunc != nil {
+		return m.ExecuteWithContextFunc(ctx)
+	}
+	if m.ExecuteFunc != nil {
+		return m.ExecuteFunc()
+	}
+	return ErrNotProgrammed
+}
+
+var _ ne.DeviceLinkUpdateRequestWithContext = (*DeviceLinkUpdateRequest)(nil)
+
+// DeviceLinkUpdateRequest is a mock implementation of ne.DeviceLinkUpdateRequestWithContext interface.
+// Responses are programmed by setting function fields. Context accepting methods
+// fall back to function of their context-less variant. Methods without programmed
+// response return zero values with ErrNotProgrammed, or new mock of a builder
+type DeviceLinkUpdateRequest struct {
+	recorder
+	ExecuteFunc            func() error
+	ExecuteWithContextFunc func(ctx context.Context) error
+	ExecuteWithResultFunc  func(ctx context.Context, opts *ne.UpdateOptions) (*ne.UpdateResult, error)
+}
+
+// WithGroupName records a call and returns the mock
+func (m *DeviceLinkUpdateRequest) WithGroupName(name string) ne.DeviceLinkUpdateRequest {
+	m.record("WithGroupName", name)
+	return m
+}
+
+// WithSubnet records a call and returns the mock
+func (m *DeviceLinkUpdateRequest) WithSubnet(subnet string) ne.DeviceLinkUpdateRequest {
+	m.record("WithSubnet", subnet)
+	return m
+}
+
+// WithDevices records a call and returns the mock
+func (m *DeviceLinkUpdateRequest) WithDevices(devices []ne.DeviceLinkGroupDevice) ne.DeviceLinkUpdateRequest {
+	m.record("WithDevices", devices)
+	return m
+}
+
+// WithLinks records a call and returns the mock
+func (m *DeviceLinkUpdateRequest) WithLinks(links []ne.DeviceLinkGroupLink) ne.DeviceLinkUpdateRequest {
+	m.record("WithLinks", links)
+	return m
+}
+
+// WithMetroLinks records a call and returns the mock
+func (m *DeviceLinkUpdateRequest) WithMetroLinks(metroLinks []ne.DeviceLinkGroupMetroLink) ne.DeviceLinkUpdateRequest {
+	m.record("WithMetroLinks", metroLinks)
+	return m
+}
+
+// WithRedundancyType records a call and returns the mock
+func (m *DeviceLinkUpdateRequest) WithRedundancyType(redundancyType string) ne.DeviceLinkUpdateRequest {
+	m.record("WithRedundancyType", redundancyType)
+	return m
+}
+
+// Execute records a call and returns programmed response
+func (m *DeviceLinkUpdateRequest) Execute() error {
+	m.record("Execute")
+	if m.ExecuteFunc != nil {
+		return m.ExecuteFunc()
+	}
+	return ErrNotProgrammed
+}
+
+// ExecuteWithContext records a call and returns programmed response
+func (m *DeviceLinkUpdateRequest) ExecuteWithContext(ctx context.Context) error {
+	m.record("ExecuteWithContext", ctx)
+	if m.ExecuteWithContextFunc != nil {
+		return m.ExecuteWithContextFunc(ctx)
+	}
+	if m.ExecuteFunc != nil {
+		return m.ExecuteFunc()
+	}
+	return ErrNotProgrammed
+}
+
+// ExecuteWithResult records a call and returns programmed response
+func (m *DeviceLinkUpdateRequest) ExecuteWithResult(ctx context.Context, opts *ne.UpdateOptions) (*ne.UpdateResult, error) {
+	m.record("ExecuteWithResult", ctx, opts)
+	if m.ExecuteWithResultFunc != nil {
+		return m.ExecuteWithResultFunc(ctx, opts)
+	}
+	return nil, ErrNotProgrammed
+}
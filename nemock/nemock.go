@@ -0,0 +1,70 @@
+// Package nemock provides mock implementations of Network Edge client interfaces.
+// Mocks record all calls and return responses programmed with per-method
+// function fields. Mocks are generated from ne package interfaces, so they are
+// kept in sync whenever a method is added to the client.
+package nemock
+
+//go:generate go run ./internal/mockgen -source ../client.go -output mock.go
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrNotProgrammed is returned by mocked methods that do not have response
+// function set
+var ErrNotProgrammed = errors.New("nemock: method response is not programmed")
+
+// Call describes single invocation of a mocked method
+type Call struct {
+	//Method is a name of invoked method
+	Method string
+	//Args are values of method arguments, in declaration order
+	Args []interface{}
+}
+
+// recorder keeps track of calls made to a mock. It is safe for concurrent use
+type recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Calls returns all recorded calls, in invocation order
+func (r *recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	calls := make([]Call, len(r.calls))
+	copy(calls, r.calls)
+	return calls
+}
+
+// CallsTo returns recorded calls of a method with a given name, in invocation order
+func (r *recorder) CallsTo(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var calls []Call
+	for _, call := range r.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// CallCount returns number of recorded calls of a method with a given name
+func (r *recorder) CallCount(method string) int {
+	return len(r.CallsTo(method))
+}
+
+// ResetCalls removes all recorded calls
+func (r *recorder) ResetCalls() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
+
+func (r *recorder) record(method string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
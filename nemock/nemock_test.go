@@ -0,0 +1,88 @@
+package nemock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/equinix/ne-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_programmedResponse(t *testing.T) {
+	//given
+	device := &ne.Device{UUID: ne.String("deviceUUID")}
+	client := &Client{
+		GetDeviceFunc: func(uuid string) (*ne.Device, error) {
+			return device, nil
+		},
+	}
+	//when
+	result, err := client.GetDevice("deviceUUID")
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, device, result, "Programmed device is returned")
+	assert.Equal(t, []Call{{Method: "GetDevice", Args: []interface{}{"deviceUUID"}}}, client.Calls(), "Call is recorded")
+}
+
+func TestClient_contextFallback(t *testing.T) {
+	//given
+	ctx := context.Background()
+	expected := errors.New("failed")
+	client := &Client{
+		DeleteDeviceFunc: func(uuid string) error {
+			return expected
+		},
+	}
+	//when
+	err := client.DeleteDeviceWithContext(ctx, "deviceUUID")
+	//then
+	assert.Equal(t, expected, err, "Context-less variant response is returned")
+	assert.Equal(t, 1, client.CallCount("DeleteDeviceWithContext"), "Context call is recorded")
+	assert.Equal(t, 0, client.CallCount("DeleteDevice"), "Context-less call is not recorded")
+}
+
+func TestClient_notProgrammed(t *testing.T) {
+	//given
+	client := &Client{}
+	//when
+	primary, secondary, err := client.CreateRedundantDevice(ne.Device{}, ne.Device{})
+	//then
+	assert.Nil(t, primary, "Primary UUID is not returned")
+	assert.Nil(t, secondary, "Secondary UUID is not returned")
+	assert.True(t, errors.Is(err, ErrNotProgrammed), "ErrNotProgrammed is returned")
+}
+
+func TestDeviceUpdateRequest(t *testing.T) {
+	//given
+	req := &DeviceUpdateRequest{
+		ExecuteFunc: func() error {
+			return nil
+		},
+	}
+	client := &Client{
+		NewDeviceUpdateRequestWithContextFunc: func(uuid string) ne.DeviceUpdateRequestWithContext {
+			return req
+		},
+	}
+	//when
+	update := client.NewDeviceUpdateRequestWithContext("deviceUUID")
+	update.WithDeviceName("name").WithCore(4)
+	err := update.ExecuteWithContext(context.Background())
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, []interface{}{"name"}, req.CallsTo("WithDeviceName")[0].Args, "Device name call is recorded")
+	assert.Equal(t, []interface{}{4}, req.CallsTo("WithCore")[0].Args, "Core call is recorded")
+	assert.Equal(t, 1, req.CallCount("ExecuteWithContext"), "Execute call is recorded")
+	req.ResetCalls()
+	assert.Empty(t, req.Calls(), "Calls are reset")
+}
+
+func TestClient_defaultBuilder(t *testing.T) {
+	//given
+	client := &Client{}
+	//when
+	err := client.NewSSHUserUpdateRequest("userUUID").WithNewPassword("secret").Execute()
+	//then
+	assert.True(t, errors.Is(err, ErrNotProgrammed), "ErrNotProgrammed is returned")
+}
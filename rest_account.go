@@ -1,6 +1,7 @@
 package ne
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 
@@ -9,11 +10,23 @@ import (
 
 // GetAccounts retrieves accounts and their details for a given metro code using Network Edge API
 func (c RestClient) GetAccounts(metroCode string) ([]Account, error) {
+	return c.GetAccountsWithContext(c.ctx, metroCode)
+}
+
+// GetAccountsWithContext retrieves accounts and their details for a given metro code
+// using Network Edge API and given context
+func (c RestClient) GetAccountsWithContext(ctx context.Context, metroCode string) ([]Account, error) {
 	path := "/ne/v1/accounts/" + url.PathEscape(metroCode)
 	respBody := api.AccountResponse{}
-	req := c.R().SetResult(&respBody)
-
-	if err := c.Execute(req, http.MethodGet, path); err != nil {
+	err := c.cached(ctx, path, &respBody, func(ctx context.Context) (interface{}, error) {
+		fetched := api.AccountResponse{}
+		req := c.R().SetResult(&fetched)
+		if err := c.execute(ctx, req, http.MethodGet, path); err != nil {
+			return nil, err
+		}
+		return fetched, nil
+	})
+	if err != nil {
 		return nil, err
 	}
 	return mapAccountsAPIToDomain(respBody.Accounts), nil
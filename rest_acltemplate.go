@@ -1,6 +1,7 @@
 package ne
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 
@@ -11,10 +12,22 @@ import (
 // CreateACLTemplate creates new ACL template with a given model
 // On successful creation, template's UUID is returned
 func (c RestClient) CreateACLTemplate(template ACLTemplate) (*string, error) {
+	return c.CreateACLTemplateWithContext(c.ctx, template)
+}
+
+// CreateACLTemplateWithContext creates new ACL template with a given model using given context
+// On successful creation, template's UUID is returned. UUID of existing template is returned,
+// when idempotent creates were enabled with SetIdempotentCreates
+func (c RestClient) CreateACLTemplateWithContext(ctx context.Context, template ACLTemplate) (*string, error) {
+	if c.idempotentCreates {
+		if uuid, err := c.findACLTemplate(ctx, template); err != nil || uuid != nil {
+			return uuid, err
+		}
+	}
 	path := "/ne/v1/aclTemplates"
 	reqBody := mapACLTemplateDomainToAPI(template)
 	req := c.R().SetBody(&reqBody)
-	resp, err := c.Do(http.MethodPost, path, req)
+	resp, err := c.do(ctx, http.MethodPost, path, req)
 	if err != nil {
 		return nil, err
 	}
@@ -27,8 +40,13 @@ func (c RestClient) CreateACLTemplate(template ACLTemplate) (*string, error) {
 
 // GetACLTemplates retrieves list of all ACL templates along with their details
 func (c RestClient) GetACLTemplates() ([]ACLTemplate, error) {
+	return c.GetACLTemplatesWithContext(c.ctx)
+}
+
+// GetACLTemplatesWithContext retrieves list of all ACL templates along with their details using given context
+func (c RestClient) GetACLTemplatesWithContext(ctx context.Context) ([]ACLTemplate, error) {
 	path := "/ne/v1/aclTemplates"
-	content, err := c.GetOffsetPaginated(path, &api.ACLTemplatesResponse{},
+	content, err := c.getOffsetPaginated(ctx, path, &api.ACLTemplatesResponse{},
 		rest.DefaultOffsetPagingConfig())
 	if err != nil {
 		return nil, err
@@ -42,10 +60,15 @@ func (c RestClient) GetACLTemplates() ([]ACLTemplate, error) {
 
 // GetACLTemplate retrieves ACL template with a given UUID
 func (c RestClient) GetACLTemplate(uuid string) (*ACLTemplate, error) {
+	return c.GetACLTemplateWithContext(c.ctx, uuid)
+}
+
+// GetACLTemplateWithContext retrieves ACL template with a given UUID using given context
+func (c RestClient) GetACLTemplateWithContext(ctx context.Context, uuid string) (*ACLTemplate, error) {
 	path := "/ne/v1/aclTemplates/" + url.PathEscape(uuid)
 	respBody := api.ACLTemplate{}
 	req := c.R().SetResult(&respBody)
-	if err := c.Execute(req, http.MethodGet, path); err != nil {
+	if err := c.execute(ctx, req, http.MethodGet, path); err != nil {
 		return nil, err
 	}
 	template := mapACLTemplateAPIToDomain(respBody)
@@ -55,6 +78,12 @@ func (c RestClient) GetACLTemplate(uuid string) (*ACLTemplate, error) {
 // ReplaceACLTemplate replaces ACL template under given UUID with
 // a new one with a given model
 func (c RestClient) ReplaceACLTemplate(uuid string, template ACLTemplate) error {
+	return c.ReplaceACLTemplateWithContext(c.ctx, uuid, template)
+}
+
+// ReplaceACLTemplateWithContext replaces ACL template under given UUID with
+// a new one with a given model using given context
+func (c RestClient) ReplaceACLTemplateWithContext(ctx context.Context, uuid string, template ACLTemplate) error {
 	path := "/ne/v1/aclTemplates/" + url.PathEscape(uuid)
 	updateTemplate := ACLTemplate{
 		Name:         template.Name,
@@ -64,7 +93,7 @@ func (c RestClient) ReplaceACLTemplate(uuid string, template ACLTemplate) error
 	}
 	reqBody := mapACLTemplateDomainToAPI(updateTemplate)
 	req := c.R().SetBody(&reqBody)
-	if err := c.Execute(req, http.MethodPut, path); err != nil {
+	if err := c.execute(ctx, req, http.MethodPut, path); err != nil {
 		return err
 	}
 	return nil
@@ -72,8 +101,13 @@ func (c RestClient) ReplaceACLTemplate(uuid string, template ACLTemplate) error
 
 // DeleteACLTemplate removes ACL template with a given UUID
 func (c RestClient) DeleteACLTemplate(uuid string) error {
+	return c.DeleteACLTemplateWithContext(c.ctx, uuid)
+}
+
+// DeleteACLTemplateWithContext removes ACL template with a given UUID using given context
+func (c RestClient) DeleteACLTemplateWithContext(ctx context.Context, uuid string) error {
 	path := "/ne/v1/aclTemplates/" + url.PathEscape(uuid)
-	if err := c.Execute(c.R(), http.MethodDelete, path); err != nil {
+	if err := c.execute(ctx, c.R(), http.MethodDelete, path); err != nil {
 		return err
 	}
 	return nil
@@ -1,10 +1,12 @@
 package ne
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 
 	"github.com/equinix/ne-go/internal/api"
+	"github.com/equinix/rest-go"
 )
 
 type restBGPConfigurationUpdateRequest struct {
@@ -21,11 +23,18 @@ type restBGPConfigurationUpdateRequest struct {
 //with a given model. Configuration's UUID is returned on successful
 //creation
 func (c RestClient) CreateBGPConfiguration(config BGPConfiguration) (*string, error) {
+	return c.CreateBGPConfigurationWithContext(c.ctx, config)
+}
+
+//CreateBGPConfigurationWithContext creates new Network Edge BGP configuration
+//with a given model using given context. Configuration's UUID is returned
+//on successful creation
+func (c RestClient) CreateBGPConfigurationWithContext(ctx context.Context, config BGPConfiguration) (*string, error) {
 	path := "/ne/v1/bgp"
 	reqBody := mapBGPConfigurationDomainToAPI(config)
 	respBody := api.BGPConfigurationCreateResponse{}
 	req := c.R().SetBody(&reqBody).SetResult(&respBody)
-	if err := c.Execute(req, http.MethodPost, path); err != nil {
+	if err := c.execute(ctx, req, http.MethodPost, path); err != nil {
 		return nil, err
 	}
 	return respBody.UUID, nil
@@ -33,10 +42,15 @@ func (c RestClient) CreateBGPConfiguration(config BGPConfiguration) (*string, er
 
 //GetBGPConfiguration retrieves BGP configuration with a given UUID
 func (c RestClient) GetBGPConfiguration(uuid string) (*BGPConfiguration, error) {
+	return c.GetBGPConfigurationWithContext(c.ctx, uuid)
+}
+
+//GetBGPConfigurationWithContext retrieves BGP configuration with a given UUID using given context
+func (c RestClient) GetBGPConfigurationWithContext(ctx context.Context, uuid string) (*BGPConfiguration, error) {
 	path := "/ne/v1/bgp/" + url.PathEscape(uuid)
 	respBody := api.BGPConfiguration{}
 	req := c.R().SetResult(&respBody)
-	if err := c.Execute(req, http.MethodGet, path); err != nil {
+	if err := c.execute(ctx, req, http.MethodGet, path); err != nil {
 		return nil, err
 	}
 	return mapBGPConfigurationAPIToDomain(respBody), nil
@@ -45,18 +59,47 @@ func (c RestClient) GetBGPConfiguration(uuid string) (*BGPConfiguration, error)
 //GetBGPConfigurationForConnection retreive BGP configuration for
 //a connection with a given connection UUID
 func (c RestClient) GetBGPConfigurationForConnection(uuid string) (*BGPConfiguration, error) {
+	return c.GetBGPConfigurationForConnectionWithContext(c.ctx, uuid)
+}
+
+//GetBGPConfigurationForConnectionWithContext retreive BGP configuration for
+//a connection with a given connection UUID using given context
+func (c RestClient) GetBGPConfigurationForConnectionWithContext(ctx context.Context, uuid string) (*BGPConfiguration, error) {
 	path := "/ne/v1/bgp/connection/" + url.PathEscape(uuid)
 	respBody := api.BGPConfiguration{}
 	req := c.R().SetResult(&respBody)
-	if err := c.Execute(req, http.MethodGet, path); err != nil {
+	if err := c.execute(ctx, req, http.MethodGet, path); err != nil {
 		return nil, err
 	}
 	return mapBGPConfigurationAPIToDomain(respBody), nil
 }
 
+//GetDeviceBGPConfigurations retrieves list of BGP configurations of a device
+//with a given UUID using given context
+func (c RestClient) GetDeviceBGPConfigurations(ctx context.Context, deviceUUID string) ([]BGPConfiguration, error) {
+	path := "/ne/v1/bgp"
+	content, err := c.getOffsetPaginated(ctx, path, &api.BGPConfigurationsResponse{},
+		rest.DefaultOffsetPagingConfig().
+			SetAdditionalParams(map[string]string{"virtualDeviceUuid": deviceUUID}))
+	if err != nil {
+		return nil, err
+	}
+	transformed := make([]BGPConfiguration, len(content))
+	for i := range content {
+		transformed[i] = *mapBGPConfigurationAPIToDomain(content[i].(api.BGPConfiguration))
+	}
+	return transformed, nil
+}
+
 //NewBGPConfigurationUpdateRequest creates new BGP configuration update
 //request for a configuration with given UUID
 func (c RestClient) NewBGPConfigurationUpdateRequest(uuid string) BGPUpdateRequest {
+	return c.NewBGPConfigurationUpdateRequestWithContext(uuid)
+}
+
+//NewBGPConfigurationUpdateRequestWithContext creates new BGP configuration update request
+//for a configuration with given UUID, that can be executed with per-call context
+func (c RestClient) NewBGPConfigurationUpdateRequestWithContext(uuid string) BGPUpdateRequestWithContext {
 	return &restBGPConfigurationUpdateRequest{
 		uuid: uuid,
 		c:    c,
@@ -89,6 +132,10 @@ func (req *restBGPConfigurationUpdateRequest) WithAuthenticationKey(authenticati
 }
 
 func (req *restBGPConfigurationUpdateRequest) Execute() error {
+	return req.ExecuteWithContext(req.c.ctx)
+}
+
+func (req *restBGPConfigurationUpdateRequest) ExecuteWithContext(ctx context.Context) error {
 	path := "/ne/v1/bgp/" + url.PathEscape(req.uuid)
 	reqBody := api.BGPConfiguration{
 		LocalIPAddress:    req.localIPAddress,
@@ -99,7 +146,7 @@ func (req *restBGPConfigurationUpdateRequest) Execute() error {
 	}
 	respBody := api.BGPConfigurationCreateResponse{}
 	restReq := req.c.R().SetBody(&reqBody).SetResult(&respBody)
-	if err := req.c.Execute(restReq, http.MethodPut, path); err != nil {
+	if err := req.c.execute(ctx, restReq, http.MethodPut, path); err != nil {
 		return err
 	}
 	return nil
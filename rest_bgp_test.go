@@ -92,6 +92,39 @@ func TestGetBGPConfigurationForConnection(t *testing.T) {
 	verifyBGPConfig(t, *bgpConf, resp)
 }
 
+func TestGetDeviceBGPConfigurations(t *testing.T) {
+	//given
+	resp := api.BGPConfiguration{}
+	if err := readJSONData("./test-fixtures/ne_bgp_get_resp.json", &resp); err != nil {
+		assert.Fail(t, "Cannot read test response")
+	}
+	deviceID := "myDevice"
+	var deviceParam string
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/bgp", baseURL),
+		func(r *http.Request) (*http.Response, error) {
+			deviceParam = r.URL.Query().Get("virtualDeviceUuid")
+			return httpmock.NewJsonResponse(200, api.BGPConfigurationsResponse{
+				Pagination: api.Pagination{Total: 1},
+				Data:       []api.BGPConfiguration{resp},
+			})
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	c.PageSize = 10
+	configs, err := c.GetDeviceBGPConfigurations(context.Background(), deviceID)
+
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, deviceID, deviceParam, "Device UUID query parameter matches")
+	assert.Equal(t, 1, len(configs), "Number of BGP configurations matches")
+	verifyBGPConfig(t, configs[0], resp)
+}
+
 func TestUpdateBGPConfiguration(t *testing.T) {
 	//given
 	resp := api.BGPConfigurationCreateResponse{}
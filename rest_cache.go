@@ -0,0 +1,310 @@
+package ne
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is time for which cached responses are valid, when
+// cache options do not set it
+const DefaultCacheTTL = time.Hour
+
+// DefaultCacheFetchTimeout is time after which shared fetch of a missing entry
+// is cancelled, when cache options do not set it
+const DefaultCacheFetchTimeout = time.Minute
+
+// CacheOptions describes read-through cache of rarely changing Network Edge data
+type CacheOptions struct {
+	//TTL is time for which cached responses are valid
+	TTL time.Duration
+	//Path is a file where cached responses are persisted, so that they survive
+	//process restarts. Responses are kept in memory only, when path is empty
+	Path string
+	//FetchTimeout limits time of a fetch shared by concurrent callers, which
+	//is not cancelled together with context of any of them
+	FetchTimeout time.Duration
+}
+
+// Cache is a read-through cache of device types and accounts. Concurrent requests
+// for a missing entry are deduplicated, so that only one of them reaches the API.
+// Shared request is not cancelled when context of any single caller is done;
+// such caller stops waiting for it instead. Shared request is cancelled after
+// fetch timeout. Cache is safe for concurrent use and can be shared by many clients
+type Cache struct {
+	ttl          time.Duration
+	fetchTimeout time.Duration
+	path         string
+	now          func() time.Time
+	mu           sync.Mutex
+	entries      map[string]cacheEntry
+	calls        map[string]*cacheCall
+	//version is incremented on every change of entries
+	version uint64
+	//generation is incremented on every invalidation, so that fetches
+	//started before it do not store their outdated data
+	generation uint64
+	//fileMu guards writes of cache file, along with written version
+	fileMu  sync.Mutex
+	written uint64
+}
+
+// NewCache creates new cache with given options. When options have path set,
+// entries persisted in a given file are loaded. Missing file is not an error
+func NewCache(opts CacheOptions) (*Cache, error) {
+	cache := &Cache{
+		ttl:          opts.TTL,
+		fetchTimeout: opts.FetchTimeout,
+		path:         opts.Path,
+		now:          time.Now,
+		entries:      make(map[string]cacheEntry),
+		calls:        make(map[string]*cacheCall),
+	}
+	if cache.ttl <= 0 {
+		cache.ttl = DefaultCacheTTL
+	}
+	if cache.fetchTimeout <= 0 {
+		cache.fetchTimeout = DefaultCacheFetchTimeout
+	}
+	if cache.path == "" {
+		return cache, nil
+	}
+	data, err := ioutil.ReadFile(cache.path)
+	if os.IsNotExist(err) {
+		return cache, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &cache.entries); err != nil {
+		return nil, err
+	}
+	return cache, nil
+}
+
+// SetCache sets cache used by device type and account queries: GetDeviceTypes,
+// GetDevicePlatforms, GetDeviceSoftwareVersions, GetCatalog and GetAccounts.
+// Nil cache disables caching
+func (c *RestClient) SetCache(cache *Cache) *RestClient {
+	c.cache = cache
+	return c
+}
+
+// Clear removes all cached entries
+func (c *Cache) Clear() {
+	c.invalidate(func(key string) bool { return true })
+}
+
+// InvalidateDeviceTypes removes cached device types, along with their
+// platforms and software versions
+func (c *Cache) InvalidateDeviceTypes() {
+	c.invalidate(func(key string) bool {
+		return strings.Contains(key, "/ne/v1/deviceTypes")
+	})
+}
+
+// InvalidateAccounts removes cached accounts of given metros. Accounts of all
+// metros are removed when no metro code is given
+func (c *Cache) InvalidateAccounts(metroCodes ...string) {
+	c.invalidate(func(key string) bool {
+		if len(metroCodes) == 0 {
+			return strings.Contains(key, "/ne/v1/accounts/")
+		}
+		for _, metroCode := range metroCodes {
+			if strings.HasSuffix(key, "/ne/v1/accounts/"+url.PathEscape(metroCode)) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Unexported package methods
+//_______________________________________________________________________
+
+type cacheEntry struct {
+	Data    json.RawMessage `json:"data"`
+	Expires time.Time       `json:"expires"`
+}
+
+//cacheCall is a fetch of a missing entry that concurrent requests wait for.
+//Done channel is closed when fetch completes. Generation is a cache generation
+//at the time fetch was started
+type cacheCall struct {
+	done       chan struct{}
+	data       []byte
+	err        error
+	generation uint64
+}
+
+//detachedContext carries values of its parent, but is not cancelled with it and
+//has no deadline, so that fetch shared by many callers outlives any of them
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+func (ctx detachedContext) Value(key interface{}) interface{} {
+	return ctx.parent.Value(key)
+}
+
+//cached loads response under a given path into result. When cache is not set,
+//or has no valid entry, given function is used to fetch response, of result's
+//element type. Paths are prefixed with client's base URL, so that cache can be
+//shared by clients of different environments
+func (c RestClient) cached(ctx context.Context, path string, result interface{}, fetch func(ctx context.Context) (interface{}, error)) error {
+	if c.cache == nil {
+		fetched, err := fetch(ctx)
+		if err != nil {
+			return err
+		}
+		reflect.ValueOf(result).Elem().Set(reflect.ValueOf(fetched))
+		return nil
+	}
+	data, err := c.cache.load(ctx, c.baseURL+path, func(ctx context.Context) ([]byte, error) {
+		fetched, err := fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(fetched)
+	})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+//load returns data of a valid entry with a given key. Missing or expired entry is
+//fetched with a given function, once for all concurrent callers, and stored.
+//Fetch gets context detached from the caller that started it, limited with fetch
+//timeout. Each caller waits for the fetch until its own context is done
+func (c *Cache) load(ctx context.Context, key string, fetch func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	c.mu.Lock()
+	if entry, ok := c.entries[key]; ok && c.now().Before(entry.Expires) {
+		c.mu.Unlock()
+		return entry.Data, nil
+	}
+	call, ok := c.calls[key]
+	if !ok {
+		call = &cacheCall{done: make(chan struct{}), generation: c.generation}
+		c.calls[key] = call
+		go c.fetch(detachedContext{parent: ctx}, key, call, fetch)
+	}
+	c.mu.Unlock()
+	select {
+	case <-call.done:
+		return call.data, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+//fetch runs given call and stores fetched data under a given key, unless cache
+//was invalidated while fetch was running
+func (c *Cache) fetch(ctx context.Context, key string, call *cacheCall, fetch func(ctx context.Context) ([]byte, error)) {
+	ctx, cancel := context.WithTimeout(ctx, c.fetchTimeout)
+	call.data, call.err = fetch(ctx)
+	cancel()
+	c.mu.Lock()
+	if c.calls[key] == call {
+		delete(c.calls, key)
+	}
+	var snapshot []byte
+	var version uint64
+	if call.err == nil && call.generation == c.generation {
+		c.entries[key] = cacheEntry{Data: call.data, Expires: c.now().Add(c.ttl)}
+		snapshot, version = c.snapshot()
+	}
+	c.mu.Unlock()
+	c.persist(snapshot, version)
+	close(call.done)
+}
+
+//invalidate removes matching entries. Matching fetches that are in progress are
+//detached, so that next request for their key starts a new fetch
+func (c *Cache) invalidate(matches func(key string) bool) {
+	c.mu.Lock()
+	c.generation++
+	for key := range c.entries {
+		if matches(key) {
+			delete(c.entries, key)
+		}
+	}
+	for key := range c.calls {
+		if matches(key) {
+			delete(c.calls, key)
+		}
+	}
+	snapshot, version := c.snapshot()
+	c.mu.Unlock()
+	c.persist(snapshot, version)
+}
+
+//snapshot returns encoded valid entries, or nil when cache is not persisted,
+//along with a new version of entries. It has to be called with mu held
+func (c *Cache) snapshot() ([]byte, uint64) {
+	if c.path == "" {
+		return nil, 0
+	}
+	c.version++
+	valid := make(map[string]cacheEntry, len(c.entries))
+	for key, entry := range c.entries {
+		if c.now().Before(entry.Expires) {
+			valid[key] = entry
+		}
+	}
+	data, err := json.Marshal(valid)
+	if err != nil {
+		return nil, 0
+	}
+	return data, c.version
+}
+
+//persist writes given snapshot to cache file, unless newer version was written
+//already. Cache is best effort, so failed write does not fail a request; entries
+//are fetched again by next process instead
+func (c *Cache) persist(snapshot []byte, version uint64) {
+	if snapshot == nil {
+		return
+	}
+	c.fileMu.Lock()
+	defer c.fileMu.Unlock()
+	if version <= c.written {
+		return
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(c.path), filepath.Base(c.path)+".*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(snapshot)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	c.written = version
+}
@@ -0,0 +1,220 @@
+package ne
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/equinix/ne-go/internal/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache_concurrentMisses(t *testing.T) {
+	//given
+	resp := api.AccountResponse{}
+	if err := readJSONData("./test-fixtures/ne_accounts.json", &resp); err != nil {
+		assert.Fail(t, "Cannot read test response")
+	}
+	metro := "SV"
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/accounts/%s", baseURL, metro),
+		func(r *http.Request) (*http.Response, error) {
+			time.Sleep(50 * time.Millisecond)
+			return httpmock.NewJsonResponse(200, resp)
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+	cache, _ := NewCache(CacheOptions{})
+	c := NewClient(context.Background(), baseURL, testHc).SetCache(cache)
+	//when
+	var wg sync.WaitGroup
+	results := make([][]Account, 5)
+	errs := make([]error, len(results))
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = c.GetAccountsWithContext(context.Background(), metro)
+		}(i)
+	}
+	wg.Wait()
+	//then
+	assert.Equal(t, 1, httpmock.GetTotalCallCount(), "Accounts are fetched once")
+	for i := range results {
+		assert.Nil(t, errs[i], "Error is not returned")
+		assert.Equal(t, len(resp.Accounts), len(results[i]), "Number of accounts matches")
+	}
+}
+
+func TestCache_cancelledCaller(t *testing.T) {
+	//given
+	resp := api.AccountResponse{}
+	if err := readJSONData("./test-fixtures/ne_accounts.json", &resp); err != nil {
+		assert.Fail(t, "Cannot read test response")
+	}
+	metro := "SV"
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/accounts/%s", baseURL, metro),
+		func(r *http.Request) (*http.Response, error) {
+			close(started)
+			<-release
+			return httpmock.NewJsonResponse(200, resp)
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+	cache, _ := NewCache(CacheOptions{})
+	c := NewClient(context.Background(), baseURL, testHc).SetCache(cache)
+	ctx, cancel := context.WithCancel(context.Background())
+	//when
+	cancelledErr := make(chan error)
+	go func() {
+		_, err := c.GetAccountsWithContext(ctx, metro)
+		cancelledErr <- err
+	}()
+	<-started
+	cancel()
+	firstErr := <-cancelledErr
+	close(release)
+	accounts, err := c.GetAccountsWithContext(context.Background(), metro)
+	//then
+	assert.Equal(t, context.Canceled, firstErr, "Cancelled caller stops waiting")
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, len(resp.Accounts), len(accounts), "Number of accounts matches")
+	assert.Equal(t, 1, httpmock.GetTotalCallCount(), "Shared fetch is not cancelled")
+}
+
+func TestCache_fetchTimeout(t *testing.T) {
+	//given
+	metro := "SV"
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/accounts/%s", baseURL, metro),
+		func(r *http.Request) (*http.Response, error) {
+			<-r.Context().Done()
+			return nil, r.Context().Err()
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+	cache, _ := NewCache(CacheOptions{FetchTimeout: 10 * time.Millisecond})
+	c := NewClient(context.Background(), baseURL, testHc).SetCache(cache)
+	//when
+	_, err := c.GetAccountsWithContext(context.Background(), metro)
+	//then
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "Shared fetch is cancelled after fetch timeout")
+	assert.Empty(t, cache.calls, "Timed out fetch is not pending")
+}
+
+func TestCache_invalidationDuringFetch(t *testing.T) {
+	//given
+	resp := api.AccountResponse{}
+	if err := readJSONData("./test-fixtures/ne_accounts.json", &resp); err != nil {
+		assert.Fail(t, "Cannot read test response")
+	}
+	metro := "SV"
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/accounts/%s", baseURL, metro),
+		func(r *http.Request) (*http.Response, error) {
+			started <- struct{}{}
+			<-release
+			return httpmock.NewJsonResponse(200, resp)
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+	cache, _ := NewCache(CacheOptions{})
+	c := NewClient(context.Background(), baseURL, testHc).SetCache(cache)
+	//when
+	fetchErr := make(chan error)
+	go func() {
+		_, err := c.GetAccountsWithContext(context.Background(), metro)
+		fetchErr <- err
+	}()
+	<-started
+	cache.InvalidateAccounts(metro)
+	close(release)
+	firstErr := <-fetchErr
+	_, err := c.GetAccountsWithContext(context.Background(), metro)
+	//then
+	assert.Nil(t, firstErr, "Error is not returned")
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, 2, httpmock.GetTotalCallCount(), "Data fetched before invalidation is not stored")
+}
+
+func TestCache_expirationAndInvalidation(t *testing.T) {
+	//given
+	respBody := api.DeviceTypeResponse{}
+	if err := readJSONData("./test-fixtures/ne_devices_types_csr1000v_get.json", &respBody); err != nil {
+		assert.Failf(t, "cannot read test response due to %s", err.Error())
+	}
+	limit := respBody.Pagination.Limit
+	deviceTypeCode := "CSR1000V"
+	testHc := setupMockedClient("GET", fmt.Sprintf("%s/ne/v1/deviceTypes?deviceTypeCode=%s&limit=%d", baseURL, deviceTypeCode, limit), 200, respBody)
+	defer httpmock.DeactivateAndReset()
+	now := time.Now()
+	cache, _ := NewCache(CacheOptions{TTL: time.Minute})
+	cache.now = func() time.Time { return now }
+	c := NewClient(context.Background(), baseURL, testHc).SetCache(cache)
+	c.PageSize = limit
+	//when
+	_, platformsErr := c.GetDevicePlatforms(deviceTypeCode)
+	_, versionsErr := c.GetDeviceSoftwareVersions(deviceTypeCode)
+	cachedCalls := httpmock.GetTotalCallCount()
+	now = now.Add(2 * time.Minute)
+	_, expiredErr := c.GetDevicePlatforms(deviceTypeCode)
+	expiredCalls := httpmock.GetTotalCallCount()
+	cache.InvalidateDeviceTypes()
+	_, invalidatedErr := c.GetDevicePlatforms(deviceTypeCode)
+	//then
+	assert.Nil(t, platformsErr, "Error is not returned")
+	assert.Nil(t, versionsErr, "Error is not returned")
+	assert.Nil(t, expiredErr, "Error is not returned")
+	assert.Nil(t, invalidatedErr, "Error is not returned")
+	assert.Equal(t, 1, cachedCalls, "Device type is fetched once")
+	assert.Equal(t, 2, expiredCalls, "Expired device type is fetched again")
+	assert.Equal(t, 3, httpmock.GetTotalCallCount(), "Invalidated device type is fetched again")
+}
+
+func TestCache_persistence(t *testing.T) {
+	//given
+	resp := api.AccountResponse{}
+	if err := readJSONData("./test-fixtures/ne_accounts.json", &resp); err != nil {
+		assert.Fail(t, "Cannot read test response")
+	}
+	metro := "SV"
+	dir, err := ioutil.TempDir("", "ne-go-cache")
+	if err != nil {
+		assert.FailNow(t, "Cannot create temporary directory")
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cache.json")
+	testHc := setupMockedClient("GET", fmt.Sprintf("%s/ne/v1/accounts/%s", baseURL, metro), 200, resp)
+	defer httpmock.DeactivateAndReset()
+	cache, _ := NewCache(CacheOptions{Path: path})
+	if _, err := NewClient(context.Background(), baseURL, testHc).SetCache(cache).GetAccounts(metro); err != nil {
+		assert.FailNow(t, "Cannot fetch accounts")
+	}
+	//when
+	persisted, err := NewCache(CacheOptions{Path: path})
+	accounts, accountsErr := NewClient(context.Background(), baseURL, testHc).SetCache(persisted).GetAccounts(metro)
+	//then
+	assert.Nil(t, err, "Persisted cache is loaded")
+	assert.Nil(t, accountsErr, "Error is not returned")
+	assert.Equal(t, 1, httpmock.GetTotalCallCount(), "Accounts are fetched once")
+	for i := range resp.Accounts {
+		verifyAccount(t, resp.Accounts[i], accounts[i])
+	}
+}
@@ -0,0 +1,364 @@
+package ne
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/equinix/ne-go/internal/api"
+)
+
+// Catalog is an in-memory view of Network Edge device types that keeps relations
+// between management types, license modes, platforms, software packages and versions.
+// Catalog is built with GetCatalog and is queried without further API calls
+type Catalog struct {
+	DeviceTypes []CatalogDeviceType
+}
+
+// CatalogDeviceType describes device type along with its platforms and software packages
+type CatalogDeviceType struct {
+	DeviceType
+	Platforms []CatalogPlatform
+	Packages  []CatalogSoftwarePackage
+}
+
+// CatalogPlatform describes platform configuration offered for a given
+// management type and license mode
+type CatalogPlatform struct {
+	ManagementType string
+	LicenseMode    string
+	Flavor         string
+	CoreCount      int
+	Memory         int
+	MemoryUnit     string
+	PackageCodes   []string
+}
+
+// CatalogSoftwarePackage describes software package of a device type. Versions
+// are sorted from the oldest to the latest one
+type CatalogSoftwarePackage struct {
+	Code     string
+	Name     string
+	Versions []DeviceSoftwareVersion
+}
+
+// CatalogPlatformQuery describes criteria of a platform query. Criteria with zero
+// values are not applied
+type CatalogPlatformQuery struct {
+	MinCoreCount   int
+	ManagementType string
+	LicenseMode    string
+	PackageCode    string
+}
+
+// CatalogConfiguration describes device configuration to be ordered. Management type,
+// license mode and version are not verified when left empty
+type CatalogConfiguration struct {
+	TypeCode       string
+	ManagementType string
+	LicenseMode    string
+	CoreCount      int
+	PackageCode    string
+	Version        string
+}
+
+// GetCatalog builds catalog of device types with given codes using given context.
+// Catalog of all device types is built when no type code is given. Details of each
+// device type are fetched with a separate request
+func (c RestClient) GetCatalog(ctx context.Context, typeCodes ...string) (*Catalog, error) {
+	if len(typeCodes) == 0 {
+		types, err := c.GetDeviceTypesWithContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for i := range types {
+			typeCodes = append(typeCodes, StringValue(types[i].Code))
+		}
+	}
+	catalog := &Catalog{DeviceTypes: make([]CatalogDeviceType, 0, len(typeCodes))}
+	for _, code := range typeCodes {
+		apiType, err := c.getDeviceType(ctx, code)
+		if err != nil {
+			return nil, err
+		}
+		catalog.DeviceTypes = append(catalog.DeviceTypes, mapDeviceTypeAPIToCatalog(*apiType))
+	}
+	return catalog, nil
+}
+
+// DeviceType returns device type with a given code or nil when catalog
+// does not have such device type
+func (c *Catalog) DeviceType(typeCode string) *CatalogDeviceType {
+	for i := range c.DeviceTypes {
+		if StringValue(c.DeviceTypes[i].Code) == typeCode {
+			return &c.DeviceTypes[i]
+		}
+	}
+	return nil
+}
+
+// DeviceTypesInMetro returns device types available in a given metro. Device types
+// are narrowed down to a given vendor, unless vendor is empty. Vendor name is
+// matched case insensitively
+func (c *Catalog) DeviceTypesInMetro(metroCode string, vendor string) []CatalogDeviceType {
+	var found []CatalogDeviceType
+	for _, deviceType := range c.DeviceTypes {
+		if vendor != "" && !strings.EqualFold(StringValue(deviceType.Vendor), vendor) {
+			continue
+		}
+		if containsString(deviceType.MetroCodes, metroCode) {
+			found = append(found, deviceType)
+		}
+	}
+	return found
+}
+
+// FindPlatforms returns platforms of a device type with a given code that
+// match given query
+func (c *Catalog) FindPlatforms(typeCode string, query CatalogPlatformQuery) []CatalogPlatform {
+	deviceType := c.DeviceType(typeCode)
+	if deviceType == nil {
+		return nil
+	}
+	var found []CatalogPlatform
+	for _, platform := range deviceType.Platforms {
+		if platform.matches(query) {
+			found = append(found, platform)
+		}
+	}
+	return found
+}
+
+// LatestVersion returns the latest software version of a given package of a device
+// type with a given code. Nil is returned when package has no versions
+func (c *Catalog) LatestVersion(typeCode string, packageCode string) *DeviceSoftwareVersion {
+	return c.latestVersion(typeCode, packageCode, false)
+}
+
+// LatestStableVersion returns the latest stable software version of a given package
+// of a device type with a given code. Nil is returned when package has no stable versions
+func (c *Catalog) LatestStableVersion(typeCode string, packageCode string) *DeviceSoftwareVersion {
+	return c.latestVersion(typeCode, packageCode, true)
+}
+
+// OrderableMetros returns codes of metros where device with a given configuration
+// can be ordered. Nil is returned when device type does not offer given configuration
+func (c *Catalog) OrderableMetros(config CatalogConfiguration) []string {
+	deviceType := c.DeviceType(config.TypeCode)
+	if deviceType == nil {
+		return nil
+	}
+	if config.Version != "" && !deviceType.hasVersion(config.PackageCode, config.Version) {
+		return nil
+	}
+	query := CatalogPlatformQuery{
+		ManagementType: config.ManagementType,
+		LicenseMode:    config.LicenseMode,
+		PackageCode:    config.PackageCode,
+	}
+	for _, platform := range deviceType.Platforms {
+		if platform.CoreCount == config.CoreCount && platform.matches(query) {
+			return append([]string{}, deviceType.MetroCodes...)
+		}
+	}
+	return nil
+}
+
+// CompareDeviceSoftwareVersions compares two device software versions and returns
+// -1, 0 or 1 when first version is, accordingly, lower, equal or greater than the
+// second one. Versions are compared by their numeric and alphabetic parts, so that
+// vendor formats like 16.09.05, 18.4R2-S1.4 or 9.1.0-h3 are ordered as expected.
+// Suffixes like alpha, beta or rc denote pre-release versions
+func CompareDeviceSoftwareVersions(a, b string) int {
+	aTokens, bTokens := versionTokens(a), versionTokens(b)
+	for i := 0; i < len(aTokens) && i < len(bTokens); i++ {
+		if result := compareVersionTokens(aTokens[i], bTokens[i]); result != 0 {
+			return result
+		}
+	}
+	switch {
+	case len(aTokens) > len(bTokens):
+		return versionSuffixSign(aTokens[len(bTokens)])
+	case len(aTokens) < len(bTokens):
+		return -versionSuffixSign(bTokens[len(aTokens)])
+	}
+	return 0
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Unexported package methods
+//_______________________________________________________________________
+
+var preReleaseVersionTokens = []string{"alpha", "beta", "dev", "pre", "rc", "snapshot"}
+
+func (c *Catalog) latestVersion(typeCode string, packageCode string, stableOnly bool) *DeviceSoftwareVersion {
+	deviceType := c.DeviceType(typeCode)
+	if deviceType == nil {
+		return nil
+	}
+	for _, pkg := range deviceType.Packages {
+		if pkg.Code != packageCode {
+			continue
+		}
+		for i := len(pkg.Versions) - 1; i >= 0; i-- {
+			if !stableOnly || BoolValue(pkg.Versions[i].IsStable) {
+				version := pkg.Versions[i]
+				return &version
+			}
+		}
+	}
+	return nil
+}
+
+func (t CatalogDeviceType) hasVersion(packageCode string, version string) bool {
+	for _, pkg := range t.Packages {
+		if packageCode != "" && pkg.Code != packageCode {
+			continue
+		}
+		for i := range pkg.Versions {
+			if StringValue(pkg.Versions[i].Version) == version {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p CatalogPlatform) matches(query CatalogPlatformQuery) bool {
+	if p.CoreCount < query.MinCoreCount {
+		return false
+	}
+	if query.ManagementType != "" && p.ManagementType != query.ManagementType {
+		return false
+	}
+	if query.LicenseMode != "" && !strings.EqualFold(p.LicenseMode, query.LicenseMode) {
+		return false
+	}
+	return query.PackageCode == "" || containsString(p.PackageCodes, query.PackageCode)
+}
+
+func mapDeviceTypeAPIToCatalog(apiType api.DeviceType) CatalogDeviceType {
+	mgmtTypes := apiType.DeviceManagementTypes
+	platforms := mapCatalogManagementType(DeviceManagementTypeEquinix, mgmtTypes.EquinixConfigured)
+	platforms = append(platforms, mapCatalogManagementType(DeviceManagementTypeSelf, mgmtTypes.SelfConfigured)...)
+	packages := make([]CatalogSoftwarePackage, len(apiType.SoftwarePackages))
+	for i, apiPkg := range apiType.SoftwarePackages {
+		pkg := CatalogSoftwarePackage{
+			Code:     StringValue(apiPkg.Code),
+			Name:     StringValue(apiPkg.Name),
+			Versions: make([]DeviceSoftwareVersion, len(apiPkg.VersionDetails)),
+		}
+		for j := range apiPkg.VersionDetails {
+			pkg.Versions[j] = *mapDeviceSoftwareVersionAPIToDomain(apiPkg.VersionDetails[j])
+			pkg.Versions[j].PackageCodes = []string{pkg.Code}
+		}
+		sort.SliceStable(pkg.Versions, func(a, b int) bool {
+			return CompareDeviceSoftwareVersions(StringValue(pkg.Versions[a].Version), StringValue(pkg.Versions[b].Version)) < 0
+		})
+		packages[i] = pkg
+	}
+	return CatalogDeviceType{
+		DeviceType: mapDeviceTypeAPIToDomain(apiType),
+		Platforms:  platforms,
+		Packages:   packages,
+	}
+}
+
+func mapCatalogManagementType(mgmtType string, apiMgmtType api.DeviceManagementType) []CatalogPlatform {
+	if !apiMgmtType.IsSupported {
+		return nil
+	}
+	platforms := mapCatalogLicenseOption(mgmtType, DeviceLicenseModeSubscription, apiMgmtType.LicenseOptions.Sub)
+	return append(platforms, mapCatalogLicenseOption(mgmtType, DeviceLicenseModeBYOL, apiMgmtType.LicenseOptions.BYOL)...)
+}
+
+func mapCatalogLicenseOption(mgmtType string, licenseMode string, licOption api.DeviceLicenseOption) []CatalogPlatform {
+	if !BoolValue(licOption.IsSupported) {
+		return nil
+	}
+	transformed := make([]CatalogPlatform, len(licOption.Cores))
+	for i, core := range licOption.Cores {
+		transformed[i] = CatalogPlatform{
+			ManagementType: mgmtType,
+			LicenseMode:    licenseMode,
+			Flavor:         StringValue(core.Flavor),
+			CoreCount:      IntValue(core.Core),
+			Memory:         IntValue(core.Memory),
+			MemoryUnit:     StringValue(core.Unit),
+			PackageCodes:   mapPackageCodesAPIToDomain(core.PackageCodes),
+		}
+	}
+	return transformed
+}
+
+//versionTokens splits version into lower case runs of digits and letters,
+//skipping separators and leading "v" prefix
+func versionTokens(version string) []string {
+	version = strings.ToLower(strings.TrimSpace(version))
+	if len(version) > 1 && version[0] == 'v' && unicode.IsDigit(rune(version[1])) {
+		version = version[1:]
+	}
+	var tokens []string
+	start := -1
+	for i, r := range version {
+		if !unicode.IsDigit(r) && !unicode.IsLetter(r) {
+			if start >= 0 {
+				tokens = append(tokens, version[start:i])
+				start = -1
+			}
+			continue
+		}
+		if start >= 0 && unicode.IsDigit(r) != unicode.IsDigit(rune(version[start])) {
+			tokens = append(tokens, version[start:i])
+			start = -1
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, version[start:])
+	}
+	return tokens
+}
+
+func compareVersionTokens(a, b string) int {
+	aNumeric, bNumeric := isNumericToken(a), isNumericToken(b)
+	switch {
+	case aNumeric && bNumeric:
+		a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+		if len(a) != len(b) {
+			return compareInts(len(a), len(b))
+		}
+		return strings.Compare(a, b)
+	case aNumeric:
+		return 1
+	case bNumeric:
+		return -1
+	}
+	return strings.Compare(a, b)
+}
+
+//versionSuffixSign tells if version with additional token is greater (1) or,
+//for pre-release token, lower (-1) than the version without it
+func versionSuffixSign(token string) int {
+	if containsString(preReleaseVersionTokens, token) {
+		return -1
+	}
+	return 1
+}
+
+func isNumericToken(token string) bool {
+	return token != "" && unicode.IsDigit(rune(token[0]))
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,90 @@
+package ne
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/equinix/ne-go/internal/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCatalog(t *testing.T) {
+	//given
+	respBody := api.DeviceTypeResponse{}
+	if err := readJSONData("./test-fixtures/ne_device_types_get.json", &respBody); err != nil {
+		assert.Failf(t, "cannot read test response due to %s", err.Error())
+	}
+	limit := respBody.Pagination.Limit
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/deviceTypes?limit=%d", baseURL, limit),
+		httpmock.NewJsonResponderOrPanic(200, respBody))
+	for _, apiType := range respBody.Data {
+		typeBody := api.DeviceTypeResponse{
+			Pagination: api.Pagination{Limit: limit, Total: 1},
+			Data:       []api.DeviceType{apiType},
+		}
+		httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/deviceTypes?deviceTypeCode=%s&limit=%d", baseURL, StringValue(apiType.Code), limit),
+			httpmock.NewJsonResponderOrPanic(200, typeBody))
+	}
+	defer httpmock.DeactivateAndReset()
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	c.PageSize = limit
+	catalog, err := c.GetCatalog(context.Background())
+	//then
+	assert.Nil(t, err, "Client should not return an error")
+	assert.Equal(t, len(respBody.Data), len(catalog.DeviceTypes), "Number of device types matches")
+	assert.Equal(t, len(respBody.Data)+1, httpmock.GetTotalCallCount(), "Each device type is fetched once")
+	juniper := catalog.DeviceTypesInMetro("SY", "juniper networks")
+	assert.Equal(t, 1, len(juniper), "Number of device types in metro matches")
+	assert.Equal(t, "VSRX", StringValue(juniper[0].Code), "Device type code matches")
+	assert.Empty(t, catalog.DeviceTypesInMetro("AM", ""), "No device types in unknown metro")
+	platforms := catalog.FindPlatforms("PA-VM", CatalogPlatformQuery{MinCoreCount: 4, LicenseMode: DeviceLicenseModeBYOL})
+	assert.Equal(t, 4, len(platforms), "Number of platforms matches")
+	for _, platform := range platforms {
+		assert.Contains(t, []string{"medium", "large"}, platform.Flavor, "Platform flavor matches")
+		assert.Equal(t, DeviceLicenseModeBYOL, platform.LicenseMode, "Platform license mode matches")
+	}
+	assert.Equal(t, "19.2R2.7", StringValue(catalog.LatestStableVersion("VSRX", "STD").Version), "Latest stable version matches")
+	assert.Equal(t, "9.1.0-h3", StringValue(catalog.LatestVersion("PA-VM", "VM500").Version), "Latest version matches")
+	assert.Nil(t, catalog.LatestVersion("PA-VM", "VM900"), "Unknown package has no versions")
+	config := CatalogConfiguration{
+		TypeCode:       "PA-VM",
+		ManagementType: DeviceManagementTypeSelf,
+		LicenseMode:    DeviceLicenseModeBYOL,
+		CoreCount:      8,
+		PackageCode:    "VM500",
+		Version:        "9.0.4",
+	}
+	assert.ElementsMatch(t, []string{"DC", "SY"}, catalog.OrderableMetros(config), "Orderable metros match")
+	config.CoreCount = 4
+	assert.Empty(t, catalog.OrderableMetros(config), "Platform without package is not orderable")
+	config.CoreCount = 8
+	config.LicenseMode = DeviceLicenseModeSubscription
+	assert.Empty(t, catalog.OrderableMetros(config), "Unsupported license mode is not orderable")
+}
+
+func TestCompareDeviceSoftwareVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"16.09.05", "16.9.5", 0},
+		{"16.09.05", "16.09.10", -1},
+		{"9.1.0-h3", "9.1.0", 1},
+		{"9.1.0", "9.0.4", 1},
+		{"18.4R2-S1.4", "18.4R3-S2", -1},
+		{"15.1X49-D142.1", "19.2R2.7", -1},
+		{"v1.2.0", "1.2", 1},
+		{"1.0.0-rc.1", "1.0.0", -1},
+		{"1.0.0-beta", "1.0.0-alpha", 1},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, CompareDeviceSoftwareVersions(test.a, test.b), "%s compared to %s", test.a, test.b)
+		assert.Equal(t, -test.expected, CompareDeviceSoftwareVersions(test.b, test.a), "%s compared to %s", test.b, test.a)
+	}
+}
@@ -2,23 +2,45 @@ package ne
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"reflect"
 	"regexp"
+	"strconv"
+	"time"
 
+	"github.com/equinix/ne-go/internal/api"
 	"github.com/equinix/rest-go"
+	"github.com/go-resty/resty/v2"
 )
 
 //RestClient describes REST implementation of Network Edge Client
 type RestClient struct {
 	*rest.Client
+	ctx         context.Context
+	baseURL     string
+	retryPolicy *RetryPolicy
+	//validateOrders enables validation of device orders before they are sent
+	validateOrders bool
+	//idempotentCreates enables lookup of existing resources before they are created
+	idempotentCreates bool
+	//cache keeps responses of device type and account queries
+	cache *Cache
+	//logger logs API requests and responses
+	logger Logger
 }
 
-//NewClient creates new REST Network Edge client with a given baseURL, context and httpClient
+//NewClient creates new REST Network Edge client with a given baseURL, context and httpClient.
+//Given context is used by all operations that do not accept context explicitly
 func NewClient(ctx context.Context, baseURL string, httpClient *http.Client) *RestClient {
 	rest := rest.NewClient(ctx, baseURL, httpClient)
 	rest.SetHeader("User-agent", "equinix/ne-go")
-	return &RestClient{rest}
+	return &RestClient{
+		Client:  rest,
+		ctx:     ctx,
+		baseURL: baseURL,
+	}
 }
 
 //‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
@@ -62,3 +84,153 @@ func getResourceIDFromLocationHeader(provider headerProvider) (*string, error) {
 	}
 	return parseResourceIDFromLocationHeader(*locHeaderValue)
 }
+
+//execute runs provided request with a given context, http method and path
+func (c RestClient) execute(ctx context.Context, req *resty.Request, method string, path string) error {
+	_, err := c.do(ctx, method, path, req)
+	return err
+}
+
+//do runs given request with a given context, method and path and returns response and error.
+//Errors are reported the same way as in rest.Client.Do
+func (c RestClient) do(ctx context.Context, method string, path string, req *resty.Request) (*resty.Response, error) {
+	if path[0:1] == "/" {
+		path = path[1:]
+	}
+	url := c.baseURL + "/" + path
+	req.SetContext(ctx)
+	var resp *resty.Response
+	var err error
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		resp, err = req.Execute(method, url)
+		c.logRequest(ctx, req, attempt, time.Since(start), resp, err)
+		if !c.retryPolicy.shouldRetry(req, attempt, resp, err) {
+			break
+		}
+		if sleepContext(ctx, c.retryPolicy.backoff(attempt, resp)) != nil {
+			break
+		}
+	}
+	if err != nil {
+		return resp, newTransportError(resp, err)
+	}
+	if resp.IsError() {
+		return resp, newAPIError(resp, createRestError(resp))
+	}
+	return resp, nil
+}
+
+//getOffsetPaginated uses HTTP GET requests to retrieve list of all objects from paginated
+//responses that use offset & limit attributes in a separate pagination object
+func (c RestClient) getOffsetPaginated(ctx context.Context, path string, result interface{}, conf *rest.OffsetPaginationConfig) ([]interface{}, error) {
+	if reflect.ValueOf(result).Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("operation failed, provided result is not a ptr")
+	}
+	resultType := reflect.ValueOf(result).Elem().Type()
+	var data []interface{}
+	for offset, first := 0, true; ; first = false {
+		pageResult := result
+		if !first {
+			pageResult = reflect.New(resultType).Interface()
+		}
+		req := c.R().SetResult(pageResult).
+			SetQueryParams(conf.AdditionalParams).
+			SetQueryParam(conf.LimitFieldName, strconv.Itoa(c.PageSize))
+		if !first {
+			req.SetQueryParam(conf.OffsetFieldName, strconv.Itoa(offset))
+		}
+		if err := c.execute(ctx, req, http.MethodGet, path); err != nil {
+			return nil, err
+		}
+		pageData, totalCount, err := getOffsetPageContent(pageResult, conf)
+		if err != nil {
+			return nil, err
+		}
+		if first {
+			data = make([]interface{}, 0, totalCount)
+		}
+		data = append(data, pageData...)
+		offset += c.PageSize
+		if offset >= totalCount {
+			return data, nil
+		}
+	}
+}
+
+func getOffsetPageContent(result interface{}, conf *rest.OffsetPaginationConfig) ([]interface{}, int, error) {
+	paginationData, err := getFieldValueFromStruct(result, conf.PaginationFieldName, reflect.Struct)
+	if err != nil {
+		return nil, 0, err
+	}
+	totalValue, err := getFieldValueFromStruct(paginationData.Interface(), conf.TotalFieldName, reflect.Int)
+	if err != nil {
+		return nil, 0, err
+	}
+	dataValue, err := getFieldValueFromStruct(result, conf.DataFieldName, reflect.Slice)
+	if err != nil {
+		return nil, 0, err
+	}
+	data := make([]interface{}, dataValue.Len())
+	for i := 0; i < dataValue.Len(); i++ {
+		data[i] = dataValue.Index(i).Interface()
+	}
+	return data, int(totalValue.Int()), nil
+}
+
+func getFieldValueFromStruct(target interface{}, fieldName string, fieldKind reflect.Kind) (*reflect.Value, error) {
+	resultVal := reflect.ValueOf(target)
+	if resultVal.Kind() == reflect.Ptr {
+		resultVal = resultVal.Elem()
+	}
+	if resultVal.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("provided target is %s and not a struct", resultVal.Kind())
+	}
+	val := resultVal.FieldByName(fieldName)
+	if val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+	if val.Kind() != fieldKind {
+		return nil, fmt.Errorf("kind of %s field in target struct is %s and not %s", fieldName, val.Kind(), fieldKind)
+	}
+	return &val, nil
+}
+
+func createRestError(resp *resty.Response) rest.Error {
+	respBody := resp.Body()
+	err := rest.Error{}
+	err.HTTPCode = resp.StatusCode()
+	err.Message = http.StatusText(err.HTTPCode)
+	appErrors, ok := mapErrorBodyAPIToDomain(respBody)
+	if !ok {
+		err.Message = string(respBody)
+		return err
+	}
+	err.ApplicationErrors = appErrors
+	return err
+}
+
+func mapErrorBodyAPIToDomain(body []byte) ([]rest.ApplicationError, bool) {
+	apiError := api.ErrorResponse{}
+	if err := json.Unmarshal(body, &apiError); err == nil {
+		return mapApplicationErrorsAPIToDomain([]api.ErrorResponse{apiError}), true
+	}
+	apiErrors := api.ErrorResponses{}
+	if err := json.Unmarshal(body, &apiErrors); err == nil {
+		return mapApplicationErrorsAPIToDomain(apiErrors), true
+	}
+	return nil, false
+}
+
+func mapApplicationErrorsAPIToDomain(apiErrors api.ErrorResponses) []rest.ApplicationError {
+	transformed := make([]rest.ApplicationError, len(apiErrors))
+	for i := range apiErrors {
+		transformed[i] = rest.ApplicationError{
+			Code:           apiErrors[i].ErrorCode,
+			Property:       apiErrors[i].Property,
+			Message:        apiErrors[i].ErrorMessage,
+			AdditionalInfo: apiErrors[i].MoreInfo,
+		}
+	}
+	return transformed
+}
@@ -3,10 +3,14 @@ package ne
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"testing"
 
+	"github.com/equinix/ne-go/internal/api"
+	"github.com/equinix/rest-go"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
 )
@@ -22,6 +26,63 @@ func TestClientImplementation(t *testing.T) {
 	assert.Implements(t, (*Client)(nil), cli, "Rest client implements Client interface")
 }
 
+func TestClientWithContextImplementation(t *testing.T) {
+	//given
+	cli := NewClient(context.Background(), baseURL, &http.Client{})
+	//then
+	assert.Implements(t, (*ClientWithContext)(nil), cli, "Rest client implements ClientWithContext interface")
+}
+
+func TestGetOffsetPaginated(t *testing.T) {
+	//given
+	pages := []api.DevicesResponse{
+		{Pagination: api.Pagination{Offset: 0, Limit: 2, Total: 3}, Data: []api.Device{{UUID: String("one")}, {UUID: String("two")}}},
+		{Pagination: api.Pagination{Offset: 2, Limit: 2, Total: 3}, Data: []api.Device{{UUID: String("three")}}},
+	}
+	offsets := []string{}
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices", baseURL),
+		func(r *http.Request) (*http.Response, error) {
+			offset := r.URL.Query().Get("offset")
+			offsets = append(offsets, offset)
+			page := pages[0]
+			if offset == "2" {
+				page = pages[1]
+			}
+			return httpmock.NewJsonResponse(200, page)
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	c.PageSize = 2
+	content, err := c.getOffsetPaginated(context.Background(), "/ne/v1/devices", &api.DevicesResponse{}, rest.DefaultOffsetPagingConfig())
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, []string{"", "2"}, offsets, "Pages were requested with expected offsets")
+	assert.Equal(t, 3, len(content), "Number of objects matches")
+	assert.Equal(t, "three", StringValue(content[2].(api.Device).UUID), "Last object matches")
+}
+
+func TestDo_error(t *testing.T) {
+	//given
+	testHc := setupMockedClient("GET", fmt.Sprintf("%s/ne/v1/devices/abc", baseURL), 404,
+		api.ErrorResponse{ErrorCode: "EQ-123", ErrorMessage: "not found", Property: "uuid"})
+	defer httpmock.DeactivateAndReset()
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	_, err := c.do(context.Background(), http.MethodGet, "/ne/v1/devices/abc", c.R())
+	//then
+	assert.NotNil(t, err, "Error is returned")
+	restErr := rest.Error{}
+	assert.True(t, errors.As(err, &restErr), "Error unwraps to rest.Error")
+	assert.Equal(t, 404, restErr.HTTPCode, "HTTP code matches")
+	assert.Equal(t, 1, len(restErr.ApplicationErrors), "Number of application errors matches")
+	assert.Equal(t, "EQ-123", restErr.ApplicationErrors[0].Code, "Application error code matches")
+	assert.Equal(t, "uuid", restErr.ApplicationErrors[0].Property, "Application error property matches")
+}
+
 func TestParseResourceIdFromLocationHeader(t *testing.T) {
 	//given
 	resourceID := "3c11e8d9-80da-4a04-ae22-a35313d64717"
@@ -105,3 +166,23 @@ func setupMockedClient(method string, url string, respCode int, resp interface{}
 	)
 	return testHc
 }
+
+func setupMockedSequence(method string, url string, resps ...interface{}) *http.Client {
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	calls := 0
+	httpmock.RegisterResponder(method, url,
+		func(r *http.Request) (*http.Response, error) {
+			resp := resps[len(resps)-1]
+			if calls < len(resps) {
+				resp = resps[calls]
+			}
+			calls++
+			if httpResp, ok := resp.(*http.Response); ok {
+				return httpResp, nil
+			}
+			return httpmock.NewJsonResponse(200, resp)
+		},
+	)
+	return testHc
+}
@@ -0,0 +1,169 @@
+package ne
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// ClusterNodeCount is a number of nodes in a Network Edge device cluster
+const ClusterNodeCount = 2
+
+// ClusterSpec describes Network Edge cluster device order
+type ClusterSpec struct {
+	//Device describes attributes shared by all cluster nodes, like device type,
+	//metro, package code, software version or account. ClusterDetails of
+	//a device are ignored
+	Device Device
+	//ClusterName is a name of a cluster
+	ClusterName string
+	//Nodes describe configuration of each cluster node, in node number order
+	Nodes []ClusterNodeSpec
+}
+
+// ClusterNodeSpec describes configuration of a single Network Edge cluster node
+type ClusterNodeSpec struct {
+	//VendorConfiguration is vendor specific configuration of a node, i.e. hostname
+	VendorConfiguration map[string]string
+	//LicenseFileID is an identifier of uploaded license file, used with BYOL licensing
+	LicenseFileID string
+	//LicenseToken is a license token, used with BYOL licensing
+	LicenseToken string
+}
+
+// Cluster describes Network Edge cluster along with devices of its nodes
+type Cluster struct {
+	ID     *string
+	Name   *string
+	Device *Device
+	Nodes  []Device
+}
+
+// CreateClusterDevice validates given cluster order and creates cluster device.
+// Cluster has to have ClusterNodeCount nodes and device type has to support clustering.
+// Each node can use either license file or license token. Violations are returned
+// with DeviceOrderValidationError. UUID of a cluster device is returned upon
+// successful creation
+func (c RestClient) CreateClusterDevice(ctx context.Context, spec ClusterSpec) (*string, error) {
+	if err := c.ValidateClusterSpec(ctx, spec); err != nil {
+		return nil, err
+	}
+	device := spec.Device
+	device.ClusterDetails = &ClusterDetails{
+		ClusterName: String(spec.ClusterName),
+		Node0:       mapClusterNodeSpecToDetail(spec.Nodes[0]),
+		Node1:       mapClusterNodeSpecToDetail(spec.Nodes[1]),
+	}
+	return c.CreateDeviceWithContext(ctx, device)
+}
+
+// ValidateClusterSpec verifies if given cluster order has required number of nodes,
+// if device type supports clustering and if node licenses are set properly.
+// All violations are returned at once with DeviceOrderValidationError
+func (c RestClient) ValidateClusterSpec(ctx context.Context, spec ClusterSpec) error {
+	var violations []DeviceOrderViolation
+	violate := func(field string, value interface{}, format string, args ...interface{}) {
+		violations = append(violations, DeviceOrderViolation{
+			Field:   field,
+			Value:   value,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+	if spec.ClusterName == "" {
+		violate("ClusterName", spec.ClusterName, "cluster name is required")
+	}
+	if len(spec.Nodes) != ClusterNodeCount {
+		violate("Nodes", len(spec.Nodes), "cluster has to have %d nodes", ClusterNodeCount)
+	}
+	for i := range spec.Nodes {
+		if spec.Nodes[i].LicenseFileID != "" && spec.Nodes[i].LicenseToken != "" {
+			violate(fmt.Sprintf("Nodes[%d].LicenseToken", i), Secret(spec.Nodes[i].LicenseToken),
+				"node can use either license file or license token")
+		}
+	}
+	typeCode := StringValue(spec.Device.TypeCode)
+	if typeCode == "" {
+		violate("Device.TypeCode", typeCode, "device type code is required")
+	} else {
+		types, err := c.GetDeviceTypesWithContext(ctx)
+		if err != nil {
+			return err
+		}
+		var deviceType *DeviceType
+		for i := range types {
+			if StringValue(types[i].Code) == typeCode {
+				deviceType = &types[i]
+				break
+			}
+		}
+		switch {
+		case deviceType == nil:
+			violate("Device.TypeCode", typeCode, "device type %q does not exist", typeCode)
+		case !BoolValue(deviceType.IsClusteringSupported):
+			violate("Device.TypeCode", typeCode, "device type %q does not support clustering", typeCode)
+		case deviceType.MaxClusterNodes != nil && len(spec.Nodes) > *deviceType.MaxClusterNodes:
+			violate("Nodes", len(spec.Nodes), "device type %q supports up to %d cluster nodes", typeCode, *deviceType.MaxClusterNodes)
+		}
+	}
+	if len(violations) > 0 {
+		return DeviceOrderValidationError{Violations: violations}
+	}
+	return nil
+}
+
+// GetCluster fetches cluster device with a given UUID along with devices of its
+// nodes, ordered by node number
+func (c RestClient) GetCluster(ctx context.Context, uuid string) (*Cluster, error) {
+	device, err := c.GetDeviceWithContext(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if device.ClusterDetails == nil {
+		return nil, fmt.Errorf("device %q is not a cluster device", uuid)
+	}
+	cluster := &Cluster{
+		ID:     device.ClusterDetails.ClusterId,
+		Name:   device.ClusterDetails.ClusterName,
+		Device: device,
+	}
+	nodes := append([]ClusterNode(nil), device.ClusterDetails.Nodes...)
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return IntValue(nodes[i].Node) < IntValue(nodes[j].Node)
+	})
+	for _, node := range nodes {
+		nodeDevice, err := c.GetDeviceWithContext(ctx, StringValue(node.UUID))
+		if err != nil {
+			return nil, err
+		}
+		cluster.Nodes = append(cluster.Nodes, *nodeDevice)
+	}
+	return cluster, nil
+}
+
+// WaitForCluster polls cluster device with a given UUID until it gets provisioned.
+// Cluster setup states, like waiting for cluster nodes, cluster setup in progress
+// or license waiting for cluster setup, are polled further. Provisioned cluster
+// is returned along with devices of its nodes
+func (c RestClient) WaitForCluster(ctx context.Context, uuid string, opts *WaitOptions) (*Cluster, error) {
+	if _, err := c.WaitForDeviceState(ctx, uuid, []string{DeviceStateProvisioned}, opts); err != nil {
+		return nil, err
+	}
+	return c.GetCluster(ctx, uuid)
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Unexported package methods
+//_______________________________________________________________________
+
+func mapClusterNodeSpecToDetail(spec ClusterNodeSpec) *ClusterNodeDetail {
+	detail := &ClusterNodeDetail{
+		VendorConfiguration: spec.VendorConfiguration,
+	}
+	if spec.LicenseFileID != "" {
+		detail.LicenseFileId = String(spec.LicenseFileID)
+	}
+	if spec.LicenseToken != "" {
+		detail.LicenseToken = String(spec.LicenseToken)
+	}
+	return detail
+}
@@ -0,0 +1,79 @@
+package ne
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/equinix/ne-go/internal/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateClusterSpec(t *testing.T) {
+	//given
+	types := api.DeviceTypeResponse{Pagination: api.Pagination{Total: 2}, Data: []api.DeviceType{
+		{Code: String("PA-VM"), ClusteringDetails: &api.DeviceTypeClusteringDetails{ClusteringEnabled: Bool(true), MaxAllowedNodes: Int(2)}},
+		{Code: String("CSR1000V")},
+	}}
+	testHc := setupMockedClient("GET", fmt.Sprintf("%s/ne/v1/deviceTypes", baseURL), 200, types)
+	defer httpmock.DeactivateAndReset()
+	c := NewClient(context.Background(), baseURL, testHc)
+	tests := []struct {
+		name   string
+		spec   ClusterSpec
+		fields []string
+	}{
+		{"valid", ClusterSpec{Device: Device{TypeCode: String("PA-VM")}, ClusterName: "cluster",
+			Nodes: []ClusterNodeSpec{{LicenseFileID: "file0"}, {LicenseFileID: "file1"}}}, nil},
+		{"notSupported", ClusterSpec{Device: Device{TypeCode: String("CSR1000V")}, ClusterName: "cluster",
+			Nodes: []ClusterNodeSpec{{}, {}}}, []string{"Device.TypeCode"}},
+		{"invalidNodes", ClusterSpec{Device: Device{TypeCode: String("PA-VM")},
+			Nodes: []ClusterNodeSpec{{LicenseFileID: "file0", LicenseToken: "token0"}}}, []string{"ClusterName", "Nodes", "Nodes[0].LicenseToken"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//when
+			err := c.ValidateClusterSpec(context.Background(), tc.spec)
+			//then
+			if tc.fields == nil {
+				assert.Nil(t, err, "Error is not returned")
+				return
+			}
+			validationErr := DeviceOrderValidationError{}
+			assert.True(t, errors.As(err, &validationErr), "DeviceOrderValidationError is returned")
+			var fields []string
+			for _, violation := range validationErr.Violations {
+				fields = append(fields, violation.Field)
+			}
+			assert.Equal(t, tc.fields, fields, "Violations match")
+		})
+	}
+}
+
+func TestGetCluster(t *testing.T) {
+	//given
+	clusterID := "cluster"
+	testHc := setupMockedClient("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, clusterID), 200, api.Device{
+		UUID: String(clusterID),
+		ClusterDetails: &api.ClusterDetails{ClusterID: String("clusterID"), ClusterName: String("clusterName"), Nodes: []api.ClusterNode{
+			{UUID: String("node1"), Node: Int(1)},
+			{UUID: String("node0"), Node: Int(0)},
+		}},
+	})
+	defer httpmock.DeactivateAndReset()
+	for _, node := range []string{"node0", "node1"} {
+		httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, node),
+			httpmock.NewJsonResponderOrPanic(200, api.Device{UUID: String(node)}))
+	}
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	cluster, err := c.GetCluster(context.Background(), clusterID)
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, "clusterID", StringValue(cluster.ID), "Cluster ID matches")
+	assert.Equal(t, "clusterName", StringValue(cluster.Name), "Cluster name matches")
+	assert.Equal(t, clusterID, StringValue(cluster.Device.UUID), "Cluster device matches")
+	assert.Equal(t, []string{"node0", "node1"}, []string{StringValue(cluster.Nodes[0].UUID), StringValue(cluster.Nodes[1].UUID)}, "Node devices are ordered by node number")
+}
@@ -1,6 +1,7 @@
 package ne
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -37,11 +38,33 @@ type restDeviceUpdateRequest struct {
 
 // CreateDevice creates given Network Edge device and returns its UUID upon successful creation
 func (c RestClient) CreateDevice(device Device) (*string, error) {
+	return c.CreateDeviceWithContext(c.ctx, device)
+}
+
+// CreateDeviceWithContext creates given Network Edge device using given context
+// and returns its UUID upon successful creation. Device order is validated first,
+// when validation was enabled with SetDeviceOrderValidation. UUID of existing
+// device is returned, when idempotent creates were enabled with SetIdempotentCreates
+func (c RestClient) CreateDeviceWithContext(ctx context.Context, device Device) (*string, error) {
+	if c.idempotentCreates {
+		existing, err := c.findDevice(ctx, device)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return existing.UUID, nil
+		}
+	}
+	if c.validateOrders {
+		if err := c.ValidateDeviceOrder(ctx, device); err != nil {
+			return nil, err
+		}
+	}
 	path := "/ne/v1/devices"
 	reqBody := createDeviceRequest(device)
 	respBody := api.DeviceRequestResponse{}
 	req := c.R().SetBody(&reqBody).SetResult(&respBody)
-	if err := c.Execute(req, http.MethodPost, path); err != nil {
+	if err := c.execute(ctx, req, http.MethodPost, path); err != nil {
 		return nil, err
 	}
 	return respBody.UUID, nil
@@ -50,31 +73,74 @@ func (c RestClient) CreateDevice(device Device) (*string, error) {
 // CreateRedundantDevice creates HA device setup from given primary and secondary devices and
 // returns their UUIDS upon successful creation
 func (c RestClient) CreateRedundantDevice(primary Device, secondary Device) (*string, *string, error) {
+	return c.CreateRedundantDeviceWithContext(c.ctx, primary, secondary)
+}
+
+// CreateRedundantDeviceWithContext creates HA device setup from given primary and secondary
+// devices using given context and returns their UUIDS upon successful creation.
+// Device orders are validated first, when validation was enabled with SetDeviceOrderValidation.
+// UUIDs of existing primary device and its redundant device are returned, when idempotent
+// creates were enabled with SetIdempotentCreates. Error is returned when existing primary
+// device has no redundant device, or when it does not match given secondary device
+func (c RestClient) CreateRedundantDeviceWithContext(ctx context.Context, primary Device, secondary Device) (*string, *string, error) {
+	if c.idempotentCreates {
+		existing, err := c.findDevice(ctx, primary)
+		if err != nil {
+			return nil, nil, err
+		}
+		if existing != nil {
+			if err := c.verifyExistingSecondary(ctx, *existing, secondary); err != nil {
+				return nil, nil, err
+			}
+			return existing.UUID, existing.RedundantUUID, nil
+		}
+	}
+	if c.validateOrders {
+		if err := c.ValidateRedundantDeviceOrder(ctx, primary, secondary); err != nil {
+			return nil, nil, err
+		}
+	}
 	path := "/ne/v1/devices"
 	reqBody := createRedundantDeviceRequest(primary, secondary)
 	respBody := api.DeviceRequestResponse{}
 	req := c.R().SetBody(&reqBody).SetResult(&respBody)
-	if err := c.Execute(req, http.MethodPost, path); err != nil {
+	if err := c.execute(ctx, req, http.MethodPost, path); err != nil {
 		return nil, nil, err
 	}
 	return respBody.UUID, respBody.SecondaryUUID, nil
 }
 
+// AddSecondary creates secondary device for a single device with a given UUID
+// and returns secondary device UUID upon successful creation.
+// UpdateError is returned if secondary device creation has failed
 func (c RestClient) AddSecondary(primaryUuid string, secondary Device) (*string, error) {
-	updateErr := UpdateError{}
-	secondaryUuid, err := c.addSecondaryDevice(primaryUuid, secondary)
+	return c.AddSecondaryWithContext(c.ctx, primaryUuid, secondary)
+}
+
+// AddSecondaryWithContext creates secondary device for a single device with a given UUID
+// using given context and returns secondary device UUID upon successful creation.
+// UpdateError is returned if secondary device creation has failed
+func (c RestClient) AddSecondaryWithContext(ctx context.Context, primaryUuid string, secondary Device) (*string, error) {
+	secondaryUuid, err := c.addSecondaryDevice(ctx, primaryUuid, secondary)
 	if err != nil {
+		updateErr := UpdateError{}
 		updateErr.AddChangeError(changeTypeUpdate, "secondary", secondary, err)
+		return nil, updateErr
 	}
 	return secondaryUuid, nil
 }
 
 // GetDevice fetches details of a device with a given UUID
 func (c RestClient) GetDevice(uuid string) (*Device, error) {
+	return c.GetDeviceWithContext(c.ctx, uuid)
+}
+
+// GetDeviceWithContext fetches details of a device with a given UUID using given context
+func (c RestClient) GetDeviceWithContext(ctx context.Context, uuid string) (*Device, error) {
 	path := "/ne/v1/devices/" + url.PathEscape(uuid)
 	result := api.Device{}
 	request := c.R().SetResult(&result)
-	if err := c.Execute(request, http.MethodGet, path); err != nil {
+	if err := c.execute(ctx, request, http.MethodGet, path); err != nil {
 		return nil, err
 	}
 	return mapDeviceAPIToDomain(result), nil
@@ -82,8 +148,14 @@ func (c RestClient) GetDevice(uuid string) (*Device, error) {
 
 // GetDevices retrieves list of devices (along with their details) with given list of statuses
 func (c RestClient) GetDevices(statuses []string) ([]Device, error) {
+	return c.GetDevicesWithContext(c.ctx, statuses)
+}
+
+// GetDevicesWithContext retrieves list of devices (along with their details) with given
+// list of statuses using given context
+func (c RestClient) GetDevicesWithContext(ctx context.Context, statuses []string) ([]Device, error) {
 	path := "/ne/v1/devices"
-	content, err := c.GetOffsetPaginated(path, &api.DevicesResponse{},
+	content, err := c.getOffsetPaginated(ctx, path, &api.DevicesResponse{},
 		rest.DefaultOffsetPagingConfig().
 			SetAdditionalParams(map[string]string{"status": buildQueryParamValueString(statuses)}))
 	if err != nil {
@@ -98,10 +170,16 @@ func (c RestClient) GetDevices(statuses []string) ([]Device, error) {
 
 // GetDeviceAdditionalBandwidthDetails retrives details of given device's additional bandwidth
 func (c RestClient) GetDeviceAdditionalBandwidthDetails(uuid string) (*DeviceAdditionalBandwidthDetails, error) {
+	return c.GetDeviceAdditionalBandwidthDetailsWithContext(c.ctx, uuid)
+}
+
+// GetDeviceAdditionalBandwidthDetailsWithContext retrives details of given device's additional
+// bandwidth using given context
+func (c RestClient) GetDeviceAdditionalBandwidthDetailsWithContext(ctx context.Context, uuid string) (*DeviceAdditionalBandwidthDetails, error) {
 	path := fmt.Sprintf("/ne/v1/devices/%s/additionalBandwidths", url.PathEscape(uuid))
 	result := api.DeviceAdditionalBandwidthResponse{}
 	request := c.R().SetResult(&result)
-	if err := c.Execute(request, http.MethodGet, path); err != nil {
+	if err := c.execute(ctx, request, http.MethodGet, path); err != nil {
 		return nil, err
 	}
 	return mapDeviceAdditionalBandwidthAPIToDomain(result), nil
@@ -109,10 +187,16 @@ func (c RestClient) GetDeviceAdditionalBandwidthDetails(uuid string) (*DeviceAdd
 
 // GetDeviceACLDetails retrives device acl template provisioning status
 func (c RestClient) GetDeviceACLDetails(uuid string) (*DeviceACLDetails, error) {
+	return c.GetDeviceACLDetailsWithContext(c.ctx, uuid)
+}
+
+// GetDeviceACLDetailsWithContext retrives device acl template provisioning status
+// using given context
+func (c RestClient) GetDeviceACLDetailsWithContext(ctx context.Context, uuid string) (*DeviceACLDetails, error) {
 	path := fmt.Sprintf("/ne/v1/devices/%s/acl", url.PathEscape(uuid))
 	result := api.DeviceACLResponse{}
 	request := c.R().SetResult(&result)
-	if err := c.Execute(request, http.MethodGet, path); err != nil {
+	if err := c.execute(ctx, request, http.MethodGet, path); err != nil {
 		return nil, err
 	}
 	return mapDeviceACLAPIToDomain(result), nil
@@ -120,6 +204,12 @@ func (c RestClient) GetDeviceACLDetails(uuid string) (*DeviceACLDetails, error)
 
 // NewDeviceUpdateRequest creates new composite update request for a device with a given UUID
 func (c RestClient) NewDeviceUpdateRequest(uuid string) DeviceUpdateRequest {
+	return c.NewDeviceUpdateRequestWithContext(uuid)
+}
+
+// NewDeviceUpdateRequestWithContext creates new composite update request for a device
+// with a given UUID, that can be executed with per-call context
+func (c RestClient) NewDeviceUpdateRequestWithContext(uuid string) DeviceUpdateRequestWithContext {
 	return &restDeviceUpdateRequest{
 		uuid:         uuid,
 		deviceFields: make(map[string]interface{}),
@@ -128,18 +218,32 @@ func (c RestClient) NewDeviceUpdateRequest(uuid string) DeviceUpdateRequest {
 
 // DeleteDevice deletes device with a given UUID
 func (c RestClient) DeleteDevice(uuid string) error {
+	return c.DeleteDeviceWithContext(c.ctx, uuid)
+}
+
+// DeleteDeviceWithContext deletes device with a given UUID using given context
+func (c RestClient) DeleteDeviceWithContext(ctx context.Context, uuid string) error {
 	path := "/ne/v1/devices/" + url.PathEscape(uuid)
 	req := c.R().SetQueryParam("deleteRedundantDevice", "true")
-	if err := c.Execute(req, http.MethodDelete, path); err != nil {
+	if err := c.execute(ctx, req, http.MethodDelete, path); err != nil {
 		return err
 	}
 	return nil
 }
 
+// DeleteSecondaryDevice deletes secondary device with a given UUID, leaving
+// its primary device in place. Use BreakDevicePair to delete secondary device
+// of a pair identified by UUID of any of its devices
 func (c RestClient) DeleteSecondaryDevice(uuid string) error {
+	return c.DeleteSecondaryDeviceWithContext(c.ctx, uuid)
+}
+
+// DeleteSecondaryDeviceWithContext deletes secondary device with a given UUID
+// using given context, leaving its primary device in place
+func (c RestClient) DeleteSecondaryDeviceWithContext(ctx context.Context, uuid string) error {
 	path := "/ne/v1/devices/" + url.PathEscape(uuid)
 	req := c.R().SetQueryParam("deleteRedundantDevice", "false")
-	if err := c.Execute(req, http.MethodDelete, path); err != nil {
+	if err := c.execute(ctx, req, http.MethodDelete, path); err != nil {
 		return err
 	}
 	return nil
@@ -195,31 +299,26 @@ func (req *restDeviceUpdateRequest) WithMgmtAclTemplate(mgmtAclTemplateUuid stri
 
 // Execute attempts to update device according new data set in composite update request.
 // This is not atomic operation and if any update will fail, other changes won't be reverted.
+// Use ExecuteWithRollback to revert applied changes on failure.
 // UpdateError will be returned if any of requested data failed to update
 func (req *restDeviceUpdateRequest) Execute() error {
-	updateErr := UpdateError{}
-	if err := req.c.replaceDeviceFields(req.uuid, req.deviceFields); err != nil {
-		updateErr.AddChangeError(changeTypeUpdate, "deviceFields", req.deviceFields, err)
-	}
-	if req.aclTemplateID != nil || req.mgmtAclTemplateUuid != nil {
-		if err := req.c.replaceDeviceACLTemplate(req.uuid, req.aclTemplateID, req.mgmtAclTemplateUuid); err != nil {
-			if req.aclTemplateID != nil {
-				updateErr.AddChangeError(changeTypeUpdate, "aclTemplateUuid", *req.aclTemplateID, err)
-			}
-			if req.mgmtAclTemplateUuid != nil {
-				updateErr.AddChangeError(changeTypeUpdate, "mgmtAclTemplateUuid", *req.mgmtAclTemplateUuid, err)
-			}
-		}
-	}
-	if req.additionalBandwidth != nil {
-		if err := req.c.replaceDeviceAdditionalBandwidth(req.uuid, *req.additionalBandwidth); err != nil {
-			updateErr.AddChangeError(changeTypeUpdate, "additionalBandwidth", req.additionalBandwidth, err)
-		}
-	}
-	if updateErr.ChangeErrorsCount() > 0 {
-		return updateErr
-	}
-	return nil
+	return req.ExecuteWithContext(req.c.ctx)
+}
+
+// ExecuteWithContext attempts to update device according new data set in composite update
+// request using given context. Semantics are the same as for Execute
+func (req *restDeviceUpdateRequest) ExecuteWithContext(ctx context.Context) error {
+	_, err := req.ExecuteWithResult(ctx, nil)
+	return err
+}
+
+// ExecuteWithResult attempts to update device according new data set in composite update
+// request using given context and options. Device fields, ACL templates and additional
+// bandwidth are updated one after another. Result with outcome of every attempted
+// change is returned along with UpdateError, if any change failed
+func (req *restDeviceUpdateRequest) ExecuteWithResult(ctx context.Context, opts *UpdateOptions) (*UpdateResult, error) {
+	result := executeChanges(ctx, opts, req.changes()...)
+	return result, result.Err()
 }
 
 //‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
@@ -354,8 +453,12 @@ func mapDeviceClusterDetailsAPIToDomain(apiClusterDetails *api.ClusterDetails) *
 		}
 	}
 	clusterDetails.Nodes = transformed
-	clusterDetails.Node0 = clusterNodeDetails[0]
-	clusterDetails.Node1 = clusterNodeDetails[1]
+	if len(clusterNodeDetails) > 0 {
+		clusterDetails.Node0 = clusterNodeDetails[0]
+	}
+	if len(clusterNodeDetails) > 1 {
+		clusterDetails.Node1 = clusterNodeDetails[1]
+	}
 	return &clusterDetails
 }
 
@@ -481,30 +584,73 @@ func addSecondaryDeviceRequest(primaryDeviceUuid string, secondary Device) api.A
 	return req
 }
 
-func (c RestClient) replaceDeviceACLTemplate(uuid string, wanAclTemplateUuid *string, mgmtAclTemplateUuid *string) error {
+//changes returns groups of changes of a composite update request, in execution order
+func (req *restDeviceUpdateRequest) changes() [][]updateChange {
+	var groups [][]updateChange
+	if len(req.deviceFields) > 0 {
+		groups = append(groups, []updateChange{{
+			changeType: changeTypeUpdate,
+			targets:    []string{"deviceFields"},
+			values:     []interface{}{req.deviceFields},
+			run: func(ctx context.Context) error {
+				return req.c.replaceDeviceFields(ctx, req.uuid, req.deviceFields)
+			},
+		}})
+	}
+	if req.aclTemplateID != nil || req.mgmtAclTemplateUuid != nil {
+		change := updateChange{
+			changeType: changeTypeUpdate,
+			run: func(ctx context.Context) error {
+				return req.c.replaceDeviceACLTemplate(ctx, req.uuid, req.aclTemplateID, req.mgmtAclTemplateUuid)
+			},
+		}
+		if req.aclTemplateID != nil {
+			change.targets = append(change.targets, "aclTemplateUuid")
+			change.values = append(change.values, *req.aclTemplateID)
+		}
+		if req.mgmtAclTemplateUuid != nil {
+			change.targets = append(change.targets, "mgmtAclTemplateUuid")
+			change.values = append(change.values, *req.mgmtAclTemplateUuid)
+		}
+		groups = append(groups, []updateChange{change})
+	}
+	if req.additionalBandwidth != nil {
+		groups = append(groups, []updateChange{{
+			changeType: changeTypeUpdate,
+			targets:    []string{"additionalBandwidth"},
+			values:     []interface{}{req.additionalBandwidth},
+			run: func(ctx context.Context) error {
+				return req.c.replaceDeviceAdditionalBandwidth(ctx, req.uuid, *req.additionalBandwidth)
+			},
+		}})
+	}
+	return groups
+}
+
+func (c RestClient) replaceDeviceACLTemplate(ctx context.Context, uuid string, wanAclTemplateUuid *string, mgmtAclTemplateUuid *string) error {
 	path := "/ne/v1/devices/" + url.PathEscape(uuid) + "/acl"
 	reqBody := api.DeviceACLTemplateRequest{
 		TemplateUUID:        wanAclTemplateUuid,
 		MgmtAclTemplateUUID: mgmtAclTemplateUuid,
 	}
 	req := c.R().SetBody(reqBody)
-	if err := c.Execute(req, http.MethodPatch, path); err != nil {
+	if err := c.execute(ctx, req, http.MethodPatch, path); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (c RestClient) replaceDeviceAdditionalBandwidth(uuid string, bandwidth int) error {
+func (c RestClient) replaceDeviceAdditionalBandwidth(ctx context.Context, uuid string, bandwidth int) error {
 	path := fmt.Sprintf("/ne/v1/devices/%s/additionalBandwidths", url.PathEscape(uuid))
 	reqBody := api.DeviceAdditionalBandwidthUpdateRequest{AdditionalBandwidth: &bandwidth}
 	req := c.R().SetBody(reqBody)
-	if err := c.Execute(req, http.MethodPut, path); err != nil {
+	if err := c.execute(ctx, req, http.MethodPut, path); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (c RestClient) replaceDeviceFields(uuid string, fields map[string]interface{}) error {
+func (c RestClient) replaceDeviceFields(ctx context.Context, uuid string, fields map[string]interface{}) error {
 	reqBody := api.DeviceUpdateRequest{}
 	okToSend := false
 	if v, ok := fields["deviceName"]; ok {
@@ -530,21 +676,21 @@ func (c RestClient) replaceDeviceFields(uuid string, fields map[string]interface
 	if okToSend {
 		path := "/ne/v1/devices/" + uuid
 		req := c.R().SetBody(&reqBody)
-		if err := c.Execute(req, http.MethodPatch, path); err != nil {
+		if err := c.execute(ctx, req, http.MethodPatch, path); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (c RestClient) addSecondaryDevice(uuid string, secondary Device) (*string, error) {
+func (c RestClient) addSecondaryDevice(ctx context.Context, uuid string, secondary Device) (*string, error) {
 	//build logic for post secondary device
 	//uuid = primary device uuid
 	path := "/ne/v1/devices"
 	reqBody := addSecondaryDeviceRequest(uuid, secondary)
 	respBody := api.DeviceRequestResponse{}
 	req := c.R().SetBody(&reqBody).SetResult(&respBody)
-	if err := c.Execute(req, http.MethodPost, path); err != nil {
+	if err := c.execute(ctx, req, http.MethodPost, path); err != nil {
 		return nil, err
 	}
 	return respBody.SecondaryUUID, nil
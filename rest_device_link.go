@@ -1,6 +1,7 @@
 package ne
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 
@@ -22,8 +23,14 @@ type restDeviceLinkUpdateRequest struct {
 // GetDeviceLinkGroups retrieves list of existing device link groups
 // (along with their details)
 func (c RestClient) GetDeviceLinkGroups() ([]DeviceLinkGroup, error) {
+	return c.GetDeviceLinkGroupsWithContext(c.ctx)
+}
+
+// GetDeviceLinkGroupsWithContext retrieves list of existing device link groups
+// (along with their details) using given context
+func (c RestClient) GetDeviceLinkGroupsWithContext(ctx context.Context) ([]DeviceLinkGroup, error) {
 	path := "/ne/v1/links"
-	content, err := c.GetOffsetPaginated(path, &api.DeviceLinkGroupsGetResponse{},
+	content, err := c.getOffsetPaginated(ctx, path, &api.DeviceLinkGroupsGetResponse{},
 		rest.DefaultOffsetPagingConfig())
 	if err != nil {
 		return nil, err
@@ -39,10 +46,16 @@ func (c RestClient) GetDeviceLinkGroups() ([]DeviceLinkGroup, error) {
 // GetDeviceLinkGroups retrieves details of a device link group
 // with a given identifier
 func (c RestClient) GetDeviceLinkGroup(uuid string) (*DeviceLinkGroup, error) {
+	return c.GetDeviceLinkGroupWithContext(c.ctx, uuid)
+}
+
+// GetDeviceLinkGroupWithContext retrieves details of a device link group
+// with a given identifier using given context
+func (c RestClient) GetDeviceLinkGroupWithContext(ctx context.Context, uuid string) (*DeviceLinkGroup, error) {
 	path := "/ne/v1/links/" + url.PathEscape(uuid)
 	result := api.DeviceLinkGroup{}
 	request := c.R().SetResult(&result)
-	if err := c.Execute(request, http.MethodGet, path); err != nil {
+	if err := c.execute(ctx, request, http.MethodGet, path); err != nil {
 		return nil, err
 	}
 	return mapDeviceLinkGroupAPIToDomain(result), nil
@@ -51,11 +64,17 @@ func (c RestClient) GetDeviceLinkGroup(uuid string) (*DeviceLinkGroup, error) {
 // CreateDeviceLinkGroup creates given device link group and returns
 // its identifier upon successful creation
 func (c RestClient) CreateDeviceLinkGroup(linkGroup DeviceLinkGroup) (*string, error) {
+	return c.CreateDeviceLinkGroupWithContext(c.ctx, linkGroup)
+}
+
+// CreateDeviceLinkGroupWithContext creates given device link group and returns
+// its identifier upon successful creation using given context
+func (c RestClient) CreateDeviceLinkGroupWithContext(ctx context.Context, linkGroup DeviceLinkGroup) (*string, error) {
 	path := "/ne/v1/links"
 	reqBody := mapDeviceLinkGroupDomainToAPI(linkGroup)
 	respBody := api.DeviceLinkGroupCreateResponse{}
 	req := c.R().SetBody(&reqBody).SetResult(&respBody)
-	if err := c.Execute(req, http.MethodPost, path); err != nil {
+	if err := c.execute(ctx, req, http.MethodPost, path); err != nil {
 		return nil, err
 	}
 	return respBody.UUID, nil
@@ -64,13 +83,24 @@ func (c RestClient) CreateDeviceLinkGroup(linkGroup DeviceLinkGroup) (*string, e
 // NewDeviceLinkGroupUpdateRequest creates new update request for a device link
 // group with a given identifier
 func (c RestClient) NewDeviceLinkGroupUpdateRequest(uuid string) DeviceLinkUpdateRequest {
+	return c.NewDeviceLinkGroupUpdateRequestWithContext(uuid)
+}
+
+// NewDeviceLinkGroupUpdateRequestWithContext creates new update request for a device link
+// group with a given identifier, that can be executed with per-call context
+func (c RestClient) NewDeviceLinkGroupUpdateRequestWithContext(uuid string) DeviceLinkUpdateRequestWithContext {
 	return &restDeviceLinkUpdateRequest{uuid: uuid, c: c}
 }
 
 // DeleteDeviceLinkGroup removes device link group with a given identifier
 func (c RestClient) DeleteDeviceLinkGroup(uuid string) error {
+	return c.DeleteDeviceLinkGroupWithContext(c.ctx, uuid)
+}
+
+// DeleteDeviceLinkGroupWithContext removes device link group with a given identifier using given context
+func (c RestClient) DeleteDeviceLinkGroupWithContext(ctx context.Context, uuid string) error {
 	path := "/ne/v1/links/" + url.PathEscape(uuid)
-	if err := c.Execute(c.R(), http.MethodDelete, path); err != nil {
+	if err := c.execute(ctx, c.R(), http.MethodDelete, path); err != nil {
 		return err
 	}
 	return nil
@@ -107,6 +137,31 @@ func (req *restDeviceLinkUpdateRequest) WithRedundancyType(redundancyType string
 }
 
 func (req *restDeviceLinkUpdateRequest) Execute() error {
+	return req.ExecuteWithContext(req.c.ctx)
+}
+
+func (req *restDeviceLinkUpdateRequest) ExecuteWithContext(ctx context.Context) error {
+	return req.update(ctx)
+}
+
+// ExecuteWithResult attempts to update device link group according new data set in
+// update request using given context and options. Device link group is updated with
+// single change. Result of the change is returned along with UpdateError, if it failed
+func (req *restDeviceLinkUpdateRequest) ExecuteWithResult(ctx context.Context, opts *UpdateOptions) (*UpdateResult, error) {
+	result := executeChanges(ctx, opts, []updateChange{{
+		changeType: changeTypeUpdate,
+		targets:    []string{"linkGroup"},
+		values:     []interface{}{req.uuid},
+		run:        req.update,
+	}})
+	return result, result.Err()
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Unexported package methods
+//_______________________________________________________________________
+
+func (req *restDeviceLinkUpdateRequest) update(ctx context.Context) error {
 	reqBody := api.DeviceLinkGroupUpdateRequest{}
 	if StringValue(req.groupName) != "" {
 		reqBody.GroupName = req.groupName
@@ -131,16 +186,12 @@ func (req *restDeviceLinkUpdateRequest) Execute() error {
 	}
 	path := "/ne/v1/links/" + url.PathEscape(req.uuid)
 	httpReq := req.c.R().SetBody(&reqBody)
-	if err := req.c.Execute(httpReq, http.MethodPatch, path); err != nil {
+	if err := req.c.execute(ctx, httpReq, http.MethodPatch, path); err != nil {
 		return err
 	}
 	return nil
 }
 
-//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
-// Unexported package methods
-//_______________________________________________________________________
-
 func mapDeviceLinkGroupAPIToDomain(apiLinkGroup api.DeviceLinkGroup) *DeviceLinkGroup {
 	linkGroup := DeviceLinkGroup{}
 	linkGroup.UUID = apiLinkGroup.UUID
@@ -0,0 +1,217 @@
+package ne
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/equinix/ne-go/internal/api"
+)
+
+// DeviceListOptions describes options of device listing.
+// Statuses, metro code and name are sent to the API as query parameters.
+// All filters are applied on fetched devices, so that results do not depend
+// on API honouring query parameters. Empty filters are ignored
+type DeviceListOptions struct {
+	//Statuses limits listed devices to ones with given statuses
+	Statuses []string
+	//MetroCode limits listed devices to ones located in a given metro
+	MetroCode string
+	//TypeCode limits listed devices to ones of a given device type
+	TypeCode string
+	//AccountNumber limits listed devices to ones billed to a given account
+	AccountNumber string
+	//ProjectID limits listed devices to ones that belong to a given project
+	ProjectID string
+	//NameContains limits listed devices to ones with names containing given
+	//text. Comparison is case insensitive
+	NameContains string
+	//RedundancyType limits listed devices to ones with a given redundancy
+	//type, i.e. PRIMARY or SECONDARY
+	RedundancyType string
+	//LicenseStatuses limits listed devices to ones with given license statuses
+	LicenseStatuses []string
+	//Clustered, when set, limits listed devices to cluster devices (true)
+	//or devices that are not part of a cluster (false)
+	Clustered *bool
+	//PageSize is a number of devices fetched with single request.
+	//Client's PageSize is used when not set
+	PageSize int
+}
+
+// DeviceIterator iterates over devices, fetching pages of devices lazily.
+// Iteration can be stopped at any time, pages that were not reached are
+// not fetched
+//
+//	it := client.ListDevices(ctx, &ne.DeviceListOptions{Statuses: []string{ne.DeviceStateProvisioned}})
+//	for it.Next() {
+//	    device := it.Device()
+//	}
+//	if err := it.Err(); err != nil {
+//	    return err
+//	}
+type DeviceIterator struct {
+	client   RestClient
+	ctx      context.Context
+	opts     DeviceListOptions
+	page     []Device
+	index    int
+	offset   int
+	total    int
+	pageSize int
+	fetched  bool
+	done     bool
+	err      error
+}
+
+// GetDevicesWithOptions retrieves list of devices (along with their details)
+// matching given options using given context
+func (c RestClient) GetDevicesWithOptions(ctx context.Context, opts *DeviceListOptions) ([]Device, error) {
+	var devices []Device
+	it := c.ListDevices(ctx, opts)
+	for it.Next() {
+		devices = append(devices, *it.Device())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return devices, nil
+}
+
+// ListDevices returns iterator over devices matching given options
+func (c RestClient) ListDevices(ctx context.Context, opts *DeviceListOptions) *DeviceIterator {
+	it := &DeviceIterator{client: c, ctx: ctx, index: -1}
+	if opts != nil {
+		it.opts = *opts
+	}
+	it.pageSize = it.opts.PageSize
+	if it.pageSize <= 0 {
+		it.pageSize = c.PageSize
+	}
+	return it
+}
+
+// Next advances iterator to the next device matching list options, fetching
+// next pages of devices when needed. Next returns false when there are no
+// more devices or when error occurred
+func (it *DeviceIterator) Next() bool {
+	if it.done {
+		return false
+	}
+	for {
+		it.index++
+		for it.index >= len(it.page) {
+			if it.fetched && it.offset >= it.total {
+				it.done = true
+				return false
+			}
+			if err := it.fetch(); err != nil {
+				it.err = err
+				it.done = true
+				return false
+			}
+		}
+		if it.opts.matches(it.page[it.index]) {
+			return true
+		}
+	}
+}
+
+// Device returns device at current iterator position
+func (it *DeviceIterator) Device() *Device {
+	if it.index < 0 || it.index >= len(it.page) {
+		return nil
+	}
+	return &it.page[it.index]
+}
+
+// Err returns error that stopped iteration, if any
+func (it *DeviceIterator) Err() error {
+	return it.err
+}
+
+// PageSize returns number of devices fetched with single request
+func (it *DeviceIterator) PageSize() int {
+	return it.pageSize
+}
+
+// Total returns total number of devices reported by API. Filters applied
+// on fetched devices are not reflected in a total.
+// Total is known once first page is fetched, before that zero is returned
+func (it *DeviceIterator) Total() int {
+	return it.total
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Unexported package methods
+//_______________________________________________________________________
+
+// fetch retrieves next page of devices
+func (it *DeviceIterator) fetch() error {
+	path := "/ne/v1/devices"
+	respBody := api.DevicesResponse{}
+	req := it.client.R().SetResult(&respBody).
+		SetQueryParam("limit", strconv.Itoa(it.pageSize))
+	if it.fetched {
+		req.SetQueryParam("offset", strconv.Itoa(it.offset))
+	}
+	if len(it.opts.Statuses) > 0 {
+		req.SetQueryParam("status", buildQueryParamValueString(it.opts.Statuses))
+	}
+	if it.opts.MetroCode != "" {
+		req.SetQueryParam("metroCode", it.opts.MetroCode)
+	}
+	if it.opts.NameContains != "" {
+		req.SetQueryParam("searchText", it.opts.NameContains)
+	}
+	if err := it.client.execute(it.ctx, req, http.MethodGet, path); err != nil {
+		return err
+	}
+	it.fetched = true
+	it.total = respBody.Pagination.Total
+	it.offset += len(respBody.Data)
+	if len(respBody.Data) == 0 {
+		it.offset = it.total
+	}
+	it.page = make([]Device, len(respBody.Data))
+	for i := range respBody.Data {
+		it.page[i] = *mapDeviceAPIToDomain(respBody.Data[i])
+	}
+	it.index = 0
+	return nil
+}
+
+//matches verifies if device matches list options. Options sent to the API are
+//verified again, as API search text matches other device attributes too
+func (o DeviceListOptions) matches(device Device) bool {
+	if len(o.Statuses) > 0 && !containsString(o.Statuses, StringValue(device.Status)) {
+		return false
+	}
+	if o.MetroCode != "" && StringValue(device.MetroCode) != o.MetroCode {
+		return false
+	}
+	if o.TypeCode != "" && StringValue(device.TypeCode) != o.TypeCode {
+		return false
+	}
+	if o.AccountNumber != "" && StringValue(device.AccountNumber) != o.AccountNumber {
+		return false
+	}
+	if o.ProjectID != "" && StringValue(device.ProjectID) != o.ProjectID {
+		return false
+	}
+	if o.NameContains != "" &&
+		!strings.Contains(strings.ToLower(StringValue(device.Name)), strings.ToLower(o.NameContains)) {
+		return false
+	}
+	if o.RedundancyType != "" && StringValue(device.RedundancyType) != o.RedundancyType {
+		return false
+	}
+	if len(o.LicenseStatuses) > 0 && !containsString(o.LicenseStatuses, StringValue(device.LicenseStatus)) {
+		return false
+	}
+	if o.Clustered != nil && *o.Clustered != (device.ClusterDetails != nil) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,167 @@
+package ne
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/equinix/ne-go/internal/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockedDevicePages(total int, requests *[]string) *http.Client {
+	return setupMockedCappedDevicePages(total, total, requests)
+}
+
+//setupMockedCappedDevicePages mocks device pages with at most maxLimit devices each
+func setupMockedCappedDevicePages(total int, maxLimit int, requests *[]string) *http.Client {
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices", baseURL),
+		func(r *http.Request) (*http.Response, error) {
+			*requests = append(*requests, r.URL.RawQuery)
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			if limit > maxLimit {
+				limit = maxLimit
+			}
+			resp := api.DevicesResponse{Pagination: api.Pagination{Offset: offset, Limit: limit, Total: total}}
+			for i := offset; i < offset+limit && i < total; i++ {
+				resp.Data = append(resp.Data, api.Device{UUID: String(strconv.Itoa(i)),
+					Status: String(DeviceStateProvisioned), MetroCode: String("SV")})
+			}
+			return httpmock.NewJsonResponse(200, resp)
+		},
+	)
+	return testHc
+}
+
+func TestListDevices(t *testing.T) {
+	//given
+	var requests []string
+	testHc := setupMockedDevicePages(5, &requests)
+	defer httpmock.DeactivateAndReset()
+	c := NewClient(context.Background(), baseURL, testHc)
+	//when
+	it := c.ListDevices(context.Background(), &DeviceListOptions{Statuses: []string{DeviceStateProvisioned}, PageSize: 2})
+	var uuids []string
+	for it.Next() {
+		uuids = append(uuids, StringValue(it.Device().UUID))
+	}
+	//then
+	assert.Nil(t, it.Err(), "Error is not returned")
+	assert.Equal(t, []string{"0", "1", "2", "3", "4"}, uuids, "All devices are listed")
+	assert.Equal(t, 5, it.Total(), "Total matches")
+	assert.Equal(t, 2, it.PageSize(), "Page size matches")
+	assert.Equal(t, []string{"limit=2&status=PROVISIONED", "limit=2&offset=2&status=PROVISIONED",
+		"limit=2&offset=4&status=PROVISIONED"}, requests, "Pages are requested")
+	assert.False(t, it.Next(), "Iteration is finished")
+}
+
+func TestListDevices_cappedLimit(t *testing.T) {
+	//given
+	var requests []string
+	testHc := setupMockedCappedDevicePages(7, 3, &requests)
+	defer httpmock.DeactivateAndReset()
+	c := NewClient(context.Background(), baseURL, testHc)
+	//when
+	devices, err := c.GetDevicesWithOptions(context.Background(), &DeviceListOptions{PageSize: 10})
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, 7, len(devices), "No device is skipped")
+	assert.Equal(t, []string{"limit=10", "limit=10&offset=3", "limit=10&offset=6"}, requests,
+		"Offset advances by number of returned devices")
+}
+
+func TestListDevices_earlyStop(t *testing.T) {
+	//given
+	var requests []string
+	testHc := setupMockedDevicePages(100, &requests)
+	defer httpmock.DeactivateAndReset()
+	c := NewClient(context.Background(), baseURL, testHc)
+	c.PageSize = 10
+	//when
+	it := c.ListDevices(context.Background(), nil)
+	totalBeforeFetch := it.Total()
+	for i := 0; i < 3 && it.Next(); i++ {
+	}
+	//then
+	assert.Nil(t, it.Err(), "Error is not returned")
+	assert.Equal(t, 0, totalBeforeFetch, "Total is not known before first page is fetched")
+	assert.Equal(t, "2", StringValue(it.Device().UUID), "Current device matches")
+	assert.Equal(t, 100, it.Total(), "Total matches")
+	assert.Equal(t, 10, it.PageSize(), "Client page size is used")
+	assert.Equal(t, 1, len(requests), "Only first page is requested")
+}
+
+func TestListDevices_empty(t *testing.T) {
+	//given
+	var requests []string
+	testHc := setupMockedDevicePages(0, &requests)
+	defer httpmock.DeactivateAndReset()
+	c := NewClient(context.Background(), baseURL, testHc)
+	//when
+	it := c.ListDevices(context.Background(), nil)
+	//then
+	assert.False(t, it.Next(), "There are no devices")
+	assert.Nil(t, it.Device(), "Device is not returned")
+	assert.Nil(t, it.Err(), "Error is not returned")
+	assert.Equal(t, 1, len(requests), "Single page is requested")
+}
+
+func TestListDevices_error(t *testing.T) {
+	//given
+	testHc := setupMockedClient("GET", fmt.Sprintf("%s/ne/v1/devices?limit=20", baseURL), http.StatusInternalServerError, api.ErrorResponses{})
+	defer httpmock.DeactivateAndReset()
+	c := NewClient(context.Background(), baseURL, testHc)
+	c.PageSize = 20
+	//when
+	it := c.ListDevices(context.Background(), nil)
+	//then
+	assert.False(t, it.Next(), "Iteration is stopped")
+	assert.NotNil(t, it.Err(), "Error is returned")
+	assert.False(t, it.Next(), "Iteration stays stopped")
+}
+
+func TestListDevices_filters(t *testing.T) {
+	//given
+	var requests []string
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices", baseURL),
+		func(r *http.Request) (*http.Response, error) {
+			requests = append(requests, r.URL.RawQuery)
+			resp := api.DevicesResponse{Pagination: api.Pagination{Total: 6}, Data: []api.Device{
+				{UUID: String("0"), MetroCode: String("SV"), Name: String("Edge-Router"), DeviceTypeCode: String("CSR1000V"), AccountNumber: String("1"), RedundancyType: String("PRIMARY"), LicenseStatus: String(DeviceLicenseStateRegistered)},
+				{UUID: String("1"), MetroCode: String("SV"), Name: String("router-edge"), DeviceTypeCode: String("CSR1000V"), AccountNumber: String("1"), RedundancyType: String("PRIMARY"), LicenseStatus: String(DeviceLicenseStateRegistered), ClusterDetails: &api.ClusterDetails{Nodes: []api.ClusterNode{{}, {}}}},
+				{UUID: String("2"), MetroCode: String("SV"), Name: String("edge"), DeviceTypeCode: String("PA-VM"), AccountNumber: String("1"), RedundancyType: String("PRIMARY"), LicenseStatus: String(DeviceLicenseStateRegistered)},
+				{UUID: String("3"), MetroCode: String("SV"), Name: String("edge"), DeviceTypeCode: String("CSR1000V"), AccountNumber: String("2"), RedundancyType: String("SECONDARY"), LicenseStatus: String(DeviceLicenseStateRegistered)},
+				{UUID: String("4"), MetroCode: String("SV"), Name: String("description has edge"), DeviceTypeCode: String("CSR1000V"), AccountNumber: String("1"), RedundancyType: String("PRIMARY"), LicenseStatus: String(DeviceLicenseStateFailed)},
+				{UUID: String("5"), MetroCode: String("DC"), Name: String("edge"), DeviceTypeCode: String("CSR1000V"), AccountNumber: String("1"), RedundancyType: String("PRIMARY"), LicenseStatus: String(DeviceLicenseStateRegistered)},
+			}}
+			return httpmock.NewJsonResponse(200, resp)
+		},
+	)
+	c := NewClient(context.Background(), baseURL, testHc)
+	opts := DeviceListOptions{
+		MetroCode:       "SV",
+		TypeCode:        "CSR1000V",
+		AccountNumber:   "1",
+		NameContains:    "EDGE",
+		RedundancyType:  "PRIMARY",
+		LicenseStatuses: []string{DeviceLicenseStateRegistered},
+		Clustered:       Bool(false),
+		PageSize:        10,
+	}
+	//when
+	devices, err := c.GetDevicesWithOptions(context.Background(), &opts)
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, 1, len(devices), "Single device matches")
+	assert.Equal(t, "0", StringValue(devices[0].UUID), "Matching device is returned")
+	assert.Equal(t, []string{"limit=10&metroCode=SV&searchText=EDGE"}, requests, "API filters are sent as query parameters")
+}
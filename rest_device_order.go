@@ -0,0 +1,261 @@
+package ne
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/equinix/ne-go/internal/api"
+)
+
+// DeviceOrderViolation describes single problem found in a device order
+type DeviceOrderViolation struct {
+	//Field is a name of a Device field that violates catalog constraints
+	Field string
+	//Value is a value of a field
+	Value interface{}
+	//Message describes a violation
+	Message string
+}
+
+func (v DeviceOrderViolation) String() string {
+	return fmt.Sprintf("%s: %s", v.Field, v.Message)
+}
+
+// DeviceOrderValidationError describes device order that violates constraints
+// of device type catalog or accounts available in a metro.
+// DeviceOrderValidationError matches ErrValidation with errors.Is
+type DeviceOrderValidationError struct {
+	Violations []DeviceOrderViolation
+}
+
+func (e DeviceOrderValidationError) Error() string {
+	violations := make([]string, len(e.Violations))
+	for i := range e.Violations {
+		violations[i] = e.Violations[i].String()
+	}
+	return fmt.Sprintf("device order is not valid: %d violations [%s]", len(e.Violations), strings.Join(violations, "; "))
+}
+
+// Is verifies if validation error matches given sentinel error
+func (e DeviceOrderValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// SetDeviceOrderValidation enables or disables validation of device orders
+// before CreateDevice and CreateRedundantDevice requests are sent
+func (c *RestClient) SetDeviceOrderValidation(enabled bool) *RestClient {
+	c.validateOrders = enabled
+	return c
+}
+
+// ValidateDeviceOrder cross-checks given device order with device types catalog
+// and accounts available in device's metro. Device type has to be available in
+// a metro, core count has to match one of platform flavors and management type,
+// license mode and package code have to be offered for that flavor. Software version
+// has to be offered for a package code. All violations are returned at once with
+// DeviceOrderValidationError. Other errors are returned when catalog can't be fetched
+func (c RestClient) ValidateDeviceOrder(ctx context.Context, device Device) error {
+	violations, err := c.validateDeviceOrder(ctx, device, "")
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return DeviceOrderValidationError{Violations: violations}
+	}
+	return nil
+}
+
+// ValidateRedundantDeviceOrder cross-checks given HA device order the same way as
+// ValidateDeviceOrder. Secondary device is validated in its own metro and account,
+// with remaining attributes of a primary device. Fields of secondary device
+// violations are prefixed with "Secondary."
+func (c RestClient) ValidateRedundantDeviceOrder(ctx context.Context, primary Device, secondary Device) error {
+	violations, err := c.validateDeviceOrder(ctx, primary, "")
+	if err != nil {
+		return err
+	}
+	secondaryOrder := primary
+	secondaryOrder.MetroCode = secondary.MetroCode
+	secondaryOrder.AccountNumber = secondary.AccountNumber
+	secondaryViolations, err := c.validateDeviceOrder(ctx, secondaryOrder, "Secondary.")
+	if err != nil {
+		return err
+	}
+	for _, violation := range secondaryViolations {
+		if violation.Field == "Secondary.MetroCode" || violation.Field == "Secondary.AccountNumber" {
+			violations = append(violations, violation)
+		}
+	}
+	if len(violations) > 0 {
+		return DeviceOrderValidationError{Violations: violations}
+	}
+	return nil
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Unexported package methods
+//_______________________________________________________________________
+
+//validateDeviceOrder returns violations of a given device order. Violation fields
+//are prefixed with given prefix
+func (c RestClient) validateDeviceOrder(ctx context.Context, device Device, prefix string) ([]DeviceOrderViolation, error) {
+	var violations []DeviceOrderViolation
+	violate := func(field string, value interface{}, format string, args ...interface{}) {
+		violations = append(violations, DeviceOrderViolation{
+			Field:   prefix + field,
+			Value:   value,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+	typeCode := StringValue(device.TypeCode)
+	metroCode := StringValue(device.MetroCode)
+	if metroCode == "" {
+		violate("MetroCode", metroCode, "metro code is required")
+	}
+	if typeCode == "" {
+		violate("TypeCode", typeCode, "device type code is required")
+		return violations, nil
+	}
+	types, err := c.GetDeviceTypesWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var deviceType *DeviceType
+	for i := range types {
+		if StringValue(types[i].Code) == typeCode {
+			deviceType = &types[i]
+			break
+		}
+	}
+	if deviceType == nil {
+		violate("TypeCode", typeCode, "device type %q does not exist", typeCode)
+		return violations, nil
+	}
+	if metroCode != "" && !containsString(deviceType.MetroCodes, metroCode) {
+		violate("MetroCode", metroCode, "device type %q is not available in metro %q, available metros: %s",
+			typeCode, metroCode, strings.Join(deviceType.MetroCodes, ", "))
+	}
+	apiType, err := c.getDeviceType(ctx, typeCode)
+	if err != nil {
+		return nil, err
+	}
+	violations = append(violations, validateDeviceOrderPlatform(device, *apiType, prefix)...)
+	violations = append(violations, validateDeviceOrderVersion(device, mapDeviceTypeAPIToDeviceSoftwareVersions(*apiType), prefix)...)
+	if metroCode != "" && device.AccountNumber != nil {
+		accounts, err := c.GetAccountsWithContext(ctx, metroCode)
+		if err != nil {
+			return nil, err
+		}
+		found := false
+		for i := range accounts {
+			if StringValue(accounts[i].Number) == StringValue(device.AccountNumber) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			violate("AccountNumber", StringValue(device.AccountNumber), "account %q is not available in metro %q",
+				StringValue(device.AccountNumber), metroCode)
+		}
+	}
+	return violations, nil
+}
+
+//validateDeviceOrderPlatform verifies if device order matches one of platforms of given
+//device type. License mode and package code are verified against platforms of ordered
+//management type with ordered core count
+func validateDeviceOrderPlatform(device Device, apiType api.DeviceType, prefix string) []DeviceOrderViolation {
+	var violations []DeviceOrderViolation
+	platforms := mapDeviceTypeAPIToDevicePlatforms(apiType)
+	if device.IsSelfManaged != nil {
+		mgmtType, mgmtTypeName := apiType.DeviceManagementTypes.EquinixConfigured, DeviceManagementTypeEquinix
+		if *device.IsSelfManaged {
+			mgmtType, mgmtTypeName = apiType.DeviceManagementTypes.SelfConfigured, DeviceManagementTypeSelf
+		}
+		platforms = flattenMgmtType(mgmtType)
+		if len(platforms) == 0 {
+			violations = append(violations, DeviceOrderViolation{
+				Field:   prefix + "IsSelfManaged",
+				Value:   *device.IsSelfManaged,
+				Message: fmt.Sprintf("management type %q is not offered for device type %q", mgmtTypeName, StringValue(device.TypeCode)),
+			})
+			return violations
+		}
+	}
+	candidates := platforms
+	if device.CoreCount != nil {
+		candidates = nil
+		for i := range platforms {
+			if IntValue(platforms[i].CoreCount) == IntValue(device.CoreCount) {
+				candidates = append(candidates, platforms[i])
+			}
+		}
+		if len(candidates) == 0 {
+			violations = append(violations, DeviceOrderViolation{
+				Field:   prefix + "CoreCount",
+				Value:   IntValue(device.CoreCount),
+				Message: fmt.Sprintf("core count %d does not match any platform flavor of device type %q", IntValue(device.CoreCount), StringValue(device.TypeCode)),
+			})
+			return violations
+		}
+	}
+	if device.IsBYOL != nil {
+		licenseMode := DeviceLicenseModeSubscription
+		if *device.IsBYOL {
+			licenseMode = DeviceLicenseModeBYOL
+		}
+		if !platformsOffer(candidates, func(p DevicePlatform) []string { return p.LicenseOptions }, licenseMode) {
+			violations = append(violations, DeviceOrderViolation{
+				Field:   prefix + "IsBYOL",
+				Value:   *device.IsBYOL,
+				Message: fmt.Sprintf("license mode %q is not offered for ordered platform", licenseMode),
+			})
+		}
+	}
+	if device.PackageCode != nil {
+		if !platformsOffer(candidates, func(p DevicePlatform) []string { return p.PackageCodes }, *device.PackageCode) {
+			violations = append(violations, DeviceOrderViolation{
+				Field:   prefix + "PackageCode",
+				Value:   *device.PackageCode,
+				Message: fmt.Sprintf("package code %q is not offered for ordered platform", *device.PackageCode),
+			})
+		}
+	}
+	return violations
+}
+
+//validateDeviceOrderVersion verifies if ordered software version is offered
+//for ordered package code
+func validateDeviceOrderVersion(device Device, versions []DeviceSoftwareVersion, prefix string) []DeviceOrderViolation {
+	if device.Version == nil {
+		return nil
+	}
+	for i := range versions {
+		if StringValue(versions[i].Version) != *device.Version {
+			continue
+		}
+		if device.PackageCode != nil && !containsString(versions[i].PackageCodes, *device.PackageCode) {
+			return []DeviceOrderViolation{{
+				Field:   prefix + "Version",
+				Value:   *device.Version,
+				Message: fmt.Sprintf("software version %q is not offered for package code %q", *device.Version, *device.PackageCode),
+			}}
+		}
+		return nil
+	}
+	return []DeviceOrderViolation{{
+		Field:   prefix + "Version",
+		Value:   *device.Version,
+		Message: fmt.Sprintf("software version %q is not offered for device type %q", *device.Version, StringValue(device.TypeCode)),
+	}}
+}
+
+func platformsOffer(platforms []DevicePlatform, values func(p DevicePlatform) []string, value string) bool {
+	for i := range platforms {
+		if containsString(values(platforms[i]), value) {
+			return true
+		}
+	}
+	return false
+}
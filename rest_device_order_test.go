@@ -0,0 +1,135 @@
+package ne
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/equinix/ne-go/internal/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+//setupMockedCatalog registers responders for device types, CSR1000V device type
+//and accounts of a DC metro
+func setupMockedCatalog(t *testing.T) *http.Client {
+	types := api.DeviceTypeResponse{}
+	if err := readJSONData("./test-fixtures/ne_device_types_get.json", &types); err != nil {
+		assert.Failf(t, "cannot read test response due to %s", err.Error())
+	}
+	csrType := api.DeviceTypeResponse{}
+	if err := readJSONData("./test-fixtures/ne_devices_types_csr1000v_get.json", &csrType); err != nil {
+		assert.Failf(t, "cannot read test response due to %s", err.Error())
+	}
+	accounts := api.AccountResponse{}
+	if err := readJSONData("./test-fixtures/ne_accounts.json", &accounts); err != nil {
+		assert.Failf(t, "cannot read test response due to %s", err.Error())
+	}
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/deviceTypes?limit=%d", baseURL, types.Pagination.Limit),
+		httpmock.NewJsonResponderOrPanic(200, types))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/deviceTypes?deviceTypeCode=CSR1000V&limit=%d", baseURL, csrType.Pagination.Limit),
+		httpmock.NewJsonResponderOrPanic(200, csrType))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/accounts/DC", baseURL),
+		httpmock.NewJsonResponderOrPanic(200, accounts))
+	return testHc
+}
+
+func TestValidateDeviceOrder(t *testing.T) {
+	//given
+	testHc := setupMockedCatalog(t)
+	defer httpmock.DeactivateAndReset()
+	device := Device{
+		TypeCode:      String("CSR1000V"),
+		MetroCode:     String("DC"),
+		AccountNumber: String("200481"),
+		CoreCount:     Int(4),
+		PackageCode:   String("SEC"),
+		Version:       String("16.09.05"),
+		IsSelfManaged: Bool(true),
+		IsBYOL:        Bool(true),
+	}
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	c.PageSize = 100
+	err := c.ValidateDeviceOrder(context.Background(), device)
+	//then
+	assert.Nil(t, err, "Error is not returned")
+}
+
+func TestValidateDeviceOrder_violations(t *testing.T) {
+	//given
+	testHc := setupMockedCatalog(t)
+	defer httpmock.DeactivateAndReset()
+	device := Device{
+		TypeCode:      String("CSR1000V"),
+		MetroCode:     String("DC"),
+		AccountNumber: String("999999"),
+		CoreCount:     Int(3),
+		PackageCode:   String("SEC"),
+		Version:       String("17.01.01"),
+		IsSelfManaged: Bool(true),
+		IsBYOL:        Bool(false),
+	}
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	c.PageSize = 100
+	err := c.ValidateDeviceOrder(context.Background(), device)
+	//then
+	validationErr := DeviceOrderValidationError{}
+	assert.True(t, errors.As(err, &validationErr), "DeviceOrderValidationError is returned")
+	assert.True(t, IsValidationError(err), "Error matches ErrValidation")
+	var fields []string
+	for _, violation := range validationErr.Violations {
+		fields = append(fields, violation.Field)
+	}
+	assert.Equal(t, []string{"CoreCount", "Version", "AccountNumber"}, fields, "All violations are returned")
+}
+
+func TestValidateDeviceOrder_platform(t *testing.T) {
+	//given
+	testHc := setupMockedCatalog(t)
+	defer httpmock.DeactivateAndReset()
+	device := Device{
+		TypeCode:      String("CSR1000V"),
+		MetroCode:     String("SV"),
+		CoreCount:     Int(2),
+		PackageCode:   String("UNKNOWN"),
+		IsSelfManaged: Bool(true),
+		IsBYOL:        Bool(false),
+	}
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	c.PageSize = 100
+	err := c.ValidateDeviceOrder(context.Background(), device)
+	//then
+	validationErr := DeviceOrderValidationError{}
+	assert.True(t, errors.As(err, &validationErr), "DeviceOrderValidationError is returned")
+	var fields []string
+	for _, violation := range validationErr.Violations {
+		fields = append(fields, violation.Field)
+	}
+	assert.Equal(t, []string{"MetroCode", "IsBYOL", "PackageCode"}, fields, "Metro, license mode and package code violations are returned")
+}
+
+func TestCreateRedundantDevice_orderValidation(t *testing.T) {
+	//given
+	testHc := setupMockedCatalog(t)
+	defer httpmock.DeactivateAndReset()
+	primary := Device{TypeCode: String("CSR1000V"), MetroCode: String("DC"), CoreCount: Int(2)}
+	secondary := Device{MetroCode: String("AM")}
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	c.PageSize = 100
+	c.SetDeviceOrderValidation(true)
+	_, _, err := c.CreateRedundantDevice(primary, secondary)
+	//then
+	validationErr := DeviceOrderValidationError{}
+	assert.True(t, errors.As(err, &validationErr), "DeviceOrderValidationError is returned")
+	assert.Equal(t, 1, len(validationErr.Violations), "Single violation is returned")
+	assert.Equal(t, "Secondary.MetroCode", validationErr.Violations[0].Field, "Secondary metro violation is returned")
+	assert.Equal(t, 0, httpmock.GetCallCountInfo()[fmt.Sprintf("POST %s/ne/v1/devices", baseURL)], "Device order is not sent")
+}
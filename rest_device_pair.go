@@ -0,0 +1,220 @@
+package ne
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const (
+	//DeviceRedundancyTypePrimary indicates primary device of a redundant device pair
+	DeviceRedundancyTypePrimary = "PRIMARY"
+	//DeviceRedundancyTypeSecondary indicates secondary device of a redundant device pair
+	DeviceRedundancyTypeSecondary = "SECONDARY"
+)
+
+// DevicePair describes Network Edge redundant (HA) device pair
+type DevicePair struct {
+	Primary   *Device
+	Secondary *Device
+}
+
+// DevicePairUpdateRequest is a composite update request that applies
+// the same changes to both devices of a redundant device pair. Core count
+// is not updated with pair requests, use UpgradeDeviceResources instead,
+// as it upgrades devices one after another
+type DevicePairUpdateRequest struct {
+	primary   *restDeviceUpdateRequest
+	secondary *restDeviceUpdateRequest
+	//err is a validation error of a pair that request was created for
+	err error
+}
+
+// GetDevicePair fetches redundant device pair that device with a given UUID,
+// either primary or secondary one, belongs to. Redundant device is resolved
+// from RedundantUUID of a given device. Error is returned when device is not
+// a part of redundant device pair. Use GetDevicePairByUUIDs to fetch both
+// devices concurrently, when UUIDs of both of them are known
+func (c RestClient) GetDevicePair(ctx context.Context, uuid string) (*DevicePair, error) {
+	device, err := c.GetDeviceWithContext(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if StringValue(device.RedundantUUID) == "" {
+		return nil, fmt.Errorf("device %q is not a part of redundant device pair", uuid)
+	}
+	redundant, err := c.GetDeviceWithContext(ctx, StringValue(device.RedundantUUID))
+	if err != nil {
+		return nil, err
+	}
+	return newDevicePair(device, redundant)
+}
+
+// GetDevicePairByUUIDs fetches primary and secondary device of a redundant
+// device pair concurrently. Error is returned when given devices are not
+// paired with each other
+func (c RestClient) GetDevicePairByUUIDs(ctx context.Context, primaryUUID, secondaryUUID string) (*DevicePair, error) {
+	uuids := []string{primaryUUID, secondaryUUID}
+	devices := make([]*Device, len(uuids))
+	errs := make([]error, len(uuids))
+	wg := sync.WaitGroup{}
+	for i := range uuids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			devices[i], errs[i] = c.GetDeviceWithContext(ctx, uuids[i])
+		}(i)
+	}
+	wg.Wait()
+	for i := range errs {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+	}
+	pair, err := newDevicePair(devices[0], devices[1])
+	if err != nil {
+		return nil, err
+	}
+	if StringValue(pair.Primary.UUID) != primaryUUID {
+		return nil, fmt.Errorf("device %q is not a primary device of redundant device pair", primaryUUID)
+	}
+	return pair, nil
+}
+
+// NewDevicePairUpdateRequest creates new composite update request for both
+// devices of a given redundant device pair. When pair lacks any of devices
+// or their UUIDs, request fails on execution without making any change
+func (c RestClient) NewDevicePairUpdateRequest(pair DevicePair) *DevicePairUpdateRequest {
+	var primaryUUID, secondaryUUID string
+	err := pair.validate()
+	if err == nil {
+		primaryUUID, secondaryUUID = StringValue(pair.Primary.UUID), StringValue(pair.Secondary.UUID)
+	}
+	return &DevicePairUpdateRequest{
+		primary:   c.NewDeviceUpdateRequest(primaryUUID).(*restDeviceUpdateRequest),
+		secondary: c.NewDeviceUpdateRequest(secondaryUUID).(*restDeviceUpdateRequest),
+		err:       err,
+	}
+}
+
+// BreakDevicePair deletes secondary device of a redundant device pair that device
+// with a given UUID, either primary or secondary one, belongs to. Primary device
+// is left in place as a single device
+func (c RestClient) BreakDevicePair(ctx context.Context, uuid string) error {
+	pair, err := c.GetDevicePair(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	if err := pair.validate(); err != nil {
+		return err
+	}
+	return c.DeleteSecondaryDeviceWithContext(ctx, StringValue(pair.Secondary.UUID))
+}
+
+// WithTermLength sets new term length of both devices in a pair update request
+func (req *DevicePairUpdateRequest) WithTermLength(termLength int) *DevicePairUpdateRequest {
+	req.primary.WithTermLength(termLength)
+	req.secondary.WithTermLength(termLength)
+	return req
+}
+
+// WithNotifications sets new notifications of both devices in a pair update request
+func (req *DevicePairUpdateRequest) WithNotifications(notifications []string) *DevicePairUpdateRequest {
+	req.primary.WithNotifications(notifications)
+	req.secondary.WithNotifications(notifications)
+	return req
+}
+
+// WithAdditionalBandwidth sets new additional bandwidth of both devices in a pair update request
+func (req *DevicePairUpdateRequest) WithAdditionalBandwidth(additionalBandwidth int) *DevicePairUpdateRequest {
+	req.primary.WithAdditionalBandwidth(additionalBandwidth)
+	req.secondary.WithAdditionalBandwidth(additionalBandwidth)
+	return req
+}
+
+// WithACLTemplates sets new ACL template identifiers of primary and secondary
+// device in a pair update request
+func (req *DevicePairUpdateRequest) WithACLTemplates(primaryTemplateID, secondaryTemplateID string) *DevicePairUpdateRequest {
+	req.primary.WithACLTemplate(primaryTemplateID)
+	req.secondary.WithACLTemplate(secondaryTemplateID)
+	return req
+}
+
+// WithMgmtAclTemplates sets new MGMT ACL template identifiers of primary and secondary
+// device in a pair update request
+func (req *DevicePairUpdateRequest) WithMgmtAclTemplates(primaryTemplateID, secondaryTemplateID string) *DevicePairUpdateRequest {
+	req.primary.WithMgmtAclTemplate(primaryTemplateID)
+	req.secondary.WithMgmtAclTemplate(secondaryTemplateID)
+	return req
+}
+
+// Execute attempts to update both devices of a pair according new data set in
+// pair update request using given context. UpdateError will be returned if any
+// of requested data failed to update on any of devices
+func (req *DevicePairUpdateRequest) Execute(ctx context.Context) error {
+	_, err := req.ExecuteWithResult(ctx, nil)
+	return err
+}
+
+// ExecuteWithResult attempts to update both devices of a pair according new data set
+// in pair update request using given context and options. Each change is applied to
+// primary and then to secondary device, or to both at once when concurrency allows.
+// Changes are identified by targets prefixed with "primary." or "secondary.".
+// Result with outcome of every attempted change is returned along with UpdateError,
+// if any change failed. Pair validation error is returned, without result, when
+// request was created for a pair that lacks any of devices or their UUIDs
+func (req *DevicePairUpdateRequest) ExecuteWithResult(ctx context.Context, opts *UpdateOptions) (*UpdateResult, error) {
+	if req.err != nil {
+		return nil, req.err
+	}
+	primary := req.primary.changes()
+	secondary := req.secondary.changes()
+	groups := make([][]updateChange, len(primary))
+	for i := range primary {
+		for _, change := range primary[i] {
+			groups[i] = append(groups[i], prefixedChange(change, "primary."))
+		}
+		for _, change := range secondary[i] {
+			groups[i] = append(groups[i], prefixedChange(change, "secondary."))
+		}
+	}
+	result := executeChanges(ctx, opts, groups...)
+	return result, result.Err()
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Unexported package methods
+//_______________________________________________________________________
+
+//newDevicePair creates redundant device pair of given devices, in any order.
+//Error is returned when devices are not paired with each other
+func newDevicePair(device *Device, redundant *Device) (*DevicePair, error) {
+	uuid := StringValue(device.UUID)
+	if StringValue(device.RedundantUUID) != StringValue(redundant.UUID) || StringValue(redundant.RedundantUUID) != uuid {
+		return nil, fmt.Errorf("device %q is not paired with device %q", StringValue(redundant.UUID), uuid)
+	}
+	if StringValue(device.RedundancyType) == DeviceRedundancyTypeSecondary {
+		return &DevicePair{Primary: redundant, Secondary: device}, nil
+	}
+	return &DevicePair{Primary: device, Secondary: redundant}, nil
+}
+
+//validate verifies if pair has both devices with their UUIDs set
+func (p DevicePair) validate() error {
+	if p.Primary == nil || StringValue(p.Primary.UUID) == "" {
+		return fmt.Errorf("device pair has no primary device UUID")
+	}
+	if p.Secondary == nil || StringValue(p.Secondary.UUID) == "" {
+		return fmt.Errorf("device pair has no secondary device UUID")
+	}
+	return nil
+}
+
+func prefixedChange(change updateChange, prefix string) updateChange {
+	targets := make([]string, len(change.targets))
+	for i := range change.targets {
+		targets[i] = prefix + change.targets[i]
+	}
+	change.targets = targets
+	return change
+}
@@ -0,0 +1,138 @@
+package ne
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/equinix/ne-go/internal/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMockedDevicePair() *http.Client {
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices/primary", baseURL),
+		httpmock.NewJsonResponderOrPanic(200, api.Device{UUID: String("primary"),
+			RedundancyType: String(DeviceRedundancyTypePrimary), RedundantUUID: String("secondary")}))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices/secondary", baseURL),
+		httpmock.NewJsonResponderOrPanic(200, api.Device{UUID: String("secondary"),
+			RedundancyType: String(DeviceRedundancyTypeSecondary), RedundantUUID: String("primary")}))
+	return testHc
+}
+
+func TestGetDevicePair(t *testing.T) {
+	//given
+	testHc := setupMockedDevicePair()
+	defer httpmock.DeactivateAndReset()
+	c := NewClient(context.Background(), baseURL, testHc)
+	for _, uuid := range []string{"primary", "secondary"} {
+		//when
+		pair, err := c.GetDevicePair(context.Background(), uuid)
+		//then
+		assert.Nil(t, err, "Error is not returned")
+		assert.Equal(t, "primary", StringValue(pair.Primary.UUID), "Primary device matches")
+		assert.Equal(t, "secondary", StringValue(pair.Secondary.UUID), "Secondary device matches")
+	}
+}
+
+func TestGetDevicePair_notPaired(t *testing.T) {
+	//given
+	devID := "myDevice"
+	testHc := setupMockedClient("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID), 200, api.Device{UUID: String(devID)})
+	defer httpmock.DeactivateAndReset()
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	pair, err := c.GetDevicePair(context.Background(), devID)
+	//then
+	assert.NotNil(t, err, "Error is returned")
+	assert.Nil(t, pair, "Pair is not returned")
+}
+
+func TestUpdateDevicePair(t *testing.T) {
+	//given
+	testHc := setupMockedDevicePair()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("PATCH", fmt.Sprintf("%s/ne/v1/devices/primary", baseURL),
+		httpmock.NewStringResponder(204, ""))
+	httpmock.RegisterResponder("PATCH", fmt.Sprintf("%s/ne/v1/devices/secondary", baseURL),
+		httpmock.NewStringResponder(204, ""))
+	httpmock.RegisterResponder("PATCH", fmt.Sprintf("%s/ne/v1/devices/primary/acl", baseURL),
+		httpmock.NewStringResponder(204, ""))
+	httpmock.RegisterResponder("PATCH", fmt.Sprintf("%s/ne/v1/devices/secondary/acl", baseURL),
+		httpmock.NewJsonResponderOrPanic(500, api.ErrorResponses{}))
+	c := NewClient(context.Background(), baseURL, testHc)
+	pair, _ := c.GetDevicePair(context.Background(), "primary")
+	//when
+	result, err := c.NewDevicePairUpdateRequest(*pair).WithTermLength(24).WithNotifications([]string{"test@test.com"}).
+		WithACLTemplates("primaryACL", "secondaryACL").ExecuteWithResult(context.Background(), nil)
+	//then
+	updateErr := UpdateError{}
+	assert.True(t, errors.As(err, &updateErr), "UpdateError is returned")
+	var targets []string
+	for _, change := range result.Changes {
+		targets = append(targets, change.Target)
+	}
+	assert.Equal(t, []string{"primary.deviceFields", "secondary.deviceFields", "primary.aclTemplateUuid", "secondary.aclTemplateUuid"},
+		targets, "Changes are applied to both devices")
+	assert.Equal(t, 1, len(result.Failed()), "One change failed")
+	assert.Equal(t, "secondary.aclTemplateUuid", result.Failed()[0].Target, "Secondary ACL template change failed")
+}
+
+func TestBreakDevicePair(t *testing.T) {
+	//given
+	testHc := setupMockedDevicePair()
+	defer httpmock.DeactivateAndReset()
+	var deleteRedundant string
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("%s/ne/v1/devices/secondary", baseURL),
+		func(r *http.Request) (*http.Response, error) {
+			deleteRedundant = r.URL.Query().Get("deleteRedundantDevice")
+			return httpmock.NewStringResponse(204, ""), nil
+		},
+	)
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	err := c.BreakDevicePair(context.Background(), "primary")
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, "false", deleteRedundant, "Redundant device is not deleted")
+	assert.Equal(t, 0, httpmock.GetCallCountInfo()[fmt.Sprintf("DELETE %s/ne/v1/devices/primary", baseURL)], "Primary device is not deleted")
+}
+
+func TestGetDevicePairByUUIDs(t *testing.T) {
+	//given
+	testHc := setupMockedDevicePair()
+	defer httpmock.DeactivateAndReset()
+	c := NewClient(context.Background(), baseURL, testHc)
+	//when
+	pair, err := c.GetDevicePairByUUIDs(context.Background(), "primary", "secondary")
+	_, swappedErr := c.GetDevicePairByUUIDs(context.Background(), "secondary", "primary")
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, "primary", StringValue(pair.Primary.UUID), "Primary device matches")
+	assert.Equal(t, "secondary", StringValue(pair.Secondary.UUID), "Secondary device matches")
+	assert.NotNil(t, swappedErr, "Error is returned when devices are swapped")
+}
+
+func TestUpdateDevicePair_invalidPair(t *testing.T) {
+	//given
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	defer httpmock.DeactivateAndReset()
+	c := NewClient(context.Background(), baseURL, testHc)
+	pairs := []DevicePair{
+		{Primary: &Device{UUID: String("primary")}},
+		{Primary: &Device{}, Secondary: &Device{UUID: String("secondary")}},
+	}
+	for _, pair := range pairs {
+		//when
+		result, err := c.NewDevicePairUpdateRequest(pair).WithTermLength(24).ExecuteWithResult(context.Background(), nil)
+		//then
+		assert.NotNil(t, err, "Error is returned")
+		assert.Nil(t, result, "Result is not returned")
+	}
+	assert.Equal(t, 0, httpmock.GetTotalCallCount(), "No requests were sent")
+}
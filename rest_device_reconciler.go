@@ -0,0 +1,391 @@
+package ne
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	//DeviceFieldName is a device name field of a device plan
+	DeviceFieldName = "name"
+	//DeviceFieldTermLength is a term length field of a device plan
+	DeviceFieldTermLength = "termLength"
+	//DeviceFieldNotifications is a notifications field of a device plan
+	DeviceFieldNotifications = "notifications"
+	//DeviceFieldCore is a core count field of a device plan
+	DeviceFieldCore = "core"
+	//DeviceFieldClusterName is a cluster name field of a device plan
+	DeviceFieldClusterName = "clusterName"
+	//DeviceFieldAdditionalBandwidth is an additional bandwidth field of a device plan
+	DeviceFieldAdditionalBandwidth = "additionalBandwidth"
+	//DeviceFieldACLTemplate is an ACL template identifier field of a device plan
+	DeviceFieldACLTemplate = "aclTemplateUuid"
+	//DeviceFieldMgmtACLTemplate is a MGMT ACL template identifier field of a device plan
+	DeviceFieldMgmtACLTemplate = "mgmtAclTemplateUuid"
+	//DeviceFieldMetroCode is a metro code field of a device plan
+	DeviceFieldMetroCode = "metroCode"
+	//DeviceFieldTypeCode is a device type code field of a device plan
+	DeviceFieldTypeCode = "typeCode"
+	//DeviceFieldLicenseMode is a license mode field of a device plan
+	DeviceFieldLicenseMode = "licenseMode"
+	//DeviceFieldPackageCode is a software package code field of a device plan
+	DeviceFieldPackageCode = "packageCode"
+)
+
+// DeviceFieldChange describes difference between current and desired
+// value of a single device field
+type DeviceFieldChange struct {
+	//Field is a name of a changed field, i.e. DeviceFieldName
+	Field string
+	//Current is a value of a field on a live device
+	Current interface{}
+	//Desired is a value of a field on a desired device
+	Desired interface{}
+}
+
+func (c DeviceFieldChange) String() string {
+	return fmt.Sprintf("%s: %v -> %v", c.Field, c.Current, c.Desired)
+}
+
+// DevicePlan describes changes needed to bring live Network Edge device
+// to its desired configuration. Updates can be applied in place, while
+// replacements can't be applied without creating new device
+type DevicePlan struct {
+	//UUID is an identifier of a planned device
+	UUID string
+	//Updates are changes that can be applied in place
+	Updates []DeviceFieldChange
+	//Replacements are changes that force device replacement
+	Replacements []DeviceFieldChange
+}
+
+// HasChanges verifies if plan contains any changes
+func (p DevicePlan) HasChanges() bool {
+	return len(p.Updates) > 0 || len(p.Replacements) > 0
+}
+
+// RequiresReplacement verifies if plan contains changes that force device replacement
+func (p DevicePlan) RequiresReplacement() bool {
+	return len(p.Replacements) > 0
+}
+
+// String returns human readable diff of a plan, suitable for a review
+func (p DevicePlan) String() string {
+	if !p.HasChanges() {
+		return fmt.Sprintf("device %q: no changes", p.UUID)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "device %q:", p.UUID)
+	for _, change := range p.Updates {
+		fmt.Fprintf(&sb, "\n  ~ %s", change)
+	}
+	for _, change := range p.Replacements {
+		fmt.Fprintf(&sb, "\n  -/+ %s (forces replacement)", change)
+	}
+	return sb.String()
+}
+
+// DeviceReplacementError describes situation when device plan can't be
+// applied because it contains changes that force device replacement
+type DeviceReplacementError struct {
+	//UUID is an identifier of a planned device
+	UUID string
+	//Replacements are changes that force device replacement
+	Replacements []DeviceFieldChange
+}
+
+func (e DeviceReplacementError) Error() string {
+	fields := make([]string, len(e.Replacements))
+	for i := range e.Replacements {
+		fields[i] = e.Replacements[i].Field
+	}
+	return fmt.Sprintf("device %q can't be updated in place, changed fields force replacement: %s", e.UUID, strings.Join(fields, ", "))
+}
+
+// DeviceReconciler compares desired Network Edge device configuration with
+// a live device and applies in place changes. Fields that are not set on
+// a desired device are not managed and never planned for a change
+//
+//	reconciler := client.NewDeviceReconciler()
+//	plan, err := reconciler.Plan(ctx, uuid, desired)
+//	if err != nil {
+//	    return err
+//	}
+//	log.Print(plan)
+//	err = reconciler.Apply(ctx, plan)
+type DeviceReconciler struct {
+	//WaitOptions configure waiting for applied changes to get provisioned
+	WaitOptions *WaitOptions
+	c           RestClient
+}
+
+// NewDeviceReconciler creates new device reconciler
+func (c RestClient) NewDeviceReconciler() *DeviceReconciler {
+	return &DeviceReconciler{c: c}
+}
+
+// Plan fetches device with a given UUID and returns plan of changes that
+// bring it to a desired configuration
+func (r *DeviceReconciler) Plan(ctx context.Context, uuid string, desired Device) (*DevicePlan, error) {
+	current, err := r.c.GetDeviceWithContext(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	plan := diffDevice(*current, desired)
+	plan.UUID = uuid
+	return &plan, nil
+}
+
+// Apply carries out in place updates of a given plan with a composite device
+// update request and waits until device is provisioned with desired core count,
+// ACL templates and additional bandwidth. DeviceReplacementError is returned, and nothing is
+// changed, when plan contains changes that force device replacement. Error is
+// also returned, before any change is made, when plan update has unknown field
+// or desired value of a wrong type
+func (r *DeviceReconciler) Apply(ctx context.Context, plan *DevicePlan) error {
+	if plan.RequiresReplacement() {
+		return DeviceReplacementError{UUID: plan.UUID, Replacements: plan.Replacements}
+	}
+	if !plan.HasChanges() {
+		return nil
+	}
+	req := r.c.NewDeviceUpdateRequestWithContext(plan.UUID)
+	var core, bandwidth *int
+	var aclTemplate, mgmtACLTemplate *string
+	for _, change := range plan.Updates {
+		var err error
+		switch change.Field {
+		case DeviceFieldName:
+			var name string
+			if name, err = change.desiredString(); err == nil {
+				req.WithDeviceName(name)
+			}
+		case DeviceFieldTermLength:
+			var termLength int
+			if termLength, err = change.desiredInt(); err == nil {
+				req.WithTermLength(termLength)
+			}
+		case DeviceFieldNotifications:
+			var notifications []string
+			if notifications, err = change.desiredStrings(); err == nil {
+				req.WithNotifications(notifications)
+			}
+		case DeviceFieldCore:
+			var desired int
+			if desired, err = change.desiredInt(); err == nil {
+				req.WithCore(desired)
+				core = &desired
+			}
+		case DeviceFieldClusterName:
+			var clusterName string
+			if clusterName, err = change.desiredString(); err == nil {
+				req.WithClusterName(clusterName)
+			}
+		case DeviceFieldAdditionalBandwidth:
+			var desired int
+			if desired, err = change.desiredInt(); err == nil {
+				req.WithAdditionalBandwidth(desired)
+				bandwidth = &desired
+			}
+		case DeviceFieldACLTemplate:
+			var templateID string
+			if templateID, err = change.desiredString(); err == nil {
+				req.WithACLTemplate(templateID)
+				aclTemplate = &templateID
+			}
+		case DeviceFieldMgmtACLTemplate:
+			var templateID string
+			if templateID, err = change.desiredString(); err == nil {
+				req.WithMgmtAclTemplate(templateID)
+				mgmtACLTemplate = &templateID
+			}
+		default:
+			err = fmt.Errorf("device field %q can't be updated in place", change.Field)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := req.ExecuteWithContext(ctx); err != nil {
+		return err
+	}
+	if core != nil {
+		if err := r.waitForCore(ctx, plan.UUID, *core); err != nil {
+			return err
+		}
+	}
+	if aclTemplate != nil || mgmtACLTemplate != nil {
+		if err := r.waitForACLTemplates(ctx, plan.UUID, aclTemplate, mgmtACLTemplate); err != nil {
+			return err
+		}
+	}
+	if bandwidth != nil {
+		if err := r.waitForAdditionalBandwidth(ctx, plan.UUID, *bandwidth); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Unexported package methods
+//_______________________________________________________________________
+
+//waitForCore polls device until it is provisioned with a given core count
+func (r *DeviceReconciler) waitForCore(ctx context.Context, uuid string, core int) error {
+	targetStates := []string{DeviceStateProvisioned}
+	return waitFor(ctx, waitResourceTypeDevice, uuid, r.WaitOptions, func(ctx context.Context) (string, interface{}, bool, error) {
+		fetched, err := r.c.GetDeviceWithContext(ctx, uuid)
+		if err != nil {
+			return "", nil, false, err
+		}
+		done, err := checkDeviceState(uuid, *fetched, targetStates)
+		done = done && IntValue(fetched.CoreCount) == core
+		return StringValue(fetched.Status), fetched, done, err
+	})
+}
+
+//waitForACLTemplates polls device ACL until it is provisioned and device
+//has given ACL and MGMT ACL templates applied. Templates that are nil
+//are not verified
+func (r *DeviceReconciler) waitForACLTemplates(ctx context.Context, uuid string, aclTemplate *string, mgmtACLTemplate *string) error {
+	return waitFor(ctx, waitResourceTypeDeviceACL, uuid, r.WaitOptions, func(ctx context.Context) (string, interface{}, bool, error) {
+		details, err := r.c.GetDeviceACLDetailsWithContext(ctx, uuid)
+		if err != nil {
+			return "", nil, false, err
+		}
+		status := StringValue(details.Status)
+		if status != ACLDeviceStatusProvisioned {
+			return status, details, false, nil
+		}
+		fetched, err := r.c.GetDeviceWithContext(ctx, uuid)
+		if err != nil {
+			return "", nil, false, err
+		}
+		done := (aclTemplate == nil || StringValue(fetched.ACLTemplateUUID) == *aclTemplate) &&
+			(mgmtACLTemplate == nil || StringValue(fetched.MgmtAclTemplateUuid) == *mgmtACLTemplate)
+		return status, details, done, nil
+	})
+}
+
+//waitForAdditionalBandwidth polls device additional bandwidth until it is
+//provisioned with a given value
+func (r *DeviceReconciler) waitForAdditionalBandwidth(ctx context.Context, uuid string, bandwidth int) error {
+	return waitFor(ctx, waitResourceTypeAdditionalBandwidth, uuid, r.WaitOptions, func(ctx context.Context) (string, interface{}, bool, error) {
+		details, err := r.c.GetDeviceAdditionalBandwidthDetailsWithContext(ctx, uuid)
+		if err != nil {
+			return "", nil, false, err
+		}
+		status := StringValue(details.Status)
+		done := status == DeviceAdditionalBandwidthStatusProvisioned && IntValue(details.AdditionalBandwidth) == bandwidth
+		return status, details, done, nil
+	})
+}
+
+//diffDevice compares fields of current and desired device. Fields that
+//are not set on a desired device are skipped
+func diffDevice(current Device, desired Device) DevicePlan {
+	plan := DevicePlan{}
+	update := func(field string, cur interface{}, des interface{}) {
+		plan.Updates = append(plan.Updates, DeviceFieldChange{Field: field, Current: cur, Desired: des})
+	}
+	replace := func(field string, cur interface{}, des interface{}) {
+		plan.Replacements = append(plan.Replacements, DeviceFieldChange{Field: field, Current: cur, Desired: des})
+	}
+	if desired.Name != nil && StringValue(desired.Name) != StringValue(current.Name) {
+		update(DeviceFieldName, StringValue(current.Name), StringValue(desired.Name))
+	}
+	if desired.TermLength != nil && IntValue(desired.TermLength) != IntValue(current.TermLength) {
+		update(DeviceFieldTermLength, IntValue(current.TermLength), IntValue(desired.TermLength))
+	}
+	if desired.Notifications != nil && !sameStringSet(desired.Notifications, current.Notifications) {
+		update(DeviceFieldNotifications, current.Notifications, desired.Notifications)
+	}
+	if desired.CoreCount != nil && IntValue(desired.CoreCount) != IntValue(current.CoreCount) {
+		update(DeviceFieldCore, IntValue(current.CoreCount), IntValue(desired.CoreCount))
+	}
+	if desired.ClusterDetails != nil && desired.ClusterDetails.ClusterName != nil {
+		currentName := ""
+		if current.ClusterDetails != nil {
+			currentName = StringValue(current.ClusterDetails.ClusterName)
+		}
+		if StringValue(desired.ClusterDetails.ClusterName) != currentName {
+			update(DeviceFieldClusterName, currentName, StringValue(desired.ClusterDetails.ClusterName))
+		}
+	}
+	if desired.AdditionalBandwidth != nil && IntValue(desired.AdditionalBandwidth) != IntValue(current.AdditionalBandwidth) {
+		update(DeviceFieldAdditionalBandwidth, IntValue(current.AdditionalBandwidth), IntValue(desired.AdditionalBandwidth))
+	}
+	if desired.ACLTemplateUUID != nil && StringValue(desired.ACLTemplateUUID) != StringValue(current.ACLTemplateUUID) {
+		update(DeviceFieldACLTemplate, StringValue(current.ACLTemplateUUID), StringValue(desired.ACLTemplateUUID))
+	}
+	if desired.MgmtAclTemplateUuid != nil && StringValue(desired.MgmtAclTemplateUuid) != StringValue(current.MgmtAclTemplateUuid) {
+		update(DeviceFieldMgmtACLTemplate, StringValue(current.MgmtAclTemplateUuid), StringValue(desired.MgmtAclTemplateUuid))
+	}
+	if desired.MetroCode != nil && StringValue(desired.MetroCode) != StringValue(current.MetroCode) {
+		replace(DeviceFieldMetroCode, StringValue(current.MetroCode), StringValue(desired.MetroCode))
+	}
+	if desired.TypeCode != nil && StringValue(desired.TypeCode) != StringValue(current.TypeCode) {
+		replace(DeviceFieldTypeCode, StringValue(current.TypeCode), StringValue(desired.TypeCode))
+	}
+	if desired.IsBYOL != nil && (current.IsBYOL == nil || BoolValue(desired.IsBYOL) != BoolValue(current.IsBYOL)) {
+		replace(DeviceFieldLicenseMode, licenseModeString(current.IsBYOL), licenseModeString(desired.IsBYOL))
+	}
+	if desired.PackageCode != nil && StringValue(desired.PackageCode) != StringValue(current.PackageCode) {
+		replace(DeviceFieldPackageCode, StringValue(current.PackageCode), StringValue(desired.PackageCode))
+	}
+	return plan
+}
+
+//desiredString returns desired value of a change that is expected to be a string
+func (c DeviceFieldChange) desiredString() (string, error) {
+	if v, ok := c.Desired.(string); ok {
+		return v, nil
+	}
+	return "", c.desiredTypeError("string")
+}
+
+//desiredInt returns desired value of a change that is expected to be an int
+func (c DeviceFieldChange) desiredInt() (int, error) {
+	if v, ok := c.Desired.(int); ok {
+		return v, nil
+	}
+	return 0, c.desiredTypeError("int")
+}
+
+//desiredStrings returns desired value of a change that is expected to be a slice of strings
+func (c DeviceFieldChange) desiredStrings() ([]string, error) {
+	if v, ok := c.Desired.([]string); ok {
+		return v, nil
+	}
+	return nil, c.desiredTypeError("[]string")
+}
+
+func (c DeviceFieldChange) desiredTypeError(expected string) error {
+	return fmt.Errorf("desired value of device field %q is %T and not %s", c.Field, c.Desired, expected)
+}
+
+func licenseModeString(isBYOL *bool) string {
+	if isBYOL == nil {
+		return ""
+	}
+	if *isBYOL {
+		return DeviceLicenseModeBYOL
+	}
+	return DeviceLicenseModeSubscription
+}
+
+//sameStringSet verifies if given slices contain the same values, regardless of order
+func sameStringSet(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := append([]string(nil), a...)
+	sortedB := append([]string(nil), b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	return reflect.DeepEqual(sortedA, sortedB)
+}
@@ -0,0 +1,170 @@
+package ne
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/equinix/ne-go/internal/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var testReconciledDevice = api.Device{
+	UUID:                String("myDevice"),
+	Name:                String("device"),
+	DeviceTypeCode:      String("CSR1000V"),
+	Status:              String(DeviceStateProvisioned),
+	MetroCode:           String("SV"),
+	PackageCode:         String("SEC"),
+	LicenseType:         String(DeviceLicenseModeSubscription),
+	TermLength:          Int(12),
+	Notifications:       []string{"a@example.com", "b@example.com"},
+	Core:                &api.DeviceCoreInformation{Core: Int(2)},
+	AdditionalBandwidth: Int(0),
+	ACLTemplateUUID:     String("acl"),
+}
+
+func TestDeviceReconciler_Plan(t *testing.T) {
+	//given
+	devID := StringValue(testReconciledDevice.UUID)
+	testHc := setupMockedClient("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID), 200, testReconciledDevice)
+	defer httpmock.DeactivateAndReset()
+	desired := Device{
+		Name:                String("renamed"),
+		TermLength:          Int(12),
+		Notifications:       []string{"b@example.com", "a@example.com"},
+		CoreCount:           Int(4),
+		AdditionalBandwidth: Int(100),
+		MetroCode:           String("DC"),
+		IsBYOL:              Bool(true),
+	}
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	plan, err := c.NewDeviceReconciler().Plan(context.Background(), devID, desired)
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, devID, plan.UUID, "Plan UUID matches")
+	assert.Equal(t, []DeviceFieldChange{
+		{Field: DeviceFieldName, Current: "device", Desired: "renamed"},
+		{Field: DeviceFieldCore, Current: 2, Desired: 4},
+		{Field: DeviceFieldAdditionalBandwidth, Current: 0, Desired: 100},
+	}, plan.Updates, "In place updates match")
+	assert.Equal(t, []DeviceFieldChange{
+		{Field: DeviceFieldMetroCode, Current: "SV", Desired: "DC"},
+		{Field: DeviceFieldLicenseMode, Current: DeviceLicenseModeSubscription, Desired: DeviceLicenseModeBYOL},
+	}, plan.Replacements, "Replacements match")
+	assert.True(t, plan.RequiresReplacement(), "Plan requires replacement")
+	assert.Contains(t, plan.String(), "name: device -> renamed", "Plan diff lists updates")
+	assert.Contains(t, plan.String(), "metroCode: SV -> DC (forces replacement)", "Plan diff lists replacements")
+}
+
+func TestDeviceReconciler_Apply(t *testing.T) {
+	//given
+	devID := StringValue(testReconciledDevice.UUID)
+	fieldsReq := api.DeviceUpdateRequest{}
+	aclReq := api.DeviceACLTemplateRequest{}
+	bandwidthReq := api.DeviceAdditionalBandwidthUpdateRequest{}
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	defer httpmock.DeactivateAndReset()
+	decoding := func(target interface{}) httpmock.Responder {
+		return func(r *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(r.Body).Decode(target); err != nil {
+				return httpmock.NewStringResponse(400, ""), nil
+			}
+			return httpmock.NewStringResponse(204, ""), nil
+		}
+	}
+	httpmock.RegisterResponder("PATCH", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID), decoding(&fieldsReq))
+	httpmock.RegisterResponder("PATCH", fmt.Sprintf("%s/ne/v1/devices/%s/acl", baseURL, devID), decoding(&aclReq))
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/ne/v1/devices/%s/additionalBandwidths", baseURL, devID), decoding(&bandwidthReq))
+	sequence := func(resps ...interface{}) httpmock.Responder {
+		calls := 0
+		return func(r *http.Request) (*http.Response, error) {
+			resp := resps[len(resps)-1]
+			if calls < len(resps) {
+				resp = resps[calls]
+			}
+			calls++
+			return httpmock.NewJsonResponse(200, resp)
+		}
+	}
+	staleDevice := testReconciledDevice
+	updatedDevice := testReconciledDevice
+	updatedDevice.Core = &api.DeviceCoreInformation{Core: Int(4)}
+	updatedDevice.MgmtAclTemplateUUID = String("mgmtAcl")
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID),
+		sequence(staleDevice, updatedDevice))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices/%s/acl", baseURL, devID),
+		httpmock.NewJsonResponderOrPanic(200, api.DeviceACLResponse{Status: String(ACLDeviceStatusProvisioned)}))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices/%s/additionalBandwidths", baseURL, devID),
+		sequence(
+			api.DeviceAdditionalBandwidthResponse{AdditionalBandwidth: Int(0), Status: String(DeviceAdditionalBandwidthStatusProvisioned)},
+			api.DeviceAdditionalBandwidthResponse{AdditionalBandwidth: Int(100), Status: String(DeviceAdditionalBandwidthStatusProvisioning)},
+			api.DeviceAdditionalBandwidthResponse{AdditionalBandwidth: Int(100), Status: String(DeviceAdditionalBandwidthStatusProvisioned)},
+		))
+	plan := &DevicePlan{UUID: devID, Updates: []DeviceFieldChange{
+		{Field: DeviceFieldName, Current: "device", Desired: "renamed"},
+		{Field: DeviceFieldNotifications, Current: []string{}, Desired: []string{"c@example.com"}},
+		{Field: DeviceFieldCore, Current: 2, Desired: 4},
+		{Field: DeviceFieldAdditionalBandwidth, Current: 0, Desired: 100},
+		{Field: DeviceFieldMgmtACLTemplate, Current: "", Desired: "mgmtAcl"},
+	}}
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	reconciler := c.NewDeviceReconciler()
+	reconciler.WaitOptions = &testWaitOptions
+	err := reconciler.Apply(context.Background(), plan)
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, "renamed", StringValue(fieldsReq.VirtualDeviceName), "Device name is updated")
+	assert.Equal(t, []string{"c@example.com"}, fieldsReq.Notifications, "Notifications are updated")
+	assert.Equal(t, "mgmtAcl", StringValue(aclReq.MgmtAclTemplateUUID), "MGMT ACL template is updated")
+	assert.Equal(t, 100, IntValue(bandwidthReq.AdditionalBandwidth), "Additional bandwidth is updated")
+	calls := httpmock.GetCallCountInfo()
+	assert.Equal(t, 3, calls[fmt.Sprintf("GET %s/ne/v1/devices/%s", baseURL, devID)], "Device is polled until core count and MGMT ACL template are applied")
+	assert.Equal(t, 3, calls[fmt.Sprintf("GET %s/ne/v1/devices/%s/additionalBandwidths", baseURL, devID)], "Additional bandwidth is polled until desired value is provisioned")
+}
+
+func TestDeviceReconciler_Apply_replacement(t *testing.T) {
+	//given
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	defer httpmock.DeactivateAndReset()
+	plan := &DevicePlan{UUID: "myDevice",
+		Updates:      []DeviceFieldChange{{Field: DeviceFieldName, Current: "device", Desired: "renamed"}},
+		Replacements: []DeviceFieldChange{{Field: DeviceFieldTypeCode, Current: "CSR1000V", Desired: "PA-VM"}},
+	}
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	err := c.NewDeviceReconciler().Apply(context.Background(), plan)
+	//then
+	replacementErr := DeviceReplacementError{}
+	assert.True(t, errors.As(err, &replacementErr), "DeviceReplacementError is returned")
+	assert.Equal(t, plan.Replacements, replacementErr.Replacements, "Replacements match")
+	assert.Equal(t, 0, httpmock.GetTotalCallCount(), "No requests were sent")
+}
+
+func TestDeviceReconciler_Apply_invalidUpdate(t *testing.T) {
+	//given
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	defer httpmock.DeactivateAndReset()
+	plans := []*DevicePlan{
+		{UUID: "myDevice", Updates: []DeviceFieldChange{{Field: DeviceFieldName, Current: "device", Desired: String("renamed")}}},
+		{UUID: "myDevice", Updates: []DeviceFieldChange{{Field: DeviceFieldTermLength, Current: 12, Desired: "24"}}},
+		{UUID: "myDevice", Updates: []DeviceFieldChange{{Field: "hostName", Current: "host", Desired: "other"}}},
+	}
+	c := NewClient(context.Background(), baseURL, testHc)
+	for _, plan := range plans {
+		//when
+		err := c.NewDeviceReconciler().Apply(context.Background(), plan)
+		//then
+		assert.NotNil(t, err, "Error is returned for %s", plan.Updates[0].Field)
+	}
+	assert.Equal(t, 0, httpmock.GetTotalCallCount(), "No requests were sent")
+}
@@ -0,0 +1,173 @@
+package ne
+
+import (
+	"context"
+	"fmt"
+)
+
+// DeviceUpdateReport describes outcome of a device update request executed
+// with rollback. Changes are identified by their targets, the same as in
+// UpdateError, i.e. deviceFields or additionalBandwidth
+type DeviceUpdateReport struct {
+	//Applied lists changes that were successfully applied, including ones
+	//that were rolled back later
+	Applied []string
+	//Skipped lists changes that were not attempted because earlier change failed
+	Skipped []string
+	//RolledBack lists applied changes that were reverted to their prior values
+	RolledBack []string
+	//NotReverted lists applied changes that could not be reverted, along with causes
+	NotReverted []ChangeError
+}
+
+//deviceUpdateStep is a single change of a composite device update request
+//along with compensating change that reverts it
+type deviceUpdateStep struct {
+	targets []string
+	values  []interface{}
+	apply   func(ctx context.Context) error
+	revert  func(ctx context.Context) error
+}
+
+// ExecuteWithRollback attempts to update device according new data set in composite
+// update request using given context. Current device is fetched before any change
+// is made. Changes are applied one after another and when one of them fails,
+// remaining changes are skipped and already applied ones are reverted to values
+// of fetched device, in reverse order. Core count change is never reverted, as
+// device resources are being upgraded, and device fields with core count are
+// reported as not reverted. Report of applied, rolled back and not reverted
+// changes is returned along with UpdateError, if any change failed
+func (req *restDeviceUpdateRequest) ExecuteWithRollback(ctx context.Context) (*DeviceUpdateReport, error) {
+	snapshot, err := req.c.GetDeviceWithContext(ctx, req.uuid)
+	if err != nil {
+		return nil, err
+	}
+	steps := req.steps(*snapshot)
+	report := &DeviceUpdateReport{}
+	updateErr := UpdateError{}
+	applied := 0
+	for ; applied < len(steps); applied++ {
+		step := steps[applied]
+		if err := step.apply(ctx); err != nil {
+			for i := range step.targets {
+				updateErr.AddChangeError(changeTypeUpdate, step.targets[i], step.values[i], err)
+			}
+			break
+		}
+		report.Applied = append(report.Applied, step.targets...)
+	}
+	if updateErr.ChangeErrorsCount() == 0 {
+		return report, nil
+	}
+	for i := applied + 1; i < len(steps); i++ {
+		report.Skipped = append(report.Skipped, steps[i].targets...)
+	}
+	for i := applied - 1; i >= 0; i-- {
+		step := steps[i]
+		if err := step.revert(ctx); err != nil {
+			for j := range step.targets {
+				report.NotReverted = append(report.NotReverted, ChangeError{
+					Type:   changeTypeUpdate,
+					Target: step.targets[j],
+					Value:  step.values[j],
+					Cause:  err,
+				})
+			}
+			continue
+		}
+		report.RolledBack = append(report.RolledBack, step.targets...)
+	}
+	return report, updateErr
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Unexported package methods
+//_______________________________________________________________________
+
+//steps returns changes of a request, in execution order, with compensating
+//changes that restore values of a given device snapshot
+func (req *restDeviceUpdateRequest) steps(snapshot Device) []deviceUpdateStep {
+	var steps []deviceUpdateStep
+	if len(req.deviceFields) > 0 {
+		prior := priorDeviceFields(snapshot, req.deviceFields)
+		steps = append(steps, deviceUpdateStep{
+			targets: []string{"deviceFields"},
+			values:  []interface{}{req.deviceFields},
+			apply: func(ctx context.Context) error {
+				return req.c.replaceDeviceFields(ctx, req.uuid, req.deviceFields)
+			},
+			revert: func(ctx context.Context) error {
+				if len(prior) > 0 {
+					if err := req.c.replaceDeviceFields(ctx, req.uuid, prior); err != nil {
+						return err
+					}
+				}
+				if _, ok := req.deviceFields["core"]; ok {
+					return fmt.Errorf("core count of device %q can't be reverted while its resources are upgraded", req.uuid)
+				}
+				return nil
+			},
+		})
+	}
+	if req.aclTemplateID != nil || req.mgmtAclTemplateUuid != nil {
+		step := deviceUpdateStep{
+			apply: func(ctx context.Context) error {
+				return req.c.replaceDeviceACLTemplate(ctx, req.uuid, req.aclTemplateID, req.mgmtAclTemplateUuid)
+			},
+		}
+		var priorACL, priorMgmtACL *string
+		if req.aclTemplateID != nil {
+			step.targets = append(step.targets, "aclTemplateUuid")
+			step.values = append(step.values, *req.aclTemplateID)
+			priorACL = snapshot.ACLTemplateUUID
+		}
+		if req.mgmtAclTemplateUuid != nil {
+			step.targets = append(step.targets, "mgmtAclTemplateUuid")
+			step.values = append(step.values, *req.mgmtAclTemplateUuid)
+			priorMgmtACL = snapshot.MgmtAclTemplateUuid
+		}
+		step.revert = func(ctx context.Context) error {
+			if (req.aclTemplateID != nil && priorACL == nil) || (req.mgmtAclTemplateUuid != nil && priorMgmtACL == nil) {
+				return fmt.Errorf("device %q had no ACL template assigned before update", req.uuid)
+			}
+			return req.c.replaceDeviceACLTemplate(ctx, req.uuid, priorACL, priorMgmtACL)
+		}
+		steps = append(steps, step)
+	}
+	if req.additionalBandwidth != nil {
+		prior := IntValue(snapshot.AdditionalBandwidth)
+		steps = append(steps, deviceUpdateStep{
+			targets: []string{"additionalBandwidth"},
+			values:  []interface{}{req.additionalBandwidth},
+			apply: func(ctx context.Context) error {
+				return req.c.replaceDeviceAdditionalBandwidth(ctx, req.uuid, *req.additionalBandwidth)
+			},
+			revert: func(ctx context.Context) error {
+				return req.c.replaceDeviceAdditionalBandwidth(ctx, req.uuid, prior)
+			},
+		})
+	}
+	return steps
+}
+
+//priorDeviceFields returns values of given device fields on a device snapshot.
+//Core count is skipped, as it can't be changed again until device resources
+//get upgraded
+func priorDeviceFields(snapshot Device, fields map[string]interface{}) map[string]interface{} {
+	prior := make(map[string]interface{}, len(fields))
+	for field := range fields {
+		switch field {
+		case "deviceName":
+			prior[field] = StringValue(snapshot.Name)
+		case "termLength":
+			prior[field] = IntValue(snapshot.TermLength)
+		case "notifications":
+			prior[field] = snapshot.Notifications
+		case "clusterName":
+			if snapshot.ClusterDetails != nil {
+				prior[field] = StringValue(snapshot.ClusterDetails.ClusterName)
+			}
+		}
+	}
+	return prior
+}
@@ -0,0 +1,117 @@
+package ne
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/equinix/ne-go/internal/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateDeviceWithRollback(t *testing.T) {
+	//given
+	devID := "myDevice"
+	var fieldsReqs []api.DeviceUpdateRequest
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID),
+		httpmock.NewJsonResponderOrPanic(200, api.Device{UUID: String(devID), Name: String("oldName"), TermLength: Int(12),
+			ACLTemplateUUID: String("oldACL"), AdditionalBandwidth: Int(50)}))
+	httpmock.RegisterResponder("PATCH", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID),
+		func(r *http.Request) (*http.Response, error) {
+			req := api.DeviceUpdateRequest{}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				return httpmock.NewStringResponse(400, ""), nil
+			}
+			fieldsReqs = append(fieldsReqs, req)
+			return httpmock.NewStringResponse(204, ""), nil
+		},
+	)
+	httpmock.RegisterResponder("PATCH", fmt.Sprintf("%s/ne/v1/devices/%s/acl", baseURL, devID),
+		httpmock.NewJsonResponderOrPanic(500, api.ErrorResponses{}))
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	req := c.NewDeviceUpdateRequestWithContext(devID)
+	req.WithDeviceName("newName").WithTermLength(24).
+		WithACLTemplate("newACL").WithAdditionalBandwidth(100)
+	report, err := req.ExecuteWithRollback(context.Background())
+	//then
+	updateErr := UpdateError{}
+	assert.True(t, errors.As(err, &updateErr), "UpdateError is returned")
+	assert.Equal(t, "aclTemplateUuid", updateErr.Failed[0].Target, "ACL template change failed")
+	assert.Equal(t, []string{"deviceFields"}, report.Applied, "Device fields were applied")
+	assert.Equal(t, []string{"additionalBandwidth"}, report.Skipped, "Additional bandwidth was skipped")
+	assert.Equal(t, []string{"deviceFields"}, report.RolledBack, "Device fields were rolled back")
+	assert.Empty(t, report.NotReverted, "All changes were reverted")
+	assert.Equal(t, 2, len(fieldsReqs), "Device fields were updated twice")
+	assert.Equal(t, "oldName", StringValue(fieldsReqs[1].VirtualDeviceName), "Device name was restored")
+	assert.Equal(t, 12, IntValue(fieldsReqs[1].TermLength), "Term length was restored")
+	assert.Equal(t, 0, httpmock.GetCallCountInfo()[fmt.Sprintf("PUT %s/ne/v1/devices/%s/additionalBandwidths", baseURL, devID)], "Additional bandwidth was not updated")
+}
+
+func TestUpdateDeviceWithRollback_notReverted(t *testing.T) {
+	//given
+	devID := "myDevice"
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID),
+		httpmock.NewJsonResponderOrPanic(200, api.Device{UUID: String(devID), AdditionalBandwidth: Int(50)}))
+	httpmock.RegisterResponder("PATCH", fmt.Sprintf("%s/ne/v1/devices/%s/acl", baseURL, devID),
+		httpmock.NewStringResponder(204, ""))
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/ne/v1/devices/%s/additionalBandwidths", baseURL, devID),
+		httpmock.NewJsonResponderOrPanic(500, api.ErrorResponses{}))
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	req := c.NewDeviceUpdateRequestWithContext(devID)
+	req.WithACLTemplate("newACL").WithAdditionalBandwidth(100)
+	report, err := req.ExecuteWithRollback(context.Background())
+	//then
+	assert.NotNil(t, err, "Error is returned")
+	assert.Equal(t, []string{"aclTemplateUuid"}, report.Applied, "ACL template was applied")
+	assert.Empty(t, report.RolledBack, "Nothing was rolled back")
+	assert.Equal(t, 1, len(report.NotReverted), "ACL template was not reverted")
+	assert.Equal(t, "aclTemplateUuid", report.NotReverted[0].Target, "Not reverted change target matches")
+}
+
+func TestUpdateDeviceWithRollback_core(t *testing.T) {
+	//given
+	devID := "myDevice"
+	var fieldsReqs []api.DeviceUpdateRequest
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID),
+		httpmock.NewJsonResponderOrPanic(200, api.Device{UUID: String(devID), Name: String("oldName"), Core: &api.DeviceCoreInformation{Core: Int(2)}}))
+	httpmock.RegisterResponder("PATCH", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID),
+		func(r *http.Request) (*http.Response, error) {
+			req := api.DeviceUpdateRequest{}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				return httpmock.NewStringResponse(400, ""), nil
+			}
+			fieldsReqs = append(fieldsReqs, req)
+			return httpmock.NewStringResponse(204, ""), nil
+		},
+	)
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/ne/v1/devices/%s/additionalBandwidths", baseURL, devID),
+		httpmock.NewJsonResponderOrPanic(500, api.ErrorResponses{}))
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	req := c.NewDeviceUpdateRequestWithContext(devID)
+	req.WithDeviceName("newName").WithCore(4).WithAdditionalBandwidth(100)
+	report, err := req.ExecuteWithRollback(context.Background())
+	//then
+	assert.NotNil(t, err, "Error is returned")
+	assert.Empty(t, report.RolledBack, "Nothing was rolled back")
+	assert.Equal(t, 1, len(report.NotReverted), "Device fields were not reverted")
+	assert.Equal(t, "deviceFields", report.NotReverted[0].Target, "Not reverted change target matches")
+	assert.Equal(t, 2, len(fieldsReqs), "Device fields were updated twice")
+	assert.Equal(t, "oldName", StringValue(fieldsReqs[1].VirtualDeviceName), "Device name was restored")
+	assert.Nil(t, fieldsReqs[1].Core, "Core count was not reverted")
+}
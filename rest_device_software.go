@@ -0,0 +1,192 @@
+package ne
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/equinix/ne-go/internal/api"
+)
+
+const (
+	//DeviceSoftwareUpgradeStatusRequested indicates that software upgrade was requested
+	DeviceSoftwareUpgradeStatusRequested = "REQUESTED"
+	//DeviceSoftwareUpgradeStatusInProgress indicates that software upgrade is in progress
+	DeviceSoftwareUpgradeStatusInProgress = "IN_PROGRESS"
+	//DeviceSoftwareUpgradeStatusCompleted indicates that software upgrade was completed
+	DeviceSoftwareUpgradeStatusCompleted = "COMPLETED"
+	//DeviceSoftwareUpgradeStatusFailed indicates that software upgrade has failed
+	DeviceSoftwareUpgradeStatusFailed = "FAILED"
+)
+
+const waitResourceTypeDeviceSoftwareUpgrade = "device software upgrade"
+
+// DeviceSoftwareUpgradeValidationError describes software upgrade that can't be
+// requested, because device already runs target version or target version is not
+// offered for device's type and package code.
+// DeviceSoftwareUpgradeValidationError matches ErrValidation with errors.Is
+type DeviceSoftwareUpgradeValidationError struct {
+	DeviceUUID string
+	Version    string
+	Message    string
+}
+
+func (e DeviceSoftwareUpgradeValidationError) Error() string {
+	return fmt.Sprintf("software upgrade of device %q to version %q is not valid: %s", e.DeviceUUID, e.Version, e.Message)
+}
+
+// Is verifies if validation error matches given sentinel error
+func (e DeviceSoftwareUpgradeValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// DeviceSoftwareUpgradeDetails describes details of a device software upgrade
+type DeviceSoftwareUpgradeDetails struct {
+	Status        *string
+	SourceVersion *string
+	TargetVersion *string
+	RequestedDate *string
+	CompletedDate *string
+	ErrorMessage  *string
+}
+
+// GetDeviceSoftwareUpgrades retrieves software versions that device with a given UUID
+// can be upgraded to, using given context. Versions offered for device's type and
+// package code, other than current device version, are returned
+func (c RestClient) GetDeviceSoftwareUpgrades(ctx context.Context, uuid string) ([]DeviceSoftwareVersion, error) {
+	device, err := c.GetDeviceWithContext(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := c.GetDeviceSoftwareVersionsWithContext(ctx, StringValue(device.TypeCode))
+	if err != nil {
+		return nil, err
+	}
+	var upgrades []DeviceSoftwareVersion
+	for i := range versions {
+		if StringValue(versions[i].Version) == StringValue(device.Version) {
+			continue
+		}
+		if device.PackageCode != nil && !containsString(versions[i].PackageCodes, *device.PackageCode) {
+			continue
+		}
+		upgrades = append(upgrades, versions[i])
+	}
+	return upgrades, nil
+}
+
+// UpgradeDeviceSoftware requests upgrade of a device with a given UUID to a given software
+// version using given context. Target version has to be offered for device's type and
+// package code, otherwise DeviceSoftwareUpgradeValidationError is returned and upgrade is not
+// requested. Use WaitForDeviceSoftwareUpgrade to wait for upgrade completion
+func (c RestClient) UpgradeDeviceSoftware(ctx context.Context, uuid string, version string) error {
+	device, err := c.GetDeviceWithContext(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	if StringValue(device.Version) == version {
+		return DeviceSoftwareUpgradeValidationError{
+			DeviceUUID: uuid,
+			Version:    version,
+			Message:    "device already runs this software version",
+		}
+	}
+	versions, err := c.GetDeviceSoftwareVersionsWithContext(ctx, StringValue(device.TypeCode))
+	if err != nil {
+		return err
+	}
+	target := *device
+	target.Version = String(version)
+	if violations := validateDeviceOrderVersion(target, versions, ""); len(violations) > 0 {
+		return DeviceSoftwareUpgradeValidationError{
+			DeviceUUID: uuid,
+			Version:    version,
+			Message:    violations[0].Message,
+		}
+	}
+	path := fmt.Sprintf("/ne/v1/devices/%s/upgrade", url.PathEscape(uuid))
+	reqBody := api.DeviceSoftwareUpgradeRequest{Version: String(version)}
+	req := c.R().SetBody(&reqBody)
+	if err := c.execute(ctx, req, http.MethodPost, path); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetDeviceSoftwareUpgradeDetails retrieves details of the latest software upgrade
+// of a device with a given UUID using given context
+func (c RestClient) GetDeviceSoftwareUpgradeDetails(ctx context.Context, uuid string) (*DeviceSoftwareUpgradeDetails, error) {
+	path := fmt.Sprintf("/ne/v1/devices/%s/upgrade", url.PathEscape(uuid))
+	result := api.DeviceSoftwareUpgradeResponse{}
+	request := c.R().SetResult(&result)
+	if err := c.execute(ctx, request, http.MethodGet, path); err != nil {
+		return nil, err
+	}
+	return mapDeviceSoftwareUpgradeAPIToDomain(result), nil
+}
+
+// WaitForDeviceSoftwareUpgrade polls software upgrade details of a device with a given
+// UUID until upgrade to a given version is completed. Details of earlier upgrades, to
+// other versions, are ignored, so that completed upgrade that preceded the request is
+// not mistaken for requested one. Waiting stops with ResourceStatusError when upgrade,
+// requested at or after given submission time, has failed. Failed upgrades to the same
+// version requested before that time are ignored; zero submission time treats every
+// failed upgrade as terminal. Last fetched details are returned along with an error.
+func (c RestClient) WaitForDeviceSoftwareUpgrade(ctx context.Context, uuid string, version string, submitted time.Time, opts *WaitOptions) (*DeviceSoftwareUpgradeDetails, error) {
+	var details *DeviceSoftwareUpgradeDetails
+	targetStatuses := []string{DeviceSoftwareUpgradeStatusCompleted}
+	err := waitFor(ctx, waitResourceTypeDeviceSoftwareUpgrade, uuid, opts, func(ctx context.Context) (string, interface{}, bool, error) {
+		fetched, err := c.GetDeviceSoftwareUpgradeDetails(ctx, uuid)
+		if err != nil {
+			return "", nil, false, err
+		}
+		details = fetched
+		status := StringValue(fetched.Status)
+		if StringValue(fetched.TargetVersion) != version {
+			return status, fetched, false, nil
+		}
+		if status == DeviceSoftwareUpgradeStatusFailed {
+			if !requestedSince(*fetched, submitted) {
+				return status, fetched, false, nil
+			}
+			return status, fetched, false, ResourceStatusError{
+				ResourceType:     waitResourceTypeDeviceSoftwareUpgrade,
+				ResourceID:       uuid,
+				Status:           status,
+				ExpectedStatuses: targetStatuses,
+			}
+		}
+		return status, fetched, containsString(targetStatuses, status), nil
+	})
+	return details, err
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Unexported package methods
+//_______________________________________________________________________
+
+//requestedSince verifies if software upgrade was requested at or after given time.
+//Requested date has a precision of seconds, so given time is truncated to seconds
+func requestedSince(details DeviceSoftwareUpgradeDetails, since time.Time) bool {
+	if since.IsZero() {
+		return true
+	}
+	requested, err := time.Parse(time.RFC3339, StringValue(details.RequestedDate))
+	if err != nil {
+		return false
+	}
+	return !requested.Before(since.Truncate(time.Second))
+}
+
+func mapDeviceSoftwareUpgradeAPIToDomain(apiDetails api.DeviceSoftwareUpgradeResponse) *DeviceSoftwareUpgradeDetails {
+	return &DeviceSoftwareUpgradeDetails{
+		Status:        apiDetails.Status,
+		SourceVersion: apiDetails.SourceVersion,
+		TargetVersion: apiDetails.TargetVersion,
+		RequestedDate: apiDetails.RequestedDate,
+		CompletedDate: apiDetails.CompletedDate,
+		ErrorMessage:  apiDetails.ErrorMessage,
+	}
+}
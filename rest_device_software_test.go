@@ -0,0 +1,90 @@
+package ne
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/equinix/ne-go/internal/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpgradeDeviceSoftware_sameVersion(t *testing.T) {
+	//given
+	devID := "myDevice"
+	testHc := setupMockedClient("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID), 200,
+		api.Device{UUID: String(devID), DeviceTypeCode: String("CSR1000V"), Version: String("16.09.05")})
+	defer httpmock.DeactivateAndReset()
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	err := c.UpgradeDeviceSoftware(context.Background(), devID, "16.09.05")
+	//then
+	validationErr := DeviceSoftwareUpgradeValidationError{}
+	assert.True(t, errors.As(err, &validationErr), "DeviceSoftwareUpgradeValidationError is returned")
+	assert.True(t, IsValidationError(err), "Validation error is returned")
+	assert.Equal(t, 1, httpmock.GetTotalCallCount(), "Upgrade is not requested")
+}
+
+func TestWaitForDeviceSoftwareUpgrade_failed(t *testing.T) {
+	//given
+	devID := "myDevice"
+	submitted := time.Date(2021, 3, 1, 10, 0, 0, 500, time.UTC)
+	testHc := setupMockedSequence("GET", fmt.Sprintf("%s/ne/v1/devices/%s/upgrade", baseURL, devID),
+		api.DeviceSoftwareUpgradeResponse{Status: String(DeviceSoftwareUpgradeStatusInProgress), TargetVersion: String("17.03.01"),
+			RequestedDate: String("2021-03-01T10:00:00Z")},
+		api.DeviceSoftwareUpgradeResponse{Status: String(DeviceSoftwareUpgradeStatusFailed), TargetVersion: String("17.03.01"),
+			RequestedDate: String("2021-03-01T10:00:00Z"), ErrorMessage: String("image corrupted")},
+	)
+	defer httpmock.DeactivateAndReset()
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	details, err := c.WaitForDeviceSoftwareUpgrade(context.Background(), devID, "17.03.01", submitted, &testWaitOptions)
+	//then
+	statusErr := ResourceStatusError{}
+	assert.True(t, errors.As(err, &statusErr), "ResourceStatusError is returned")
+	assert.Equal(t, DeviceSoftwareUpgradeStatusFailed, statusErr.Status, "Status matches")
+	assert.Equal(t, "image corrupted", StringValue(details.ErrorMessage), "Error message matches")
+}
+
+func TestWaitForDeviceSoftwareUpgrade_previousUpgrade(t *testing.T) {
+	//given
+	devID := "myDevice"
+	testHc := setupMockedSequence("GET", fmt.Sprintf("%s/ne/v1/devices/%s/upgrade", baseURL, devID),
+		api.DeviceSoftwareUpgradeResponse{Status: String(DeviceSoftwareUpgradeStatusCompleted), TargetVersion: String("16.09.05")},
+		api.DeviceSoftwareUpgradeResponse{Status: String(DeviceSoftwareUpgradeStatusRequested), TargetVersion: String("17.03.01")},
+		api.DeviceSoftwareUpgradeResponse{Status: String(DeviceSoftwareUpgradeStatusCompleted), TargetVersion: String("17.03.01")},
+	)
+	defer httpmock.DeactivateAndReset()
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	details, err := c.WaitForDeviceSoftwareUpgrade(context.Background(), devID, "17.03.01", time.Time{}, &testWaitOptions)
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, "17.03.01", StringValue(details.TargetVersion), "Target version matches")
+	assert.Equal(t, 3, httpmock.GetTotalCallCount(), "Completed upgrade to other version is ignored")
+}
+
+func TestWaitForDeviceSoftwareUpgrade_previousFailure(t *testing.T) {
+	//given
+	devID := "myDevice"
+	submitted := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	testHc := setupMockedSequence("GET", fmt.Sprintf("%s/ne/v1/devices/%s/upgrade", baseURL, devID),
+		api.DeviceSoftwareUpgradeResponse{Status: String(DeviceSoftwareUpgradeStatusFailed), TargetVersion: String("17.03.01"),
+			RequestedDate: String("2021-02-01T10:00:00Z")},
+		api.DeviceSoftwareUpgradeResponse{Status: String(DeviceSoftwareUpgradeStatusRequested), TargetVersion: String("17.03.01"),
+			RequestedDate: String("2021-03-01T10:00:01Z")},
+		api.DeviceSoftwareUpgradeResponse{Status: String(DeviceSoftwareUpgradeStatusCompleted), TargetVersion: String("17.03.01"),
+			RequestedDate: String("2021-03-01T10:00:01Z")},
+	)
+	defer httpmock.DeactivateAndReset()
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	details, err := c.WaitForDeviceSoftwareUpgrade(context.Background(), devID, "17.03.01", submitted, &testWaitOptions)
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, DeviceSoftwareUpgradeStatusCompleted, StringValue(details.Status), "Status matches")
+	assert.Equal(t, 3, httpmock.GetTotalCallCount(), "Failed upgrade requested before submission is ignored")
+}
@@ -0,0 +1,387 @@
+package ne
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	//TeardownActionUnassociate indicates removal of device association of a resource
+	TeardownActionUnassociate = "Unassociate"
+	//TeardownActionUpdate indicates removal of a device from a resource shared with other devices
+	TeardownActionUpdate = "Update"
+	//TeardownActionDelete indicates deletion of a resource
+	TeardownActionDelete = "Delete"
+	//TeardownActionWait indicates waiting for device deprovisioning
+	TeardownActionWait = "Wait"
+	//TeardownActionRetain indicates dependency that is not removed by teardown
+	TeardownActionRetain = "Retain"
+)
+
+const (
+	teardownResourceDevice          = "device"
+	teardownResourceSSHUser         = "sshUser"
+	teardownResourceDeviceLinkGroup = "deviceLinkGroup"
+	teardownResourceBGP             = "bgpConfiguration"
+	teardownResourceACLTemplate     = "aclTemplate"
+)
+
+// TeardownOptions describes configuration of device teardown
+type TeardownOptions struct {
+	//DeleteACLTemplates deletes ACL templates bound to torn down devices, unless
+	//they are bound to other devices as well. Templates can be deleted only
+	//after devices are deprovisioned, so teardown waits for it
+	DeleteACLTemplates bool
+	//WaitOptions configure waiting for devices deprovisioning
+	WaitOptions *WaitOptions
+	//DeleteConnection deletes connection with a given UUID, i.e. with Equinix
+	//Fabric client. BGP configurations are removed only along with their
+	//connections, so when it is not set they are reported as leftovers
+	DeleteConnection func(ctx context.Context, connectionUUID string) error
+}
+
+// TeardownStep describes single action of a device teardown
+type TeardownStep struct {
+	Action       string
+	ResourceType string
+	ResourceID   string
+	Description  string
+	//afterDeletion indicates that step can be executed only when device was deleted
+	afterDeletion bool
+	run           func(ctx context.Context) error
+}
+
+// TeardownPlan describes dependencies of a device and actions that remove them,
+// in execution order. Retained lists dependencies that teardown does not remove
+type TeardownPlan struct {
+	DeviceUUID string
+	Steps      []TeardownStep
+	Retained   []TeardownStep
+}
+
+// TeardownLeftover describes teardown step that failed or was not attempted
+type TeardownLeftover struct {
+	Step  TeardownStep
+	Cause error
+}
+
+// TeardownReport describes outcome of a device teardown
+type TeardownReport struct {
+	Plan      *TeardownPlan
+	Removed   []TeardownStep
+	Leftovers []TeardownLeftover
+}
+
+// Complete verifies if all steps of a teardown plan were executed
+func (r TeardownReport) Complete() bool {
+	return len(r.Leftovers) == 0
+}
+
+func (p TeardownPlan) String() string {
+	lines := []string{fmt.Sprintf("device %q teardown:", p.DeviceUUID)}
+	for _, step := range p.Steps {
+		lines = append(lines, fmt.Sprintf("  %s %s %q: %s", step.Action, step.ResourceType, step.ResourceID, step.Description))
+	}
+	for _, step := range p.Retained {
+		lines = append(lines, fmt.Sprintf("  %s %s %q: %s", step.Action, step.ResourceType, step.ResourceID, step.Description))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// PlanDeviceTeardown discovers dependencies of a device with a given UUID and
+// returns plan of their removal. Redundant device and cluster nodes are torn
+// down along with a device. BGP configurations are removed first, by deleting
+// their connections with DeleteConnection option, or retained when it is not set.
+// SSH users are unassociated from devices and devices are removed from device
+// link groups before device deletion. SSH users that are not associated with
+// other devices, and device link groups left with less than two devices, are
+// deleted after device deletion. ACL templates are deleted after devices are
+// deprovisioned, when enabled in options
+func (c RestClient) PlanDeviceTeardown(ctx context.Context, uuid string, opts *TeardownOptions) (*TeardownPlan, error) {
+	o := TeardownOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	device, err := c.GetDeviceWithContext(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	devices := []string{uuid}
+	if StringValue(device.RedundantUUID) != "" {
+		devices = append(devices, StringValue(device.RedundantUUID))
+	}
+	if device.ClusterDetails != nil {
+		for _, node := range device.ClusterDetails.Nodes {
+			if StringValue(node.UUID) != "" {
+				devices = append(devices, StringValue(node.UUID))
+			}
+		}
+	}
+	plan := &TeardownPlan{DeviceUUID: uuid}
+	if err := c.planBGPConfigurationsTeardown(ctx, plan, devices, o); err != nil {
+		return nil, err
+	}
+	userDeletions, err := c.planSSHUsersTeardown(ctx, plan, devices)
+	if err != nil {
+		return nil, err
+	}
+	groupDeletions, err := c.planDeviceLinkGroupsTeardown(ctx, plan, devices)
+	if err != nil {
+		return nil, err
+	}
+	description := "delete device"
+	if len(devices) > 1 {
+		description = fmt.Sprintf("delete device along with devices %s", strings.Join(devices[1:], ", "))
+	}
+	plan.Steps = append(plan.Steps, TeardownStep{
+		Action:       TeardownActionDelete,
+		ResourceType: teardownResourceDevice,
+		ResourceID:   uuid,
+		Description:  description,
+		run: func(ctx context.Context) error {
+			return c.EnsureDeviceDeleted(ctx, uuid)
+		},
+	})
+	plan.Steps = append(plan.Steps, userDeletions...)
+	plan.Steps = append(plan.Steps, groupDeletions...)
+	if err := c.planACLTemplatesTeardown(ctx, plan, devices, o); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// TeardownDevice tears down device with a given UUID along with its dependencies,
+// according to a plan returned by PlanDeviceTeardown. Failed steps do not stop
+// teardown, they are reported as leftovers along with steps that depend on device
+// deletion, when it failed. Retained BGP configurations are reported as leftovers
+// as well. Error is returned only when plan can't be prepared
+func (c RestClient) TeardownDevice(ctx context.Context, uuid string, opts *TeardownOptions) (*TeardownReport, error) {
+	plan, err := c.PlanDeviceTeardown(ctx, uuid, opts)
+	if err != nil {
+		return nil, err
+	}
+	report := &TeardownReport{Plan: plan}
+	for _, step := range plan.Retained {
+		if step.ResourceType == teardownResourceBGP {
+			report.Leftovers = append(report.Leftovers, TeardownLeftover{
+				Step:  step,
+				Cause: fmt.Errorf("BGP configuration %q can't be removed without its connection", step.ResourceID),
+			})
+		}
+	}
+	var deletionErr error
+	for _, step := range plan.Steps {
+		if step.afterDeletion && deletionErr != nil {
+			report.Leftovers = append(report.Leftovers, TeardownLeftover{
+				Step:  step,
+				Cause: fmt.Errorf("device %q was not deleted: %w", uuid, deletionErr),
+			})
+			continue
+		}
+		if err := step.run(ctx); err != nil {
+			if step.ResourceType == teardownResourceDevice {
+				deletionErr = err
+			}
+			report.Leftovers = append(report.Leftovers, TeardownLeftover{Step: step, Cause: err})
+			continue
+		}
+		report.Removed = append(report.Removed, step)
+	}
+	return report, nil
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Unexported package methods
+//_______________________________________________________________________
+
+//planBGPConfigurationsTeardown adds steps that delete connections of BGP configurations
+//of given devices, or retains BGP configurations when connection deletion is not set
+func (c RestClient) planBGPConfigurationsTeardown(ctx context.Context, plan *TeardownPlan, devices []string, opts TeardownOptions) error {
+	for _, deviceUUID := range devices {
+		configs, err := c.GetDeviceBGPConfigurations(ctx, deviceUUID)
+		if err != nil {
+			return err
+		}
+		for _, config := range configs {
+			connectionUUID := StringValue(config.ConnectionUUID)
+			step := TeardownStep{
+				ResourceType: teardownResourceBGP,
+				ResourceID:   StringValue(config.UUID),
+			}
+			if opts.DeleteConnection == nil {
+				step.Action = TeardownActionRetain
+				step.Description = fmt.Sprintf("removed along with connection %q", connectionUUID)
+				plan.Retained = append(plan.Retained, step)
+				continue
+			}
+			step.Action = TeardownActionDelete
+			step.Description = fmt.Sprintf("delete connection %q along with BGP configuration", connectionUUID)
+			step.run = func(ctx context.Context) error {
+				return opts.DeleteConnection(ctx, connectionUUID)
+			}
+			plan.Steps = append(plan.Steps, step)
+		}
+	}
+	return nil
+}
+
+//planSSHUsersTeardown adds steps that unassociate SSH users from given devices and
+//returns steps that delete SSH users not associated with other devices
+func (c RestClient) planSSHUsersTeardown(ctx context.Context, plan *TeardownPlan, devices []string) ([]TeardownStep, error) {
+	users, err := c.GetSSHUsersWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var deletions []TeardownStep
+	for _, user := range users {
+		userID := StringValue(user.UUID)
+		var associated []string
+		for _, deviceUUID := range user.DeviceUUIDs {
+			if containsString(devices, deviceUUID) {
+				associated = append(associated, deviceUUID)
+			}
+		}
+		if len(associated) == 0 {
+			continue
+		}
+		if len(associated) == len(user.DeviceUUIDs) {
+			deletions = append(deletions, TeardownStep{
+				Action:        TeardownActionDelete,
+				ResourceType:  teardownResourceSSHUser,
+				ResourceID:    userID,
+				Description:   fmt.Sprintf("delete SSH user %q", StringValue(user.Username)),
+				afterDeletion: true,
+				run: func(ctx context.Context) error {
+					return c.EnsureSSHUserDeleted(ctx, userID)
+				},
+			})
+			continue
+		}
+		for _, deviceUUID := range associated {
+			deviceUUID := deviceUUID
+			plan.Steps = append(plan.Steps, TeardownStep{
+				Action:       TeardownActionUnassociate,
+				ResourceType: teardownResourceSSHUser,
+				ResourceID:   userID,
+				Description:  fmt.Sprintf("unassociate SSH user %q from device %q", StringValue(user.Username), deviceUUID),
+				run: func(ctx context.Context) error {
+					return ignoreNotFound(c.changeDeviceAssociation(ctx, unassociateDevice, userID, deviceUUID))
+				},
+			})
+		}
+	}
+	return deletions, nil
+}
+
+//planDeviceLinkGroupsTeardown adds steps that remove given devices from device link
+//groups and returns steps that delete groups left with less than two devices
+func (c RestClient) planDeviceLinkGroupsTeardown(ctx context.Context, plan *TeardownPlan, devices []string) ([]TeardownStep, error) {
+	groups, err := c.GetDeviceLinkGroupsWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var deletions []TeardownStep
+	for _, group := range groups {
+		groupID := StringValue(group.UUID)
+		var remaining []DeviceLinkGroupDevice
+		for _, member := range group.Devices {
+			if !containsString(devices, StringValue(member.DeviceID)) {
+				remaining = append(remaining, member)
+			}
+		}
+		if len(remaining) == len(group.Devices) {
+			continue
+		}
+		if len(remaining) < 2 {
+			deletions = append(deletions, TeardownStep{
+				Action:        TeardownActionDelete,
+				ResourceType:  teardownResourceDeviceLinkGroup,
+				ResourceID:    groupID,
+				Description:   fmt.Sprintf("delete device link group %q left with %d devices", StringValue(group.Name), len(remaining)),
+				afterDeletion: true,
+				run: func(ctx context.Context) error {
+					return c.EnsureDeviceLinkGroupDeleted(ctx, groupID)
+				},
+			})
+			continue
+		}
+		links, metroLinks := group.Links, group.MetroLinks
+		plan.Steps = append(plan.Steps, TeardownStep{
+			Action:       TeardownActionUpdate,
+			ResourceType: teardownResourceDeviceLinkGroup,
+			ResourceID:   groupID,
+			Description:  fmt.Sprintf("remove devices from device link group %q", StringValue(group.Name)),
+			run: func(ctx context.Context) error {
+				req := c.NewDeviceLinkGroupUpdateRequestWithContext(groupID)
+				req.WithDevices(remaining).
+					WithLinks(links).
+					WithMetroLinks(metroLinks)
+				return req.ExecuteWithContext(ctx)
+			},
+		})
+	}
+	return deletions, nil
+}
+
+func (c RestClient) planACLTemplatesTeardown(ctx context.Context, plan *TeardownPlan, devices []string, opts TeardownOptions) error {
+	templates, err := c.GetACLTemplatesWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	var deletions []TeardownStep
+	for _, template := range templates {
+		templateID := StringValue(template.UUID)
+		bound, shared := false, false
+		for _, details := range template.DeviceDetails {
+			if containsString(devices, StringValue(details.UUID)) {
+				bound = true
+			} else {
+				shared = true
+			}
+		}
+		if !bound {
+			continue
+		}
+		step := TeardownStep{
+			ResourceType: teardownResourceACLTemplate,
+			ResourceID:   templateID,
+		}
+		switch {
+		case shared:
+			step.Action = TeardownActionRetain
+			step.Description = fmt.Sprintf("ACL template %q is bound to other devices", StringValue(template.Name))
+			plan.Retained = append(plan.Retained, step)
+		case !opts.DeleteACLTemplates:
+			step.Action = TeardownActionRetain
+			step.Description = fmt.Sprintf("ACL template %q deletion was not requested", StringValue(template.Name))
+			plan.Retained = append(plan.Retained, step)
+		default:
+			step.Action = TeardownActionDelete
+			step.Description = fmt.Sprintf("delete ACL template %q", StringValue(template.Name))
+			step.afterDeletion = true
+			step.run = func(ctx context.Context) error {
+				return c.EnsureACLTemplateDeleted(ctx, templateID)
+			}
+			deletions = append(deletions, step)
+		}
+	}
+	if len(deletions) == 0 {
+		return nil
+	}
+	for _, deviceUUID := range devices {
+		deviceUUID := deviceUUID
+		plan.Steps = append(plan.Steps, TeardownStep{
+			Action:        TeardownActionWait,
+			ResourceType:  teardownResourceDevice,
+			ResourceID:    deviceUUID,
+			Description:   "wait for device deprovisioning",
+			afterDeletion: true,
+			run: func(ctx context.Context) error {
+				return c.WaitForDeviceDeleted(ctx, deviceUUID, opts.WaitOptions)
+			},
+		})
+	}
+	plan.Steps = append(plan.Steps, deletions...)
+	return nil
+}
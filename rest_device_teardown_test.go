@@ -0,0 +1,115 @@
+package ne
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/equinix/ne-go/internal/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeardownDevice_leftovers(t *testing.T) {
+	//given
+	devID := "myDevice"
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID),
+		httpmock.NewJsonResponderOrPanic(200, api.Device{UUID: String(devID)}))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/sshUsers", baseURL),
+		httpmock.NewJsonResponderOrPanic(200, api.SSHUsersResponse{}))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/links", baseURL),
+		httpmock.NewJsonResponderOrPanic(200, api.DeviceLinkGroupsGetResponse{}))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/bgp", baseURL),
+		httpmock.NewJsonResponderOrPanic(200, api.BGPConfigurationsResponse{Pagination: api.Pagination{Total: 1}, Data: []api.BGPConfiguration{
+			{UUID: String("bgp"), ConnectionUUID: String("connection"), VirtualDeviceUUID: String(devID)},
+		}}))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/aclTemplates", baseURL),
+		httpmock.NewJsonResponderOrPanic(200, api.ACLTemplatesResponse{Pagination: api.Pagination{Total: 1}, Data: []api.ACLTemplate{
+			{UUID: String("acl"), Name: String("acl"), DeviceDetails: []api.ACLTemplateDeviceDetails{{UUID: String(devID)}}},
+		}}))
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID),
+		httpmock.NewJsonResponderOrPanic(500, api.ErrorResponses{}))
+	c := NewClient(context.Background(), baseURL, testHc)
+	c.PageSize = 10
+	//when
+	report, err := c.TeardownDevice(context.Background(), devID, &TeardownOptions{DeleteACLTemplates: true, WaitOptions: &testWaitOptions})
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.False(t, report.Complete(), "Teardown is not complete")
+	assert.Empty(t, report.Removed, "Nothing was removed")
+	var leftovers []string
+	for _, leftover := range report.Leftovers {
+		leftovers = append(leftovers, leftover.Step.Action+" "+leftover.Step.ResourceID)
+		assert.NotNil(t, leftover.Cause, "Leftover cause is returned")
+	}
+	assert.Equal(t, []string{"Retain bgp", "Delete myDevice", "Wait myDevice", "Delete acl"}, leftovers, "Leftovers match")
+	assert.Equal(t, 1, len(report.Plan.Retained), "BGP configuration is retained")
+	assert.Equal(t, "bgp", report.Plan.Retained[0].ResourceID, "Retained BGP configuration matches")
+	assert.Equal(t, 0, httpmock.GetCallCountInfo()[fmt.Sprintf("DELETE %s/ne/v1/aclTemplates/acl", baseURL)], "ACL template is not deleted")
+}
+
+func TestTeardownDevice_partialFailure(t *testing.T) {
+	//given
+	devID := "myDevice"
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID),
+		httpmock.NewJsonResponderOrPanic(200, api.Device{UUID: String(devID)}))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/sshUsers", baseURL),
+		httpmock.NewJsonResponderOrPanic(200, api.SSHUsersResponse{Pagination: api.Pagination{Total: 2}, Data: []api.SSHUser{
+			{UUID: String("own"), Username: String("own"), DeviceUUIDs: []string{devID}},
+			{UUID: String("shared"), Username: String("shared"), DeviceUUIDs: []string{devID, "other"}},
+		}}))
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("%s/ne/v1/sshUsers/shared/devices/%s", baseURL, devID),
+		httpmock.NewJsonResponderOrPanic(500, api.ErrorResponses{}))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/links", baseURL),
+		httpmock.NewJsonResponderOrPanic(200, api.DeviceLinkGroupsGetResponse{Pagination: api.Pagination{Total: 1}, Data: []api.DeviceLinkGroup{
+			{UUID: String("group"), GroupName: String("group"), Devices: []api.DeviceLinkGroupDevice{{DeviceUUID: String(devID)}, {DeviceUUID: String("other")}}},
+		}}))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/bgp", baseURL),
+		httpmock.NewJsonResponderOrPanic(200, api.BGPConfigurationsResponse{Pagination: api.Pagination{Total: 1}, Data: []api.BGPConfiguration{
+			{UUID: String("bgp"), ConnectionUUID: String("connection"), VirtualDeviceUUID: String(devID)},
+		}}))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/aclTemplates", baseURL),
+		httpmock.NewJsonResponderOrPanic(200, api.ACLTemplatesResponse{}))
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID),
+		httpmock.NewJsonResponderOrPanic(500, api.ErrorResponses{}))
+	var deletedConnections []string
+	opts := &TeardownOptions{
+		WaitOptions: &testWaitOptions,
+		DeleteConnection: func(ctx context.Context, connectionUUID string) error {
+			deletedConnections = append(deletedConnections, connectionUUID)
+			return nil
+		},
+	}
+	c := NewClient(context.Background(), baseURL, testHc)
+	c.PageSize = 10
+	//when
+	report, err := c.TeardownDevice(context.Background(), devID, opts)
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	var steps []string
+	for _, step := range report.Plan.Steps {
+		steps = append(steps, step.Action+" "+step.ResourceID)
+	}
+	assert.Equal(t, []string{"Delete bgp", "Unassociate shared", "Delete myDevice", "Delete own", "Delete group"}, steps, "Steps are planned in order")
+	assert.Empty(t, report.Plan.Retained, "BGP configuration is not retained")
+	assert.Equal(t, []string{"connection"}, deletedConnections, "Connection of BGP configuration is deleted")
+	assert.Equal(t, 1, len(report.Removed), "One step was executed")
+	assert.Equal(t, "bgp", report.Removed[0].ResourceID, "BGP configuration was removed")
+	var leftovers []string
+	for _, leftover := range report.Leftovers {
+		leftovers = append(leftovers, leftover.Step.Action+" "+leftover.Step.ResourceID)
+	}
+	assert.Equal(t, []string{"Unassociate shared", "Delete myDevice", "Delete own", "Delete group"}, leftovers, "Leftovers match")
+	deviceErr := APIError{}
+	assert.True(t, errors.As(report.Leftovers[3].Cause, &deviceErr), "Device deletion error is a cause of link group leftover")
+	assert.Equal(t, 0, httpmock.GetCallCountInfo()[fmt.Sprintf("GET %s/ne/v1/sshUsers/own", baseURL)], "SSH user is not deleted")
+	assert.Equal(t, 0, httpmock.GetCallCountInfo()[fmt.Sprintf("DELETE %s/ne/v1/links/group", baseURL)], "Device link group is not deleted")
+}
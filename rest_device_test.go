@@ -3,11 +3,13 @@ package ne
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/equinix/ne-go/internal/api"
 	"github.com/jarcoal/httpmock"
@@ -284,6 +286,61 @@ func TestGetDevice(t *testing.T) {
 	verifyDevice(t, *dev, resp)
 }
 
+func TestGetDeviceWithContext(t *testing.T) {
+	//given
+	type ctxKey string
+	resp := api.Device{}
+	if err := readJSONData("./test-fixtures/ne_device_get_resp.json", &resp); err != nil {
+		assert.Fail(t, "Cannot read test response")
+	}
+	devID := "myDevice"
+	var ctxValue interface{}
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID),
+		func(r *http.Request) (*http.Response, error) {
+			ctxValue = r.Context().Value(ctxKey("trace"))
+			return httpmock.NewJsonResponse(200, resp)
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+	ctx := context.WithValue(context.Background(), ctxKey("trace"), "traceValue")
+
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	dev, err := c.GetDeviceWithContext(ctx, devID)
+
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.NotNil(t, dev, "Returned device is not nil")
+	assert.Equal(t, "traceValue", ctxValue, "Per-call context is used in HTTP request")
+}
+
+func TestGetDeviceWithContext_deadline(t *testing.T) {
+	//given
+	devID := "myDevice"
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID),
+		func(r *http.Request) (*http.Response, error) {
+			<-r.Context().Done()
+			return nil, r.Context().Err()
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	dev, err := c.GetDeviceWithContext(ctx, devID)
+
+	//then
+	assert.Nil(t, dev, "Device is not returned")
+	assert.NotNil(t, err, "Error is returned")
+	assert.Contains(t, err.Error(), context.DeadlineExceeded.Error(), "Error describes exceeded deadline")
+}
+
 func TestGetDevices(t *testing.T) {
 	//Given
 	var respBody api.DevicesResponse
@@ -394,6 +451,33 @@ func TestUpdateDeviceACLTemplate(t *testing.T) {
 	assert.Equal(t, &newACLTemplateID, req.TemplateUUID, "ACLTemplateUUID matches")
 }
 
+func TestUpdateDeviceWithContext(t *testing.T) {
+	//given
+	type ctxKey string
+	devID := "myDevice"
+	ctxValues := []interface{}{}
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	responder := func(r *http.Request) (*http.Response, error) {
+		ctxValues = append(ctxValues, r.Context().Value(ctxKey("trace")))
+		return httpmock.NewStringResponse(204, ""), nil
+	}
+	httpmock.RegisterResponder("PATCH", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID), responder)
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/ne/v1/devices/%s/additionalBandwidths", baseURL, devID), responder)
+	defer httpmock.DeactivateAndReset()
+	ctx := context.WithValue(context.Background(), ctxKey("trace"), "traceValue")
+
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	req := c.NewDeviceUpdateRequestWithContext(devID)
+	req.WithDeviceName("newName").WithAdditionalBandwidth(100)
+	err := req.ExecuteWithContext(ctx)
+
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, []interface{}{"traceValue", "traceValue"}, ctxValues, "Per-call context is used in all HTTP requests")
+}
+
 func TestUpdateDeviceAdditionalBandwidth(t *testing.T) {
 	//given
 	devID := "myDevice"
@@ -615,3 +699,22 @@ func verifyClusterNodeDetailRequest(t *testing.T, clusterNodeDetail *ClusterNode
 	assert.Equal(t, clusterNodeDetail.LicenseFileId, apiClusterNodeDetailReq.LicenseFileID, "LicenseFileId matches")
 	assert.Equal(t, clusterNodeDetail.LicenseToken, apiClusterNodeDetailReq.LicenseToken, "LicenseToken matches")
 }
+
+func TestAddSecondary_error(t *testing.T) {
+	//given
+	devID := "myDevice"
+	testHc := setupMockedClient("POST", fmt.Sprintf("%s/ne/v1/devices", baseURL), 400,
+		api.ErrorResponse{ErrorCode: "EQ-123", ErrorMessage: "invalid"})
+	defer httpmock.DeactivateAndReset()
+
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	secUUID, err := c.AddSecondary(devID, Device{Name: String("secondary")})
+
+	//then
+	assert.Nil(t, secUUID, "Secondary UUID is not returned")
+	updateErr := UpdateError{}
+	assert.True(t, errors.As(err, &updateErr), "UpdateError is returned")
+	assert.Equal(t, "secondary", updateErr.Failed[0].Target, "Change target matches")
+	assert.True(t, IsValidationError(err), "Error cause is preserved")
+}
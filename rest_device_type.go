@@ -1,6 +1,7 @@
 package ne
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 
@@ -10,22 +11,44 @@ import (
 
 //GetDeviceTypes retrieves list of devices types along with their details
 func (c RestClient) GetDeviceTypes() ([]DeviceType, error) {
+	return c.GetDeviceTypesWithContext(c.ctx)
+}
+
+//GetDeviceTypesWithContext retrieves list of devices types along with their details using given context
+func (c RestClient) GetDeviceTypesWithContext(ctx context.Context) ([]DeviceType, error) {
 	path := "/ne/v1/deviceTypes"
-	content, err := c.GetOffsetPaginated(path, &api.DeviceTypeResponse{},
-		rest.DefaultOffsetPagingConfig())
+	var apiTypes []api.DeviceType
+	err := c.cached(ctx, path, &apiTypes, func(ctx context.Context) (interface{}, error) {
+		content, err := c.getOffsetPaginated(ctx, path, &api.DeviceTypeResponse{},
+			rest.DefaultOffsetPagingConfig())
+		if err != nil {
+			return nil, err
+		}
+		fetched := make([]api.DeviceType, len(content))
+		for i := range content {
+			fetched[i] = content[i].(api.DeviceType)
+		}
+		return fetched, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	transformed := make([]DeviceType, len(content))
-	for i := range content {
-		transformed[i] = mapDeviceTypeAPIToDomain(content[i].(api.DeviceType))
+	transformed := make([]DeviceType, len(apiTypes))
+	for i := range apiTypes {
+		transformed[i] = mapDeviceTypeAPIToDomain(apiTypes[i])
 	}
 	return transformed, nil
 }
 
 //GetDeviceSoftwareVersions retrieves list of available software versions for a given device type
 func (c RestClient) GetDeviceSoftwareVersions(deviceTypeCode string) ([]DeviceSoftwareVersion, error) {
-	deviceType, err := c.getDeviceType(deviceTypeCode)
+	return c.GetDeviceSoftwareVersionsWithContext(c.ctx, deviceTypeCode)
+}
+
+//GetDeviceSoftwareVersionsWithContext retrieves list of available software versions for a given device type
+//using given context
+func (c RestClient) GetDeviceSoftwareVersionsWithContext(ctx context.Context, deviceTypeCode string) ([]DeviceSoftwareVersion, error) {
+	deviceType, err := c.getDeviceType(ctx, deviceTypeCode)
 	if err != nil {
 		return nil, err
 	}
@@ -34,7 +57,13 @@ func (c RestClient) GetDeviceSoftwareVersions(deviceTypeCode string) ([]DeviceSo
 
 //GetDevicePlatforms retrieves list of available platform configurations for a given device type
 func (c RestClient) GetDevicePlatforms(deviceTypeCode string) ([]DevicePlatform, error) {
-	deviceType, err := c.getDeviceType(deviceTypeCode)
+	return c.GetDevicePlatformsWithContext(c.ctx, deviceTypeCode)
+}
+
+//GetDevicePlatformsWithContext retrieves list of available platform configurations for a given device type
+//using given context
+func (c RestClient) GetDevicePlatformsWithContext(ctx context.Context, deviceTypeCode string) ([]DevicePlatform, error) {
+	deviceType, err := c.getDeviceType(ctx, deviceTypeCode)
 	if err != nil {
 		return nil, err
 	}
@@ -45,26 +74,32 @@ func (c RestClient) GetDevicePlatforms(deviceTypeCode string) ([]DevicePlatform,
 // Unexported package methods
 //_______________________________________________________________________
 
-func (c RestClient) getDeviceType(typeCode string) (*api.DeviceType, error) {
+func (c RestClient) getDeviceType(ctx context.Context, typeCode string) (*api.DeviceType, error) {
 	path := "/ne/v1/deviceTypes"
-	content, err := c.GetOffsetPaginated(path, &api.DeviceTypeResponse{},
-		rest.DefaultOffsetPagingConfig().
-			SetAdditionalParams(map[string]string{"deviceTypeCode": url.QueryEscape(typeCode)}))
+	devType := api.DeviceType{}
+	err := c.cached(ctx, path+"?deviceTypeCode="+url.QueryEscape(typeCode), &devType, func(ctx context.Context) (interface{}, error) {
+		content, err := c.getOffsetPaginated(ctx, path, &api.DeviceTypeResponse{},
+			rest.DefaultOffsetPagingConfig().
+				SetAdditionalParams(map[string]string{"deviceTypeCode": url.QueryEscape(typeCode)}))
+		if err != nil {
+			return nil, err
+		}
+		if len(content) < 1 {
+			return nil, fmt.Errorf("device type query returned no results for given type code: %s", typeCode)
+		}
+		if len(content) > 1 {
+			return nil, fmt.Errorf("device type query returned more than one result for a given type code: %s", typeCode)
+		}
+		return content[0].(api.DeviceType), nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	if len(content) < 1 {
-		return nil, fmt.Errorf("device type query returned no results for given type code: %s", typeCode)
-	}
-	if len(content) > 1 {
-		return nil, fmt.Errorf("device type query returned more than one result for a given type code: %s", typeCode)
-	}
-	devType := content[0].(api.DeviceType)
 	return &devType, nil
 }
 
 func mapDeviceTypeAPIToDomain(apiDevice api.DeviceType) DeviceType {
-	return DeviceType{
+	deviceType := DeviceType{
 		Name:        apiDevice.Name,
 		Code:        apiDevice.Code,
 		Description: apiDevice.Description,
@@ -72,6 +107,11 @@ func mapDeviceTypeAPIToDomain(apiDevice api.DeviceType) DeviceType {
 		Category:    apiDevice.Category,
 		MetroCodes:  mapDeviceTypeAvailableMetrosAPIToDomain(apiDevice.AvailableMetros),
 	}
+	if apiDevice.ClusteringDetails != nil {
+		deviceType.IsClusteringSupported = apiDevice.ClusteringDetails.ClusteringEnabled
+		deviceType.MaxClusterNodes = apiDevice.ClusteringDetails.MaxAllowedNodes
+	}
+	return deviceType
 }
 
 func mapDeviceTypeAvailableMetrosAPIToDomain(apiMetros []api.DeviceTypeAvailableMetro) []string {
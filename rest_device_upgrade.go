@@ -0,0 +1,104 @@
+package ne
+
+import (
+	"context"
+	"fmt"
+)
+
+// DeviceResources describes target resources of a Network Edge device upgrade
+type DeviceResources struct {
+	//CoreCount is a number of cores, it has to match one of device type platforms
+	CoreCount int
+}
+
+// UpgradeDeviceResources changes resources of a device with a given UUID using given
+// context. Target core count is verified against platforms of device's type and package
+// code before any change is made. Each device is polled until upgrade completes, using
+// given wait options. Devices of HA pair are upgraded one after another, secondary device
+// first, so that at least one of them stays operational. Devices that already have
+// target resources are skipped. Upgrade stops on first failure, leaving devices that
+// were upgraded before in place
+func (c RestClient) UpgradeDeviceResources(ctx context.Context, uuid string, resources DeviceResources, opts *WaitOptions) error {
+	device, err := c.GetDeviceWithContext(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	if err := c.validateDeviceResources(ctx, *device, resources); err != nil {
+		return err
+	}
+	devices := []*Device{device}
+	if StringValue(device.RedundantUUID) != "" {
+		redundant, err := c.GetDeviceWithContext(ctx, StringValue(device.RedundantUUID))
+		if err != nil {
+			return err
+		}
+		if StringValue(device.RedundancyType) == DeviceRedundancyTypeSecondary {
+			devices = append(devices, redundant)
+		} else {
+			devices = []*Device{redundant, device}
+		}
+	}
+	for _, node := range devices {
+		if hasDeviceResources(*node, resources) {
+			continue
+		}
+		if err := c.upgradeDeviceResources(ctx, *node, resources, opts); err != nil {
+			return fmt.Errorf("resource upgrade of device %q failed: %w", StringValue(node.UUID), err)
+		}
+	}
+	return nil
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Unexported package methods
+//_______________________________________________________________________
+
+//validateDeviceResources verifies if device type offers platform with target
+//core count for device's package code
+func (c RestClient) validateDeviceResources(ctx context.Context, device Device, resources DeviceResources) error {
+	platforms, err := c.GetDevicePlatformsWithContext(ctx, StringValue(device.TypeCode))
+	if err != nil {
+		return err
+	}
+	for i := range platforms {
+		if IntValue(platforms[i].CoreCount) != resources.CoreCount {
+			continue
+		}
+		if device.PackageCode == nil || containsString(platforms[i].PackageCodes, *device.PackageCode) {
+			return nil
+		}
+	}
+	return DeviceOrderValidationError{Violations: []DeviceOrderViolation{{
+		Field: "CoreCount",
+		Value: resources.CoreCount,
+		Message: fmt.Sprintf("core count %d is not offered for device type %q and package code %q",
+			resources.CoreCount, StringValue(device.TypeCode), StringValue(device.PackageCode)),
+	}}}
+}
+
+//upgradeDeviceResources submits resource change of a given device and polls it
+//until it gets provisioned with target resources
+func (c RestClient) upgradeDeviceResources(ctx context.Context, device Device, resources DeviceResources, opts *WaitOptions) error {
+	uuid := StringValue(device.UUID)
+	if StringValue(device.Status) != DeviceStateProvisioned {
+		return fmt.Errorf("device is in state %q, expected state: %s", StringValue(device.Status), DeviceStateProvisioned)
+	}
+	fields := map[string]interface{}{"core": resources.CoreCount}
+	if err := c.replaceDeviceFields(ctx, uuid, fields); err != nil {
+		return err
+	}
+	targetStates := []string{DeviceStateProvisioned}
+	return waitFor(ctx, waitResourceTypeDevice, uuid, opts, func(ctx context.Context) (string, interface{}, bool, error) {
+		fetched, err := c.GetDeviceWithContext(ctx, uuid)
+		if err != nil {
+			return "", nil, false, err
+		}
+		done, err := checkDeviceState(uuid, *fetched, targetStates)
+		done = done && hasDeviceResources(*fetched, resources)
+		return StringValue(fetched.Status), fetched, done, err
+	})
+}
+
+func hasDeviceResources(device Device, resources DeviceResources) bool {
+	return IntValue(device.CoreCount) == resources.CoreCount
+}
@@ -0,0 +1,50 @@
+package ne
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/equinix/ne-go/internal/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpgradeDeviceResources_failed(t *testing.T) {
+	//given
+	core := func(cores int) *api.DeviceCoreInformation {
+		return &api.DeviceCoreInformation{Core: Int(cores)}
+	}
+	primary := api.Device{UUID: String("primary"), DeviceTypeCode: String("CSR1000V"), PackageCode: String("SEC"), Status: String(DeviceStateProvisioned),
+		Core: core(2), RedundancyType: String(DeviceRedundancyTypePrimary), RedundantUUID: String("secondary")}
+	secondary := primary
+	secondary.UUID, secondary.RedundancyType, secondary.RedundantUUID = String("secondary"), String(DeviceRedundancyTypeSecondary), String("primary")
+	upgrading, failed := secondary, secondary
+	upgrading.Status = String(DeviceStateResourceUpgradeInProgress)
+	failed.Status = String(DeviceStateResourceUpgradeFailed)
+	testHc := setupMockedSequence("GET", fmt.Sprintf("%s/ne/v1/devices/secondary", baseURL), secondary, upgrading, failed)
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices/primary", baseURL),
+		httpmock.NewJsonResponderOrPanic(200, primary))
+	supported := true
+	deviceType := api.DeviceType{Code: String("CSR1000V")}
+	deviceType.DeviceManagementTypes.EquinixConfigured = api.DeviceManagementType{IsSupported: true, LicenseOptions: api.DeviceLicenseOptions{
+		Sub: api.DeviceLicenseOption{IsSupported: &supported, Cores: []api.DeviceCore{
+			{Core: Int(4), Flavor: String("medium"), IsSupported: &supported, PackageCodes: []api.DevicePackageCode{{PackageCode: String("SEC"), IsSupported: &supported}}},
+		}},
+	}}
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/deviceTypes", baseURL),
+		httpmock.NewJsonResponderOrPanic(200, api.DeviceTypeResponse{Pagination: api.Pagination{Total: 1}, Data: []api.DeviceType{deviceType}}))
+	httpmock.RegisterResponder("PATCH", fmt.Sprintf("%s/ne/v1/devices/secondary", baseURL),
+		httpmock.NewStringResponder(204, ""))
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	c.PageSize = 10
+	err := c.UpgradeDeviceResources(context.Background(), "primary", DeviceResources{CoreCount: 4}, &testWaitOptions)
+	//then
+	stateErr := DeviceStateError{}
+	assert.True(t, errors.As(err, &stateErr), "DeviceStateError is returned")
+	assert.Equal(t, DeviceStateResourceUpgradeFailed, stateErr.State, "Device state matches")
+	assert.Equal(t, 0, httpmock.GetCallCountInfo()[fmt.Sprintf("PATCH %s/ne/v1/devices/primary", baseURL)], "Primary device is not upgraded")
+}
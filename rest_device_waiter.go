@@ -0,0 +1,151 @@
+package ne
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const waitResourceTypeDevice = "device"
+
+// DeviceStateError describes situation when Network Edge device reached
+// a state from which it will not get to any of expected states, i.e.
+// device provisioning or license registration have failed
+type DeviceStateError struct {
+	//UUID is device identifier
+	UUID string
+	//State is observed device state
+	State string
+	//LicenseState is observed device license state
+	LicenseState string
+	//ExpectedStates are states that device was expected to reach
+	ExpectedStates []string
+}
+
+func (e DeviceStateError) Error() string {
+	return fmt.Sprintf("device %q reached state %q with license state %q, expected states: %s",
+		e.UUID, e.State, e.LicenseState, strings.Join(e.ExpectedStates, ", "))
+}
+
+// Is verifies if device state error matches given sentinel error.
+// ErrDeviceRemoved is matched when device is deprovisioning or deprovisioned
+func (e DeviceStateError) Is(target error) bool {
+	return target == ErrDeviceRemoved &&
+		(e.State == DeviceStateDeprovisioning || e.State == DeviceStateDeprovisioned)
+}
+
+// WaitForDeviceState polls Network Edge device with a given UUID until it reaches
+// one of given target states. Waiting stops immediately with DeviceStateError when
+// device provisioning, resource upgrade or license registration have failed or when
+// device is deprovisioned while other states were expected. Transitional states,
+// like waiting for primary device or cluster setup, are polled further.
+// Last fetched device is returned along with an error.
+func (c RestClient) WaitForDeviceState(ctx context.Context, uuid string, targetStates []string, opts *WaitOptions) (*Device, error) {
+	var device *Device
+	err := waitFor(ctx, waitResourceTypeDevice, uuid, opts, func(ctx context.Context) (string, interface{}, bool, error) {
+		fetched, err := c.GetDeviceWithContext(ctx, uuid)
+		if err != nil {
+			return "", nil, false, err
+		}
+		device = fetched
+		done, err := checkDeviceState(uuid, *fetched, targetStates)
+		return StringValue(fetched.Status), fetched, done, err
+	})
+	return device, err
+}
+
+// WaitForDeviceDeleted polls Network Edge device with a given UUID until it gets
+// deprovisioned. Device that no longer exists is treated as deleted
+func (c RestClient) WaitForDeviceDeleted(ctx context.Context, uuid string, opts *WaitOptions) error {
+	targetStates := []string{DeviceStateDeprovisioned}
+	return waitFor(ctx, waitResourceTypeDevice, uuid, opts, func(ctx context.Context) (string, interface{}, bool, error) {
+		fetched, err := c.GetDeviceWithContext(ctx, uuid)
+		if IsNotFound(err) {
+			return DeviceStateDeprovisioned, nil, true, nil
+		}
+		if err != nil {
+			return "", nil, false, err
+		}
+		done, err := checkDeviceState(uuid, *fetched, targetStates)
+		return StringValue(fetched.Status), fetched, done, err
+	})
+}
+
+// AddSecondaryAndWait converts single device with a given UUID into HA device pair.
+// Secondary device is created and both devices are polled until they leave states
+// of waiting for their redundant device and get provisioned. Timeout of given options
+// limits total time of waiting for both devices. UUID of a secondary device, linked
+// with primary device as its redundant device, is returned. Errors of waiting for
+// secondary device are returned along with its UUID
+func (c RestClient) AddSecondaryAndWait(ctx context.Context, primaryUUID string, secondary Device, opts *WaitOptions) (*string, error) {
+	secondaryUUID, err := c.AddSecondaryWithContext(ctx, primaryUUID, secondary)
+	if err != nil {
+		return nil, err
+	}
+	if secondaryUUID == nil {
+		return nil, fmt.Errorf("secondary device UUID was not returned for device %q", primaryUUID)
+	}
+	waitOpts := opts.withDefaults()
+	deadline := time.Now().Add(waitOpts.Timeout)
+	targetStates := []string{DeviceStateProvisioned}
+	if _, err := c.WaitForDeviceState(ctx, *secondaryUUID, targetStates, &waitOpts); err != nil {
+		return secondaryUUID, err
+	}
+	waitOpts.Timeout = time.Until(deadline)
+	if waitOpts.Timeout <= 0 {
+		waitOpts.Timeout = time.Nanosecond
+	}
+	err = waitFor(ctx, waitResourceTypeDevice, primaryUUID, &waitOpts, func(ctx context.Context) (string, interface{}, bool, error) {
+		fetched, err := c.GetDeviceWithContext(ctx, primaryUUID)
+		if err != nil {
+			return "", nil, false, err
+		}
+		done, err := checkDeviceState(primaryUUID, *fetched, targetStates)
+		redundantUUID := StringValue(fetched.RedundantUUID)
+		if err == nil && redundantUUID != "" && redundantUUID != *secondaryUUID {
+			err = fmt.Errorf("device %q is linked with redundant device %q instead of created secondary device %q",
+				primaryUUID, redundantUUID, *secondaryUUID)
+		}
+		done = done && redundantUUID == *secondaryUUID
+		return StringValue(fetched.Status), fetched, done, err
+	})
+	if err != nil {
+		return secondaryUUID, err
+	}
+	return secondaryUUID, nil
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Unexported package methods
+//_______________________________________________________________________
+
+//checkDeviceState verifies if device reached one of target states. Error is returned
+//when target states can't be reached anymore
+func checkDeviceState(uuid string, device Device, targetStates []string) (bool, error) {
+	state := StringValue(device.Status)
+	licenseState := StringValue(device.LicenseStatus)
+	stateErr := DeviceStateError{
+		UUID:           uuid,
+		State:          state,
+		LicenseState:   licenseState,
+		ExpectedStates: targetStates,
+	}
+	removalExpected := containsString(targetStates, DeviceStateDeprovisioning) ||
+		containsString(targetStates, DeviceStateDeprovisioned)
+	if licenseState == DeviceLicenseStateFailed && !removalExpected {
+		return false, stateErr
+	}
+	if containsString(targetStates, state) {
+		return true, nil
+	}
+	switch state {
+	case DeviceStateFailed, DeviceStateResourceUpgradeFailed:
+		return false, stateErr
+	case DeviceStateDeprovisioning, DeviceStateDeprovisioned:
+		if !removalExpected {
+			return false, stateErr
+		}
+	}
+	return false, nil
+}
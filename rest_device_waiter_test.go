@@ -0,0 +1,249 @@
+package ne
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/equinix/ne-go/internal/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var testWaitOptions = WaitOptions{
+	PollInterval:    time.Millisecond,
+	MaxPollInterval: 2 * time.Millisecond,
+	Timeout:         time.Second,
+}
+
+func TestWaitForDeviceState(t *testing.T) {
+	//given
+	devID := "myDevice"
+	testHc := setupMockedSequence("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID),
+		api.Device{UUID: String(devID), Status: String(DeviceStateInitializing), LicenseStatus: String(DeviceLicenseStateApplying)},
+		api.Device{UUID: String(devID), Status: String(DeviceStateWaitingPrimary), LicenseStatus: String(DeviceLicenseStateApplying)},
+		api.Device{UUID: String(devID), Status: String(DeviceStateClusterSetUpInProgress), LicenseStatus: String(DeviceLicenseStateWaitingClusterSetUp)},
+		api.Device{UUID: String(devID), Status: String(DeviceStateProvisioned), LicenseStatus: String(DeviceLicenseStateRegistered)},
+	)
+	defer httpmock.DeactivateAndReset()
+	var observed []string
+	opts := testWaitOptions
+	opts.OnProgress = func(progress WaitProgress) {
+		observed = append(observed, progress.Status)
+	}
+
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	device, err := c.WaitForDeviceState(context.Background(), devID, []string{DeviceStateProvisioned}, &opts)
+
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.NotNil(t, device, "Device is returned")
+	assert.Equal(t, DeviceStateProvisioned, StringValue(device.Status), "Device state matches")
+	assert.Equal(t, []string{DeviceStateInitializing, DeviceStateWaitingPrimary, DeviceStateClusterSetUpInProgress, DeviceStateProvisioned},
+		observed, "Progress callback observed all states")
+}
+
+func TestWaitForDeviceState_failures(t *testing.T) {
+	devID := "myDevice"
+	tests := []struct {
+		name   string
+		device api.Device
+	}{
+		{"failed", api.Device{Status: String(DeviceStateFailed)}},
+		{"resourceUpgradeFailed", api.Device{Status: String(DeviceStateResourceUpgradeFailed)}},
+		{"licenseFailed", api.Device{Status: String(DeviceStateProvisioning), LicenseStatus: String(DeviceLicenseStateFailed)}},
+		{"deprovisioned", api.Device{Status: String(DeviceStateDeprovisioned)}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			//given
+			testHc := setupMockedSequence("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID), tc.device)
+			defer httpmock.DeactivateAndReset()
+			//when
+			c := NewClient(context.Background(), baseURL, testHc)
+			_, err := c.WaitForDeviceState(context.Background(), devID, []string{DeviceStateProvisioned}, &testWaitOptions)
+			//then
+			stateErr := DeviceStateError{}
+			assert.True(t, errors.As(err, &stateErr), "DeviceStateError is returned")
+			assert.Equal(t, StringValue(tc.device.Status), stateErr.State, "Error state matches")
+			assert.Equal(t, 1, httpmock.GetTotalCallCount(), "Waiting stopped after first check")
+		})
+	}
+}
+
+func TestWaitForDeviceState_timeout(t *testing.T) {
+	//given
+	devID := "myDevice"
+	testHc := setupMockedSequence("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID),
+		api.Device{UUID: String(devID), Status: String(DeviceStateWaitingSecondary)},
+	)
+	defer httpmock.DeactivateAndReset()
+	opts := testWaitOptions
+	opts.Timeout = 20 * time.Millisecond
+
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	_, err := c.WaitForDeviceState(context.Background(), devID, []string{DeviceStateProvisioned}, &opts)
+
+	//then
+	timeoutErr := WaitTimeoutError{}
+	assert.True(t, errors.As(err, &timeoutErr), "WaitTimeoutError is returned")
+	assert.Equal(t, DeviceStateWaitingSecondary, timeoutErr.LastStatus, "Last observed status matches")
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "Error wraps context deadline")
+}
+
+func TestWaitForDeviceState_cancelled(t *testing.T) {
+	//given
+	devID := "myDevice"
+	testHc := setupMockedSequence("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID),
+		api.Device{UUID: String(devID), Status: String(DeviceStateProvisioning)},
+	)
+	defer httpmock.DeactivateAndReset()
+	ctx, cancel := context.WithCancel(context.Background())
+	opts := testWaitOptions
+	opts.OnProgress = func(progress WaitProgress) {
+		cancel()
+	}
+
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	_, err := c.WaitForDeviceState(ctx, devID, []string{DeviceStateProvisioned}, &opts)
+
+	//then
+	assert.Equal(t, context.Canceled, err, "Context cancellation error is returned")
+}
+
+func TestWaitForDeviceState_deprovisioning(t *testing.T) {
+	//given
+	devID := "myDevice"
+	testHc := setupMockedSequence("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID),
+		api.Device{UUID: String(devID), Status: String(DeviceStateDeprovisioning), LicenseStatus: String(DeviceLicenseStateFailed)},
+		api.Device{UUID: String(devID), Status: String(DeviceStateDeprovisioned), LicenseStatus: String(DeviceLicenseStateFailed)},
+	)
+	defer httpmock.DeactivateAndReset()
+
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	device, err := c.WaitForDeviceState(context.Background(), devID, []string{DeviceStateDeprovisioned}, &testWaitOptions)
+
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, DeviceStateDeprovisioned, StringValue(device.Status), "Device state matches")
+}
+
+func TestWaitForDeviceDeleted(t *testing.T) {
+	//given
+	devID := "myDevice"
+	testHc := setupMockedSequence("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID),
+		api.Device{UUID: String(devID), Status: String(DeviceStateDeprovisioning)},
+		httpmock.NewStringResponse(404, ""),
+	)
+	defer httpmock.DeactivateAndReset()
+
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	err := c.WaitForDeviceDeleted(context.Background(), devID, &testWaitOptions)
+
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, 2, httpmock.GetTotalCallCount(), "Device was polled until not found")
+}
+
+func TestAddSecondaryAndWait(t *testing.T) {
+	//given
+	priID := "primary"
+	secID := "secondary"
+	req := api.AddSecondaryRequest{}
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/ne/v1/devices", baseURL),
+		func(r *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				return httpmock.NewStringResponse(400, ""), nil
+			}
+			return httpmock.NewJsonResponse(202, api.DeviceRequestResponse{SecondaryUUID: String(secID)})
+		},
+	)
+	registerDeviceSequence := func(uuid string, devices ...api.Device) {
+		calls := 0
+		httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, uuid),
+			func(r *http.Request) (*http.Response, error) {
+				device := devices[len(devices)-1]
+				if calls < len(devices) {
+					device = devices[calls]
+				}
+				calls++
+				return httpmock.NewJsonResponse(200, device)
+			},
+		)
+	}
+	registerDeviceSequence(secID,
+		api.Device{UUID: String(secID), Status: String(DeviceStateInitializing)},
+		api.Device{UUID: String(secID), Status: String(DeviceStateWaitingPrimary)},
+		api.Device{UUID: String(secID), Status: String(DeviceStateProvisioned), RedundantUUID: String(priID)},
+	)
+	registerDeviceSequence(priID,
+		api.Device{UUID: String(priID), Status: String(DeviceStateWaitingSecondary)},
+		api.Device{UUID: String(priID), Status: String(DeviceStateProvisioned)},
+		api.Device{UUID: String(priID), Status: String(DeviceStateProvisioned), RedundantUUID: String(secID)},
+	)
+	defer httpmock.DeactivateAndReset()
+
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	redundantUUID, err := c.AddSecondaryAndWait(context.Background(), priID, Device{Name: String("secondary")}, &testWaitOptions)
+
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, secID, StringValue(redundantUUID), "Redundant UUID matches")
+	assert.Equal(t, priID, StringValue(req.PrimaryDeviceUUID), "Primary device UUID was sent")
+	assert.Equal(t, 7, httpmock.GetTotalCallCount(), "All devices states were polled")
+}
+
+func TestAddSecondaryAndWait_secondaryFailed(t *testing.T) {
+	//given
+	priID := "primary"
+	secID := "secondary"
+	testHc := setupMockedClient("POST", fmt.Sprintf("%s/ne/v1/devices", baseURL), 202,
+		api.DeviceRequestResponse{SecondaryUUID: String(secID)})
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, secID),
+		httpmock.NewJsonResponderOrPanic(200, api.Device{UUID: String(secID), Status: String(DeviceStateFailed)}))
+	defer httpmock.DeactivateAndReset()
+
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	secUUID, err := c.AddSecondaryAndWait(context.Background(), priID, Device{Name: String("secondary")}, &testWaitOptions)
+
+	//then
+	stateErr := DeviceStateError{}
+	assert.True(t, errors.As(err, &stateErr), "DeviceStateError is returned")
+	assert.Equal(t, secID, StringValue(secUUID), "Secondary UUID is returned")
+}
+
+func TestAddSecondaryAndWait_otherRedundantDevice(t *testing.T) {
+	//given
+	priID := "primary"
+	secID := "secondary"
+	testHc := setupMockedClient("POST", fmt.Sprintf("%s/ne/v1/devices", baseURL), 202,
+		api.DeviceRequestResponse{SecondaryUUID: String(secID)})
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, secID),
+		httpmock.NewJsonResponderOrPanic(200, api.Device{UUID: String(secID), Status: String(DeviceStateProvisioned)}))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, priID),
+		httpmock.NewJsonResponderOrPanic(200, api.Device{UUID: String(priID), Status: String(DeviceStateProvisioned), RedundantUUID: String("other")}))
+	defer httpmock.DeactivateAndReset()
+
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	secUUID, err := c.AddSecondaryAndWait(context.Background(), priID, Device{Name: String("secondary")}, &testWaitOptions)
+
+	//then
+	assert.NotNil(t, err, "Error is returned")
+	assert.Contains(t, err.Error(), "other", "Error names linked redundant device")
+	assert.Equal(t, secID, StringValue(secUUID), "Secondary UUID is returned")
+	assert.Equal(t, 3, httpmock.GetTotalCallCount(), "Primary device is not polled after mismatch")
+}
@@ -1,6 +1,7 @@
 package ne
 
 import (
+	"context"
 	"github.com/equinix/ne-go/internal/api"
 	"io"
 	"net/http"
@@ -18,6 +19,12 @@ const (
 //UploadFile performs multipart upload of a cloud_init/license file from a given reader interface
 //along with provided data. Uploaded file identifier is returned on success.
 func (c RestClient) UploadFile(metroCode, deviceTypeCode, processType, deviceManagementMode, licenseMode, fileName string, reader io.Reader) (*string, error) {
+	return c.UploadFileWithContext(c.ctx, metroCode, deviceTypeCode, processType, deviceManagementMode, licenseMode, fileName, reader)
+}
+
+//UploadFileWithContext performs multipart upload of a cloud_init/license file from a given reader interface
+//along with provided data using given context. Uploaded file identifier is returned on success.
+func (c RestClient) UploadFileWithContext(ctx context.Context, metroCode, deviceTypeCode, processType, deviceManagementMode, licenseMode, fileName string, reader io.Reader) (*string, error) {
 	path := "/ne/v1/files"
 	respBody := api.FileUploadResponse{}
 	req := c.R().
@@ -30,7 +37,7 @@ func (c RestClient) UploadFile(metroCode, deviceTypeCode, processType, deviceMan
 			"deviceManagementType": deviceManagementMode,
 		}).
 		SetResult(&respBody)
-	if err := c.Execute(req, http.MethodPost, path); err != nil {
+	if err := c.execute(ctx, req, http.MethodPost, path); err != nil {
 		return nil, err
 	}
 	return respBody.FileUUID, nil
@@ -38,10 +45,15 @@ func (c RestClient) UploadFile(metroCode, deviceTypeCode, processType, deviceMan
 
 //GetFile retrieves file metadata with a given UUID
 func (c RestClient) GetFile(uuid string) (*File, error) {
+	return c.GetFileWithContext(c.ctx, uuid)
+}
+
+//GetFileWithContext retrieves file metadata with a given UUID using given context
+func (c RestClient) GetFileWithContext(ctx context.Context, uuid string) (*File, error) {
 	path := "/ne/v1/files/" + url.PathEscape(uuid)
 	respBody := api.File{}
 	req := c.R().SetResult(&respBody)
-	if err := c.Execute(req, http.MethodGet, path); err != nil {
+	if err := c.execute(ctx, req, http.MethodGet, path); err != nil {
 		return nil, err
 	}
 	file := mapFileAPIToDomain(respBody)
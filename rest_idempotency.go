@@ -0,0 +1,186 @@
+package ne
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// SetIdempotentCreates enables or disables idempotent creation of devices, SSH users,
+// ACL templates and SSH public keys. When enabled, existing resource matching
+// an idempotency key is looked up before creation request is sent and its UUID
+// is returned instead of creating a duplicate. Idempotency keys are:
+//   - devices: order reference, or name and metro code when order reference is not set.
+//     Deprovisioning and deprovisioned devices are not matched
+//   - SSH users: username and associated device
+//   - ACL templates and SSH public keys: name and project identifier
+func (c *RestClient) SetIdempotentCreates(enabled bool) *RestClient {
+	c.idempotentCreates = enabled
+	return c
+}
+
+// EnsureDeviceDeleted deletes device with a given UUID, along with its redundant
+// device, using given context. Device that does not exist, is deprovisioning
+// or is already deprovisioned is treated as deleted
+func (c RestClient) EnsureDeviceDeleted(ctx context.Context, uuid string) error {
+	err := c.DeleteDeviceWithContext(ctx, uuid)
+	if IsNotFound(err) || IsDeviceRemoved(err) {
+		return nil
+	}
+	return err
+}
+
+// EnsureSSHUserDeleted deletes SSH user with a given UUID using given context.
+// SSH user that does not exist, or whose every device association is not found,
+// is treated as deleted
+func (c RestClient) EnsureSSHUserDeleted(ctx context.Context, uuid string) error {
+	return ignoreNotFound(c.DeleteSSHUserWithContext(ctx, uuid))
+}
+
+// EnsureACLTemplateDeleted deletes ACL template with a given UUID using given context.
+// ACL template that does not exist is treated as deleted
+func (c RestClient) EnsureACLTemplateDeleted(ctx context.Context, uuid string) error {
+	return ignoreNotFound(c.DeleteACLTemplateWithContext(ctx, uuid))
+}
+
+// EnsureDeviceLinkGroupDeleted deletes device link group with a given UUID using
+// given context. Device link group that does not exist is treated as deleted
+func (c RestClient) EnsureDeviceLinkGroupDeleted(ctx context.Context, uuid string) error {
+	return ignoreNotFound(c.DeleteDeviceLinkGroupWithContext(ctx, uuid))
+}
+
+// EnsureSSHPublicKeyDeleted deletes SSH public key with a given UUID using given
+// context. SSH public key that does not exist is treated as deleted
+func (c RestClient) EnsureSSHPublicKeyDeleted(ctx context.Context, uuid string) error {
+	return ignoreNotFound(c.DeleteSSHPublicKeyWithContext(ctx, uuid))
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Unexported package methods
+//_______________________________________________________________________
+
+//findDevice returns device matching idempotency key of a given device order
+//or nil, when no such device exists
+func (c RestClient) findDevice(ctx context.Context, device Device) (*Device, error) {
+	opts := &DeviceListOptions{
+		MetroCode: StringValue(device.MetroCode),
+	}
+	orderReference := StringValue(device.OrderReference)
+	if orderReference == "" {
+		if StringValue(device.Name) == "" || opts.MetroCode == "" {
+			return nil, nil
+		}
+		opts.NameContains = StringValue(device.Name)
+	}
+	devices, err := c.GetDevicesWithOptions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	for i := range devices {
+		status := StringValue(devices[i].Status)
+		if status == DeviceStateDeprovisioning || status == DeviceStateDeprovisioned {
+			continue
+		}
+		if orderReference != "" && StringValue(devices[i].OrderReference) != orderReference {
+			continue
+		}
+		if orderReference == "" && StringValue(devices[i].Name) != StringValue(device.Name) {
+			continue
+		}
+		return &devices[i], nil
+	}
+	return nil, nil
+}
+
+//verifyExistingSecondary verifies if existing primary device has redundant device
+//that matches idempotency key of a given secondary device order
+func (c RestClient) verifyExistingSecondary(ctx context.Context, primary Device, secondary Device) error {
+	uuid := StringValue(primary.UUID)
+	redundantUUID := StringValue(primary.RedundantUUID)
+	if redundantUUID == "" {
+		return fmt.Errorf("existing device %q has no redundant device", uuid)
+	}
+	redundant, err := c.GetDeviceWithContext(ctx, redundantUUID)
+	if err != nil {
+		return err
+	}
+	matches := secondary.MetroCode == nil || StringValue(redundant.MetroCode) == StringValue(secondary.MetroCode)
+	if secondary.OrderReference != nil {
+		matches = matches && StringValue(redundant.OrderReference) == StringValue(secondary.OrderReference)
+	} else if secondary.Name != nil {
+		matches = matches && StringValue(redundant.Name) == StringValue(secondary.Name)
+	}
+	if !matches {
+		return fmt.Errorf("redundant device %q of existing device %q does not match secondary device", redundantUUID, uuid)
+	}
+	return nil
+}
+
+//findSSHUser returns UUID of SSH user with a given username, associated
+//with a given device, or nil, when no such user exists
+func (c RestClient) findSSHUser(ctx context.Context, username string, device string) (*string, error) {
+	users, err := c.GetSSHUsersWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range users {
+		if StringValue(users[i].Username) == username && containsString(users[i].DeviceUUIDs, device) {
+			return users[i].UUID, nil
+		}
+	}
+	return nil, nil
+}
+
+//findACLTemplate returns UUID of ACL template with the same name and project
+//as a given template, or nil, when no such template exists
+func (c RestClient) findACLTemplate(ctx context.Context, template ACLTemplate) (*string, error) {
+	templates, err := c.GetACLTemplatesWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range templates {
+		if StringValue(templates[i].Name) == StringValue(template.Name) &&
+			(template.ProjectID == nil || StringValue(templates[i].ProjectID) == *template.ProjectID) {
+			return templates[i].UUID, nil
+		}
+	}
+	return nil, nil
+}
+
+//findSSHPublicKey returns UUID of SSH public key with the same name and project
+//as a given key, or nil, when no such key exists
+func (c RestClient) findSSHPublicKey(ctx context.Context, key SSHPublicKey) (*string, error) {
+	keys, err := c.GetSSHPublicKeysWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range keys {
+		if StringValue(keys[i].Name) == StringValue(key.Name) &&
+			(key.ProjectID == nil || StringValue(keys[i].ProjectID) == *key.ProjectID) {
+			return keys[i].UUID, nil
+		}
+	}
+	return nil, nil
+}
+
+//ignoreNotFound returns nil when given error indicates that resource does not exist.
+//Update error is ignored only when every of its failed changes indicates that, so
+//that not found association does not hide other failures
+func ignoreNotFound(err error) error {
+	updateErr := UpdateError{}
+	if errors.As(err, &updateErr) {
+		if updateErr.ChangeErrorsCount() == 0 {
+			return err
+		}
+		for i := range updateErr.Failed {
+			if !IsNotFound(updateErr.Failed[i]) {
+				return err
+			}
+		}
+		return nil
+	}
+	if IsNotFound(err) {
+		return nil
+	}
+	return err
+}
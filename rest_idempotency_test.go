@@ -0,0 +1,113 @@
+package ne
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/equinix/ne-go/internal/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateDevice_idempotent(t *testing.T) {
+	//given
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices", baseURL),
+		httpmock.NewJsonResponderOrPanic(200, api.DevicesResponse{Pagination: api.Pagination{Total: 3}, Data: []api.Device{
+			{UUID: String("removed"), MetroCode: String("SV"), OrderReference: String("order"), Status: String(DeviceStateDeprovisioned)},
+			{UUID: String("other"), MetroCode: String("SV"), OrderReference: String("otherOrder"), Status: String(DeviceStateProvisioned)},
+			{UUID: String("existing"), MetroCode: String("SV"), OrderReference: String("order"), Status: String(DeviceStateProvisioning)},
+		}}))
+	c := NewClient(context.Background(), baseURL, testHc).SetIdempotentCreates(true)
+	c.PageSize = 10
+	//when
+	uuid, err := c.CreateDeviceWithContext(context.Background(), Device{MetroCode: String("SV"), OrderReference: String("order")})
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, "existing", StringValue(uuid), "Existing device UUID is returned")
+	assert.Equal(t, 0, httpmock.GetCallCountInfo()[fmt.Sprintf("POST %s/ne/v1/devices", baseURL)], "Device is not created")
+}
+
+func TestEnsureDeleted(t *testing.T) {
+	//given
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	defer httpmock.DeactivateAndReset()
+	removed := api.ErrorResponses{{ErrorCode: ErrorCodeDeviceRemoved}}
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("%s/ne/v1/devices/removed", baseURL),
+		httpmock.NewJsonResponderOrPanic(400, removed))
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("%s/ne/v1/devices/invalid", baseURL),
+		httpmock.NewJsonResponderOrPanic(400, api.ErrorResponses{}))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/sshUsers/missing", baseURL),
+		httpmock.NewStringResponder(404, ""))
+	c := NewClient(context.Background(), baseURL, testHc)
+	//when
+	removedErr := c.EnsureDeviceDeleted(context.Background(), "removed")
+	invalidErr := c.EnsureDeviceDeleted(context.Background(), "invalid")
+	missingErr := c.EnsureSSHUserDeleted(context.Background(), "missing")
+	//then
+	assert.Nil(t, removedErr, "Removed device is treated as deleted")
+	assert.NotNil(t, invalidErr, "Other errors are returned")
+	assert.Nil(t, missingErr, "Missing SSH user is treated as deleted")
+}
+
+func TestEnsureSSHUserDeleted_associations(t *testing.T) {
+	//given
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/sshUsers/mixed", baseURL),
+		httpmock.NewJsonResponderOrPanic(200, api.SSHUser{UUID: String("mixed"), DeviceUUIDs: []string{"missing", "failing"}}))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/sshUsers/unassociated", baseURL),
+		httpmock.NewJsonResponderOrPanic(200, api.SSHUser{UUID: String("unassociated"), DeviceUUIDs: []string{"missing", "removed"}}))
+	for _, user := range []string{"mixed", "unassociated"} {
+		for _, device := range []string{"missing", "removed"} {
+			httpmock.RegisterResponder("DELETE", fmt.Sprintf("%s/ne/v1/sshUsers/%s/devices/%s", baseURL, user, device),
+				httpmock.NewStringResponder(404, ""))
+		}
+	}
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("%s/ne/v1/sshUsers/mixed/devices/failing", baseURL),
+		httpmock.NewJsonResponderOrPanic(500, api.ErrorResponses{}))
+	c := NewClient(context.Background(), baseURL, testHc)
+	//when
+	mixedErr := c.EnsureSSHUserDeleted(context.Background(), "mixed")
+	unassociatedErr := c.EnsureSSHUserDeleted(context.Background(), "unassociated")
+	//then
+	assert.NotNil(t, mixedErr, "Error is returned when one of associations failed")
+	assert.Nil(t, unassociatedErr, "SSH user with not found associations is treated as deleted")
+}
+
+func TestCreateRedundantDevice_idempotent(t *testing.T) {
+	//given
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices", baseURL),
+		httpmock.NewJsonResponderOrPanic(200, api.DevicesResponse{Pagination: api.Pagination{Total: 2}, Data: []api.Device{
+			{UUID: String("single"), MetroCode: String("SV"), OrderReference: String("singleOrder"), Status: String(DeviceStateProvisioned)},
+			{UUID: String("primary"), MetroCode: String("SV"), OrderReference: String("order"), Status: String(DeviceStateProvisioned), RedundantUUID: String("secondary")},
+		}}))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices/secondary", baseURL),
+		httpmock.NewJsonResponderOrPanic(200, api.Device{UUID: String("secondary"), MetroCode: String("DC"), OrderReference: String("secondaryOrder")}))
+	c := NewClient(context.Background(), baseURL, testHc).SetIdempotentCreates(true)
+	c.PageSize = 10
+	primary := Device{MetroCode: String("SV"), OrderReference: String("order")}
+	//when
+	pUUID, sUUID, err := c.CreateRedundantDeviceWithContext(context.Background(), primary,
+		Device{MetroCode: String("DC"), OrderReference: String("secondaryOrder")})
+	_, _, otherErr := c.CreateRedundantDeviceWithContext(context.Background(), primary,
+		Device{MetroCode: String("DC"), OrderReference: String("otherOrder")})
+	_, _, singleErr := c.CreateRedundantDeviceWithContext(context.Background(),
+		Device{MetroCode: String("SV"), OrderReference: String("singleOrder")}, Device{MetroCode: String("DC")})
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, "primary", StringValue(pUUID), "Existing primary device UUID is returned")
+	assert.Equal(t, "secondary", StringValue(sUUID), "Existing secondary device UUID is returned")
+	assert.NotNil(t, otherErr, "Error is returned when secondary device does not match")
+	assert.NotNil(t, singleErr, "Error is returned when existing device has no redundant device")
+	assert.Equal(t, 0, httpmock.GetCallCountInfo()[fmt.Sprintf("POST %s/ne/v1/devices", baseURL)], "Devices are not created")
+}
@@ -1,6 +1,7 @@
 package ne
 
 import (
+	"context"
 	"io"
 	"net/http"
 
@@ -10,6 +11,12 @@ import (
 //UploadLicenseFile performs multipart upload of a license file from a given reader interface
 //along with provided data. Uploaded file identifier is returned on success.
 func (c RestClient) UploadLicenseFile(metroCode, deviceTypeCode, deviceManagementMode, licenseMode, fileName string, reader io.Reader) (*string, error) {
+	return c.UploadLicenseFileWithContext(c.ctx, metroCode, deviceTypeCode, deviceManagementMode, licenseMode, fileName, reader)
+}
+
+//UploadLicenseFileWithContext performs multipart upload of a license file from a given reader interface
+//along with provided data using given context. Uploaded file identifier is returned on success.
+func (c RestClient) UploadLicenseFileWithContext(ctx context.Context, metroCode, deviceTypeCode, deviceManagementMode, licenseMode, fileName string, reader io.Reader) (*string, error) {
 	path := "/ne/v1/devices/licenseFiles"
 	respBody := api.LicenseFileUploadResponse{}
 	req := c.R().
@@ -21,7 +28,7 @@ func (c RestClient) UploadLicenseFile(metroCode, deviceTypeCode, deviceManagemen
 			"deviceManagementType": deviceManagementMode,
 		}).
 		SetResult(&respBody)
-	if err := c.Execute(req, http.MethodPost, path); err != nil {
+	if err := c.execute(ctx, req, http.MethodPost, path); err != nil {
 		return nil, err
 	}
 	return respBody.FileID, nil
@@ -1,6 +1,7 @@
 package ne
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 
@@ -9,10 +10,15 @@ import (
 
 // GetSSHPublicKeys retrieves list of available SSH public keys
 func (c RestClient) GetSSHPublicKeys() ([]SSHPublicKey, error) {
+	return c.GetSSHPublicKeysWithContext(c.ctx)
+}
+
+// GetSSHPublicKeysWithContext retrieves list of available SSH public keys using given context
+func (c RestClient) GetSSHPublicKeysWithContext(ctx context.Context) ([]SSHPublicKey, error) {
 	path := "/ne/v1/publicKeys"
 	respBody := make([]api.SSHPublicKey, 0)
 	req := c.R().SetResult(&respBody)
-	if err := c.Execute(req, http.MethodGet, path); err != nil {
+	if err := c.execute(ctx, req, http.MethodGet, path); err != nil {
 		return nil, err
 	}
 	return mapSSHPublicKeysAPIToDomain(respBody), nil
@@ -20,10 +26,15 @@ func (c RestClient) GetSSHPublicKeys() ([]SSHPublicKey, error) {
 
 // GetSSHPublicKey retrieves SSH public key with a given identifier
 func (c RestClient) GetSSHPublicKey(uuid string) (*SSHPublicKey, error) {
+	return c.GetSSHPublicKeyWithContext(c.ctx, uuid)
+}
+
+// GetSSHPublicKeyWithContext retrieves SSH public key with a given identifier using given context
+func (c RestClient) GetSSHPublicKeyWithContext(ctx context.Context, uuid string) (*SSHPublicKey, error) {
 	path := "/ne/v1/publicKeys/" + url.PathEscape(uuid)
 	respBody := api.SSHPublicKey{}
 	req := c.R().SetResult(&respBody)
-	if err := c.Execute(req, http.MethodGet, path); err != nil {
+	if err := c.execute(ctx, req, http.MethodGet, path); err != nil {
 		return nil, err
 	}
 	mapped := mapSSHPublicKeyAPIToDomain(respBody)
@@ -32,10 +43,21 @@ func (c RestClient) GetSSHPublicKey(uuid string) (*SSHPublicKey, error) {
 
 // CreateSSHPublicKey creates new SSH public key with a given details
 func (c RestClient) CreateSSHPublicKey(key SSHPublicKey) (*string, error) {
+	return c.CreateSSHPublicKeyWithContext(c.ctx, key)
+}
+
+// CreateSSHPublicKeyWithContext creates new SSH public key with a given details using given context.
+// UUID of existing key is returned, when idempotent creates were enabled with SetIdempotentCreates
+func (c RestClient) CreateSSHPublicKeyWithContext(ctx context.Context, key SSHPublicKey) (*string, error) {
+	if c.idempotentCreates {
+		if uuid, err := c.findSSHPublicKey(ctx, key); err != nil || uuid != nil {
+			return uuid, err
+		}
+	}
 	path := "/ne/v1/publicKeys"
 	reqBody := mapSSHPublicKeyDomainToAPI(key)
 	req := c.R().SetBody(&reqBody)
-	resp, err := c.Do(http.MethodPost, path, req)
+	resp, err := c.do(ctx, http.MethodPost, path, req)
 	if err != nil {
 		return nil, err
 	}
@@ -48,8 +70,13 @@ func (c RestClient) CreateSSHPublicKey(key SSHPublicKey) (*string, error) {
 
 // DeleteSSHPublicKey removes SSH Public key with given identifier
 func (c RestClient) DeleteSSHPublicKey(uuid string) error {
+	return c.DeleteSSHPublicKeyWithContext(c.ctx, uuid)
+}
+
+// DeleteSSHPublicKeyWithContext removes SSH Public key with given identifier using given context
+func (c RestClient) DeleteSSHPublicKeyWithContext(ctx context.Context, uuid string) error {
 	path := "/ne/v1/publicKeys/" + url.PathEscape(uuid)
-	if err := c.Execute(c.R(), http.MethodDelete, path); err != nil {
+	if err := c.execute(ctx, c.R(), http.MethodDelete, path); err != nil {
 		return err
 	}
 	return nil
@@ -1,6 +1,7 @@
 package ne
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -24,6 +25,18 @@ type restSSHUserUpdateRequest struct {
 
 //CreateSSHUser creates new Network Edge SSH user with a given parameters and returns its UUID upon successful creation
 func (c RestClient) CreateSSHUser(username string, password string, device string) (*string, error) {
+	return c.CreateSSHUserWithContext(c.ctx, username, password, device)
+}
+
+//CreateSSHUserWithContext creates new Network Edge SSH user with a given parameters using given context
+//and returns its UUID upon successful creation. UUID of existing user is returned,
+//when idempotent creates were enabled with SetIdempotentCreates
+func (c RestClient) CreateSSHUserWithContext(ctx context.Context, username string, password string, device string) (*string, error) {
+	if c.idempotentCreates {
+		if uuid, err := c.findSSHUser(ctx, username, device); err != nil || uuid != nil {
+			return uuid, err
+		}
+	}
 	path := "/ne/v1/sshUsers"
 	reqBody := api.SSHUserRequest{
 		Username:   &username,
@@ -31,7 +44,7 @@ func (c RestClient) CreateSSHUser(username string, password string, device strin
 		DeviceUUID: &device,
 	}
 	req := c.R().SetBody(&reqBody)
-	resp, err := c.Do(http.MethodPost, path, req)
+	resp, err := c.do(ctx, http.MethodPost, path, req)
 	if err != nil {
 		return nil, err
 	}
@@ -44,8 +57,13 @@ func (c RestClient) CreateSSHUser(username string, password string, device strin
 
 //GetSSHUsers retrieves list of all SSH users (with details)
 func (c RestClient) GetSSHUsers() ([]SSHUser, error) {
+	return c.GetSSHUsersWithContext(c.ctx)
+}
+
+//GetSSHUsersWithContext retrieves list of all SSH users (with details) using given context
+func (c RestClient) GetSSHUsersWithContext(ctx context.Context) ([]SSHUser, error) {
 	path := "/ne/v1/sshUsers"
-	content, err := c.GetOffsetPaginated(path, &api.SSHUsersResponse{},
+	content, err := c.getOffsetPaginated(ctx, path, &api.SSHUsersResponse{},
 		rest.DefaultOffsetPagingConfig().
 			SetAdditionalParams(map[string]string{"verbose": "true"}))
 	if err != nil {
@@ -60,10 +78,15 @@ func (c RestClient) GetSSHUsers() ([]SSHUser, error) {
 
 //GetSSHUser fetches details of a SSH user with a given UUID
 func (c RestClient) GetSSHUser(uuid string) (*SSHUser, error) {
+	return c.GetSSHUserWithContext(c.ctx, uuid)
+}
+
+//GetSSHUserWithContext fetches details of a SSH user with a given UUID using given context
+func (c RestClient) GetSSHUserWithContext(ctx context.Context, uuid string) (*SSHUser, error) {
 	path := "/ne/v1/sshUsers/" + url.PathEscape(uuid)
 	respBody := api.SSHUser{}
 	req := c.R().SetResult(&respBody)
-	if err := c.Execute(req, http.MethodGet, path); err != nil {
+	if err := c.execute(ctx, req, http.MethodGet, path); err != nil {
 		return nil, err
 	}
 	return mapSSHUserAPIToDomain(respBody), nil
@@ -71,6 +94,12 @@ func (c RestClient) GetSSHUser(uuid string) (*SSHUser, error) {
 
 //NewSSHUserUpdateRequest creates new composite update request for a user with a given UUID
 func (c RestClient) NewSSHUserUpdateRequest(uuid string) SSHUserUpdateRequest {
+	return c.NewSSHUserUpdateRequestWithContext(uuid)
+}
+
+//NewSSHUserUpdateRequestWithContext creates new composite update request for a user
+//with a given UUID, that can be executed with per-call context
+func (c RestClient) NewSSHUserUpdateRequestWithContext(uuid string) SSHUserUpdateRequestWithContext {
 	return &restSSHUserUpdateRequest{
 		uuid: uuid,
 		c:    c}
@@ -78,13 +107,18 @@ func (c RestClient) NewSSHUserUpdateRequest(uuid string) SSHUserUpdateRequest {
 
 //DeleteSSHUser deletes ssh user with a given UUID
 func (c RestClient) DeleteSSHUser(uuid string) error {
-	user, err := c.GetSSHUser(uuid)
+	return c.DeleteSSHUserWithContext(c.ctx, uuid)
+}
+
+//DeleteSSHUserWithContext deletes ssh user with a given UUID using given context
+func (c RestClient) DeleteSSHUserWithContext(ctx context.Context, uuid string) error {
+	user, err := c.GetSSHUserWithContext(ctx, uuid)
 	if err != nil {
 		return err
 	}
 	updateErr := UpdateError{}
 	for _, dev := range user.DeviceUUIDs {
-		if err := c.changeDeviceAssociation(unassociateDevice, uuid, dev); err != nil {
+		if err := c.changeDeviceAssociation(ctx, unassociateDevice, uuid, dev); err != nil {
 			updateErr.AddChangeError(changeTypeDelete, "devices", dev, err)
 		}
 	}
@@ -106,44 +140,70 @@ func (req *restSSHUserUpdateRequest) WithDeviceChange(old []string, new []string
 }
 
 func (req *restSSHUserUpdateRequest) Execute() error {
-	updateErr := UpdateError{}
+	return req.ExecuteWithContext(req.c.ctx)
+}
+
+func (req *restSSHUserUpdateRequest) ExecuteWithContext(ctx context.Context) error {
+	_, err := req.ExecuteWithResult(ctx, nil)
+	return err
+}
+
+// ExecuteWithResult attempts to update SSH user according new data set in composite update
+// request using given context and options. Password is changed first, then devices are
+// associated and unassociated. Device association changes are independent and can be
+// executed concurrently. Result with outcome of every attempted change is returned along
+// with UpdateError, if any change failed
+func (req *restSSHUserUpdateRequest) ExecuteWithResult(ctx context.Context, opts *UpdateOptions) (*UpdateResult, error) {
+	var groups [][]updateChange
 	if req.newPassword != "" {
-		if err := req.c.changeUserPassword(req.uuid, req.newPassword); err != nil {
-			updateErr.AddChangeError(changeTypeUpdate, "password", req.newPassword, err)
-		}
+		groups = append(groups, []updateChange{{
+			changeType: changeTypeUpdate,
+			targets:    []string{"password"},
+			values:     []interface{}{Secret(req.newPassword)},
+			run: func(ctx context.Context) error {
+				return req.c.changeUserPassword(ctx, req.uuid, req.newPassword)
+			},
+		}})
 	}
 	removed, added := diffStringSlices(req.oldDevices, req.newDevices)
+	var associations []updateChange
 	for _, dev := range added {
-		if err := req.c.changeDeviceAssociation(associateDevice, req.uuid, dev); err != nil {
-			updateErr.AddChangeError(changeTypeCreate, "devices", dev, err)
-		}
+		associations = append(associations, req.deviceAssociationChange(changeTypeCreate, associateDevice, dev))
 	}
 	for _, dev := range removed {
-		if err := req.c.changeDeviceAssociation(unassociateDevice, req.uuid, dev); err != nil {
-			updateErr.AddChangeError(changeTypeDelete, "devices", dev, err)
-		}
+		associations = append(associations, req.deviceAssociationChange(changeTypeDelete, unassociateDevice, dev))
 	}
-	if updateErr.ChangeErrorsCount() > 0 {
-		return updateErr
-	}
-	return nil
+	groups = append(groups, associations)
+	result := executeChanges(ctx, opts, groups...)
+	return result, result.Err()
 }
 
 //‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
 // Unexported package methods
 //_______________________________________________________________________
 
-func (c RestClient) changeUserPassword(userID string, newPassword string) error {
+func (req *restSSHUserUpdateRequest) deviceAssociationChange(changeType string, associationType string, deviceID string) updateChange {
+	return updateChange{
+		changeType: changeType,
+		targets:    []string{"devices"},
+		values:     []interface{}{deviceID},
+		run: func(ctx context.Context) error {
+			return req.c.changeDeviceAssociation(ctx, associationType, req.uuid, deviceID)
+		},
+	}
+}
+
+func (c RestClient) changeUserPassword(ctx context.Context, userID string, newPassword string) error {
 	path := "/ne/v1/sshUsers/" + url.PathEscape(userID)
 	reqBody := api.SSHUserUpdateRequest{Password: &newPassword}
 	req := c.R().SetBody(&reqBody)
-	if err := c.Execute(req, http.MethodPut, path); err != nil {
+	if err := c.execute(ctx, req, http.MethodPut, path); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (c RestClient) changeDeviceAssociation(changeType string, userID string, deviceID string) error {
+func (c RestClient) changeDeviceAssociation(ctx context.Context, changeType string, userID string, deviceID string) error {
 	path := fmt.Sprintf("/ne/v1/sshUsers/%s/devices/%s",
 		url.PathEscape(userID), url.PathEscape(deviceID))
 	var method string
@@ -159,7 +219,7 @@ func (c RestClient) changeDeviceAssociation(changeType string, userID string, de
 		//due to bug in NE API that requires content type and content len = 0 altough there is no content needed in any case
 		SetHeader("Content-Type", "application/json").
 		SetBody("{}")
-	if err := c.Execute(req, method, path); err != nil {
+	if err := c.execute(ctx, req, method, path); err != nil {
 		return err
 	}
 	return nil
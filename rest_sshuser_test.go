@@ -186,3 +186,37 @@ func verifyUserRequest(t *testing.T, user SSHUser, req api.SSHUserRequest) {
 func verifyUserUpdateRequest(t *testing.T, updateReq *restSSHUserUpdateRequest, req api.SSHUserUpdateRequest) {
 	assert.Equal(t, updateReq.newPassword, StringValue(req.Password), "Password matches")
 }
+
+func TestSSHUserUpdateWithResult(t *testing.T) {
+	//given
+	userID := "myTestUser"
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/ne/v1/sshUsers/%s", baseURL, userID),
+		httpmock.NewStringResponder(201, ""))
+	for _, dev := range []string{"Dev1", "Dev3"} {
+		httpmock.RegisterResponder("POST", fmt.Sprintf("%s/ne/v1/sshUsers/%s/devices/%s", baseURL, userID, dev),
+			httpmock.NewStringResponder(201, ""))
+	}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/ne/v1/sshUsers/%s/devices/%s", baseURL, userID, "Dev2"),
+		httpmock.NewJsonResponderOrPanic(500, api.ErrorResponses{}))
+	defer httpmock.DeactivateAndReset()
+
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	req := c.NewSSHUserUpdateRequestWithContext(userID)
+	req.WithNewPassword("myNewPassword").
+		WithDeviceChange(nil, []string{"Dev1", "Dev2", "Dev3"})
+	result, err := req.ExecuteWithResult(context.Background(), &UpdateOptions{Concurrency: 3})
+
+	//then
+	assert.NotNil(t, err, "Error is returned")
+	assert.Equal(t, 4, len(result.Changes), "All changes are reported")
+	assert.Equal(t, []string{"password", "devices", "devices", "devices"},
+		[]string{result.Changes[0].Target, result.Changes[1].Target, result.Changes[2].Target, result.Changes[3].Target},
+		"Changes are reported in requested order")
+	assert.Equal(t, 3, len(result.Succeeded()), "Three changes succeeded")
+	assert.Equal(t, 1, len(result.Failed()), "One change failed")
+	assert.Equal(t, "Dev2", result.Failed()[0].Value, "Failed change value matches")
+	assert.NotNil(t, result.Failed()[0].Cause, "Failed change cause is set")
+}
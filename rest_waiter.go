@@ -0,0 +1,119 @@
+package ne
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	waitResourceTypeBGP                 = "BGP configuration"
+	waitResourceTypeDeviceACL           = "device ACL"
+	waitResourceTypeAdditionalBandwidth = "device additional bandwidth"
+	waitResourceTypeDeviceLinkGroup     = "device link group"
+)
+
+// WaitForBGPConfigurationStatus polls BGP configuration with a given UUID until its
+// provisioning status is one of given target statuses. Waiting stops with
+// ResourceStatusError when BGP provisioning has failed.
+// Last fetched configuration is returned along with an error.
+func (c RestClient) WaitForBGPConfigurationStatus(ctx context.Context, uuid string, targetStatuses []string, opts *WaitOptions) (*BGPConfiguration, error) {
+	var config *BGPConfiguration
+	err := waitForStatus(ctx, waitResourceTypeBGP, uuid, opts, targetStatuses,
+		[]string{BGPProvisioningStatusFailed},
+		func(ctx context.Context) (string, interface{}, error) {
+			fetched, err := c.GetBGPConfigurationWithContext(ctx, uuid)
+			if err != nil {
+				return "", nil, err
+			}
+			config = fetched
+			return StringValue(fetched.ProvisioningStatus), fetched, nil
+		})
+	return config, err
+}
+
+// WaitForBGPSessionState polls BGP configuration with a given UUID until it is provisioned
+// and its BGP peer state is one of given target states, i.e. BGPStateEstablished.
+// Waiting stops with ResourceStatusError when BGP provisioning has failed.
+// Observed status has a form of provisioning status and peer state separated with slash.
+// Last fetched configuration is returned along with an error.
+func (c RestClient) WaitForBGPSessionState(ctx context.Context, uuid string, targetStates []string, opts *WaitOptions) (*BGPConfiguration, error) {
+	var config *BGPConfiguration
+	err := waitFor(ctx, waitResourceTypeBGP, uuid, opts, func(ctx context.Context) (string, interface{}, bool, error) {
+		fetched, err := c.GetBGPConfigurationWithContext(ctx, uuid)
+		if err != nil {
+			return "", nil, false, err
+		}
+		config = fetched
+		provisioningStatus := StringValue(fetched.ProvisioningStatus)
+		state := StringValue(fetched.State)
+		status := fmt.Sprintf("%s/%s", provisioningStatus, state)
+		if provisioningStatus == BGPProvisioningStatusFailed {
+			return status, fetched, false, ResourceStatusError{
+				ResourceType:     waitResourceTypeBGP,
+				ResourceID:       uuid,
+				Status:           provisioningStatus,
+				ExpectedStatuses: []string{BGPProvisioningStatusProvisioned},
+			}
+		}
+		done := provisioningStatus == BGPProvisioningStatusProvisioned && containsString(targetStates, state)
+		return status, fetched, done, nil
+	})
+	return config, err
+}
+
+// WaitForDeviceACLStatus polls ACL provisioning details of a device with a given UUID
+// until ACL status is one of given target statuses.
+// Last fetched details are returned along with an error.
+func (c RestClient) WaitForDeviceACLStatus(ctx context.Context, uuid string, targetStatuses []string, opts *WaitOptions) (*DeviceACLDetails, error) {
+	var details *DeviceACLDetails
+	err := waitForStatus(ctx, waitResourceTypeDeviceACL, uuid, opts, targetStatuses, nil,
+		func(ctx context.Context) (string, interface{}, error) {
+			fetched, err := c.GetDeviceACLDetailsWithContext(ctx, uuid)
+			if err != nil {
+				return "", nil, err
+			}
+			details = fetched
+			return StringValue(fetched.Status), fetched, nil
+		})
+	return details, err
+}
+
+// WaitForDeviceAdditionalBandwidthStatus polls additional bandwidth details of a device
+// with a given UUID until additional bandwidth status is one of given target statuses.
+// Last fetched details are returned along with an error.
+func (c RestClient) WaitForDeviceAdditionalBandwidthStatus(ctx context.Context, uuid string, targetStatuses []string, opts *WaitOptions) (*DeviceAdditionalBandwidthDetails, error) {
+	var details *DeviceAdditionalBandwidthDetails
+	err := waitForStatus(ctx, waitResourceTypeAdditionalBandwidth, uuid, opts, targetStatuses, nil,
+		func(ctx context.Context) (string, interface{}, error) {
+			fetched, err := c.GetDeviceAdditionalBandwidthDetailsWithContext(ctx, uuid)
+			if err != nil {
+				return "", nil, err
+			}
+			details = fetched
+			return StringValue(fetched.Status), fetched, nil
+		})
+	return details, err
+}
+
+// WaitForDeviceLinkGroupStatus polls device link group with a given UUID until its
+// status is one of given target statuses. Waiting stops with ResourceStatusError when
+// link group is being deprovisioned while other statuses were expected.
+// Last fetched link group is returned along with an error.
+func (c RestClient) WaitForDeviceLinkGroupStatus(ctx context.Context, uuid string, targetStatuses []string, opts *WaitOptions) (*DeviceLinkGroup, error) {
+	var linkGroup *DeviceLinkGroup
+	failureStatuses := []string{DeviceLinkGroupStatusDeprovisioning, DeviceLinkGroupStatusDeprovisioned}
+	if containsString(targetStatuses, DeviceLinkGroupStatusDeprovisioning) ||
+		containsString(targetStatuses, DeviceLinkGroupStatusDeprovisioned) {
+		failureStatuses = nil
+	}
+	err := waitForStatus(ctx, waitResourceTypeDeviceLinkGroup, uuid, opts, targetStatuses, failureStatuses,
+		func(ctx context.Context) (string, interface{}, error) {
+			fetched, err := c.GetDeviceLinkGroupWithContext(ctx, uuid)
+			if err != nil {
+				return "", nil, err
+			}
+			linkGroup = fetched
+			return StringValue(fetched.Status), fetched, nil
+		})
+	return linkGroup, err
+}
@@ -0,0 +1,164 @@
+package ne
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/equinix/ne-go/internal/api"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitForBGPConfigurationStatus(t *testing.T) {
+	//given
+	bgpID := "myBGP"
+	testHc := setupMockedSequence("GET", fmt.Sprintf("%s/ne/v1/bgp/%s", baseURL, bgpID),
+		api.BGPConfiguration{UUID: String(bgpID), ProvisioningStatus: String(BGPProvisioningStatusProvisioning)},
+		api.BGPConfiguration{UUID: String(bgpID), ProvisioningStatus: String(BGPProvisioningStatusProvisioned)},
+	)
+	defer httpmock.DeactivateAndReset()
+
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	config, err := c.WaitForBGPConfigurationStatus(context.Background(), bgpID, []string{BGPProvisioningStatusProvisioned}, &testWaitOptions)
+
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, BGPProvisioningStatusProvisioned, StringValue(config.ProvisioningStatus), "Provisioning status matches")
+	assert.Equal(t, 2, httpmock.GetTotalCallCount(), "Number of status checks matches")
+}
+
+func TestWaitForBGPConfigurationStatus_failed(t *testing.T) {
+	//given
+	bgpID := "myBGP"
+	testHc := setupMockedSequence("GET", fmt.Sprintf("%s/ne/v1/bgp/%s", baseURL, bgpID),
+		api.BGPConfiguration{UUID: String(bgpID), ProvisioningStatus: String(BGPProvisioningStatusFailed)},
+	)
+	defer httpmock.DeactivateAndReset()
+
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	_, err := c.WaitForBGPConfigurationStatus(context.Background(), bgpID, []string{BGPProvisioningStatusProvisioned}, &testWaitOptions)
+
+	//then
+	statusErr := ResourceStatusError{}
+	assert.True(t, errors.As(err, &statusErr), "ResourceStatusError is returned")
+	assert.Equal(t, BGPProvisioningStatusFailed, statusErr.Status, "Error status matches")
+	assert.Equal(t, bgpID, statusErr.ResourceID, "Error resource identifier matches")
+}
+
+func TestWaitForBGPSessionState(t *testing.T) {
+	//given
+	bgpID := "myBGP"
+	testHc := setupMockedSequence("GET", fmt.Sprintf("%s/ne/v1/bgp/%s", baseURL, bgpID),
+		api.BGPConfiguration{ProvisioningStatus: String(BGPProvisioningStatusProvisioning), State: String(BGPStateIdle)},
+		api.BGPConfiguration{ProvisioningStatus: String(BGPProvisioningStatusProvisioned), State: String(BGPStateConnect)},
+		api.BGPConfiguration{ProvisioningStatus: String(BGPProvisioningStatusProvisioned), State: String(BGPStateEstablished)},
+	)
+	defer httpmock.DeactivateAndReset()
+
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	config, err := c.WaitForBGPSessionState(context.Background(), bgpID, []string{BGPStateEstablished}, &testWaitOptions)
+
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, BGPStateEstablished, StringValue(config.State), "BGP state matches")
+	assert.Equal(t, 3, httpmock.GetTotalCallCount(), "Number of status checks matches")
+}
+
+func TestWaitForBGPSessionState_timeout(t *testing.T) {
+	//given
+	bgpID := "myBGP"
+	testHc := setupMockedSequence("GET", fmt.Sprintf("%s/ne/v1/bgp/%s", baseURL, bgpID),
+		api.BGPConfiguration{ProvisioningStatus: String(BGPProvisioningStatusProvisioned), State: String(BGPStateActive)},
+	)
+	defer httpmock.DeactivateAndReset()
+	opts := testWaitOptions
+	opts.Timeout = 20 * time.Millisecond
+
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	_, err := c.WaitForBGPSessionState(context.Background(), bgpID, []string{BGPStateEstablished}, &opts)
+
+	//then
+	timeoutErr := WaitTimeoutError{}
+	assert.True(t, errors.As(err, &timeoutErr), "WaitTimeoutError is returned")
+	assert.Equal(t, BGPProvisioningStatusProvisioned+"/"+BGPStateActive, timeoutErr.LastStatus, "Last observed status matches")
+}
+
+func TestWaitForDeviceACLStatus(t *testing.T) {
+	//given
+	devID := "myDevice"
+	testHc := setupMockedSequence("GET", fmt.Sprintf("%s/ne/v1/devices/%s/acl", baseURL, devID),
+		api.DeviceACLResponse{Status: String(ACLDeviceStatusProvisioning)},
+		api.DeviceACLResponse{Status: String(ACLDeviceStatusProvisioned)},
+	)
+	defer httpmock.DeactivateAndReset()
+
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	details, err := c.WaitForDeviceACLStatus(context.Background(), devID, []string{ACLDeviceStatusProvisioned}, &testWaitOptions)
+
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, ACLDeviceStatusProvisioned, StringValue(details.Status), "ACL status matches")
+}
+
+func TestWaitForDeviceAdditionalBandwidthStatus(t *testing.T) {
+	//given
+	devID := "myDevice"
+	testHc := setupMockedSequence("GET", fmt.Sprintf("%s/ne/v1/devices/%s/additionalBandwidths", baseURL, devID),
+		api.DeviceAdditionalBandwidthResponse{AdditionalBandwidth: Int(100), Status: String(DeviceAdditionalBandwidthStatusProvisioning)},
+		api.DeviceAdditionalBandwidthResponse{AdditionalBandwidth: Int(100), Status: String(DeviceAdditionalBandwidthStatusProvisioned)},
+	)
+	defer httpmock.DeactivateAndReset()
+
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	details, err := c.WaitForDeviceAdditionalBandwidthStatus(context.Background(), devID, []string{DeviceAdditionalBandwidthStatusProvisioned}, &testWaitOptions)
+
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, DeviceAdditionalBandwidthStatusProvisioned, StringValue(details.Status), "Additional bandwidth status matches")
+	assert.Equal(t, 100, IntValue(details.AdditionalBandwidth), "Additional bandwidth matches")
+}
+
+func TestWaitForDeviceLinkGroupStatus(t *testing.T) {
+	//given
+	linkID := "myLink"
+	testHc := setupMockedSequence("GET", fmt.Sprintf("%s/ne/v1/links/%s", baseURL, linkID),
+		api.DeviceLinkGroup{UUID: String(linkID), Status: String(DeviceLinkGroupStatusProvisioning)},
+		api.DeviceLinkGroup{UUID: String(linkID), Status: String(DeviceLinkGroupStatusProvisioned)},
+	)
+	defer httpmock.DeactivateAndReset()
+
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	linkGroup, err := c.WaitForDeviceLinkGroupStatus(context.Background(), linkID, []string{DeviceLinkGroupStatusProvisioned}, &testWaitOptions)
+
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, DeviceLinkGroupStatusProvisioned, StringValue(linkGroup.Status), "Link group status matches")
+}
+
+func TestWaitForDeviceLinkGroupStatus_deprovisioned(t *testing.T) {
+	//given
+	linkID := "myLink"
+	testHc := setupMockedSequence("GET", fmt.Sprintf("%s/ne/v1/links/%s", baseURL, linkID),
+		api.DeviceLinkGroup{UUID: String(linkID), Status: String(DeviceLinkGroupStatusDeprovisioning)},
+	)
+	defer httpmock.DeactivateAndReset()
+
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	_, err := c.WaitForDeviceLinkGroupStatus(context.Background(), linkID, []string{DeviceLinkGroupStatusProvisioned}, &testWaitOptions)
+
+	//then
+	statusErr := ResourceStatusError{}
+	assert.True(t, errors.As(err, &statusErr), "ResourceStatusError is returned")
+	assert.Equal(t, DeviceLinkGroupStatusDeprovisioning, statusErr.Status, "Error status matches")
+}
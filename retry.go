@@ -0,0 +1,147 @@
+package ne
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// RetryPolicy describes how Network Edge API requests are retried on rate limiting,
+// server side errors and transport errors. Delay between attempts grows exponentially
+// and is randomized with jitter. When server responds with Retry-After header, its
+// value is used as a delay instead. Both delays are capped with MaxBackoff.
+type RetryPolicy struct {
+	//MaxAttempts is maximum number of attempts, including first one.
+	//Values lower than two disable retries
+	MaxAttempts int
+	//InitialBackoff is a delay before first retry
+	InitialBackoff time.Duration
+	//MaxBackoff is upper limit of a delay between attempts
+	MaxBackoff time.Duration
+	//BackoffMultiplier is a factor by which delay grows after each attempt
+	BackoffMultiplier float64
+	//Jitter is a fraction, between zero and one, of a delay that is randomized
+	Jitter float64
+	//RetryableMethods is a list of HTTP methods that are retried. Non idempotent
+	//methods, like POST or PATCH, have to be added explicitly.
+	//Multipart file uploads are never retried
+	RetryableMethods []string
+	//RetryableStatusCodes is a list of HTTP response codes that are retried
+	RetryableStatusCodes []int
+}
+
+// DefaultRetryPolicy returns retry policy that retries idempotent requests up to
+// four times on rate limiting, server side errors and transport errors
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:       5,
+		InitialBackoff:    time.Second,
+		MaxBackoff:        30 * time.Second,
+		BackoffMultiplier: 2,
+		Jitter:            0.2,
+		RetryableMethods:  []string{http.MethodGet, http.MethodPut, http.MethodDelete},
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+//SetRetryPolicy sets policy used to retry failed requests. Nil policy disables retries
+func (c *RestClient) SetRetryPolicy(policy *RetryPolicy) *RestClient {
+	c.retryPolicy = policy
+	return c
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Unexported package methods
+//_______________________________________________________________________
+
+//shouldRetry verifies if request, that was executed given number of times
+//with a given outcome, should be retried
+func (p *RetryPolicy) shouldRetry(req *resty.Request, attempt int, resp *resty.Response, err error) bool {
+	if p == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+	if !containsString(p.RetryableMethods, req.Method) || len(req.FormData) > 0 {
+		return false
+	}
+	if err != nil {
+		return req.Context().Err() == nil
+	}
+	if resp == nil {
+		return false
+	}
+	for _, code := range p.RetryableStatusCodes {
+		if resp.StatusCode() == code {
+			return true
+		}
+	}
+	return false
+}
+
+//backoff returns delay before next attempt. Retry-After header value is used when present.
+//Delay never exceeds MaxBackoff, when it is set
+func (p *RetryPolicy) backoff(attempt int, resp *resty.Response) time.Duration {
+	if resp != nil {
+		if delay, ok := parseRetryAfter(resp.Header().Get("Retry-After"), time.Now()); ok {
+			if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+				delay = p.MaxBackoff
+			}
+			return delay
+		}
+	}
+	delay := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		delay *= p.BackoffMultiplier
+		if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+			delay = float64(p.MaxBackoff)
+			break
+		}
+	}
+	if p.Jitter > 0 {
+		delay -= delay * p.Jitter * rand.Float64()
+	}
+	return time.Duration(delay)
+}
+
+//parseRetryAfter parses Retry-After header value given either as
+//number of seconds or as HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := date.Sub(now)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+//sleepContext waits for a given time or until context is done
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,242 @@
+package ne
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/equinix/ne-go/internal/api"
+	"github.com/equinix/rest-go"
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxAttempts:          3,
+	InitialBackoff:       time.Millisecond,
+	MaxBackoff:           2 * time.Millisecond,
+	BackoffMultiplier:    2,
+	RetryableMethods:     []string{http.MethodGet, http.MethodPut, http.MethodDelete},
+	RetryableStatusCodes: []int{http.StatusTooManyRequests, http.StatusServiceUnavailable},
+}
+
+func TestRetry_serverError(t *testing.T) {
+	//given
+	devID := "myDevice"
+	testHc := setupMockedSequence("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID),
+		httpmock.NewStringResponse(http.StatusServiceUnavailable, ""),
+		httpmock.NewStringResponse(http.StatusTooManyRequests, ""),
+		api.Device{UUID: String(devID)},
+	)
+	defer httpmock.DeactivateAndReset()
+	policy := testRetryPolicy
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	c.SetRetryPolicy(&policy)
+	device, err := c.GetDevice(devID)
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, devID, StringValue(device.UUID), "Device UUID matches")
+	assert.Equal(t, 3, httpmock.GetTotalCallCount(), "Request was attempted three times")
+}
+
+func TestRetry_exhausted(t *testing.T) {
+	//given
+	devID := "myDevice"
+	testHc := setupMockedSequence("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID),
+		httpmock.NewStringResponse(http.StatusServiceUnavailable, ""),
+	)
+	defer httpmock.DeactivateAndReset()
+	policy := testRetryPolicy
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	c.SetRetryPolicy(&policy)
+	_, err := c.GetDevice(devID)
+	//then
+	restErr := rest.Error{}
+	assert.True(t, errors.As(err, &restErr), "Error is rest.Error")
+	assert.Equal(t, http.StatusServiceUnavailable, restErr.HTTPCode, "HTTP code of last response is returned")
+	assert.Equal(t, policy.MaxAttempts, httpmock.GetTotalCallCount(), "Request was attempted max number of times")
+}
+
+func TestRetry_notRetryableStatus(t *testing.T) {
+	//given
+	devID := "myDevice"
+	testHc := setupMockedSequence("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID),
+		httpmock.NewStringResponse(http.StatusNotFound, ""),
+	)
+	defer httpmock.DeactivateAndReset()
+	policy := testRetryPolicy
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	c.SetRetryPolicy(&policy)
+	_, err := c.GetDevice(devID)
+	//then
+	assert.NotNil(t, err, "Error is returned")
+	assert.Equal(t, 1, httpmock.GetTotalCallCount(), "Request was attempted once")
+}
+
+func TestRetry_transportError(t *testing.T) {
+	//given
+	devID := "myDevice"
+	attempts := 0
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID),
+		func(r *http.Request) (*http.Response, error) {
+			attempts++
+			if attempts == 1 {
+				return nil, errors.New("connection reset")
+			}
+			return httpmock.NewJsonResponse(http.StatusOK, api.Device{UUID: String(devID)})
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+	policy := testRetryPolicy
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	c.SetRetryPolicy(&policy)
+	device, err := c.GetDevice(devID)
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, devID, StringValue(device.UUID), "Device UUID matches")
+	assert.Equal(t, 2, attempts, "Request was attempted twice")
+}
+
+func TestRetry_nonIdempotentMethod(t *testing.T) {
+	//given
+	url := fmt.Sprintf("%s/ne/v1/devices", baseURL)
+	policy := testRetryPolicy
+	tests := []struct {
+		name     string
+		methods  []string
+		attempts int
+	}{
+		{"default", policy.RetryableMethods, 1},
+		{"optIn", append([]string{http.MethodPost}, policy.RetryableMethods...), 2},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testHc := setupMockedSequence("POST", url,
+				httpmock.NewStringResponse(http.StatusServiceUnavailable, ""),
+				httpmock.NewStringResponse(http.StatusCreated, ""),
+			)
+			defer httpmock.DeactivateAndReset()
+			p := policy
+			p.RetryableMethods = tc.methods
+			//when
+			c := NewClient(context.Background(), baseURL, testHc)
+			c.SetRetryPolicy(&p)
+			_, _ = c.do(context.Background(), http.MethodPost, "/ne/v1/devices", c.R().SetBody(api.Device{}))
+			//then
+			assert.Equal(t, tc.attempts, httpmock.GetTotalCallCount(), "Number of attempts matches")
+		})
+	}
+}
+
+func TestRetry_requestBodyResent(t *testing.T) {
+	//given
+	devID := "myDevice"
+	bodies := []string{}
+	testHc := &http.Client{}
+	httpmock.ActivateNonDefault(testHc)
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID),
+		func(r *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			if len(bodies) == 1 {
+				return httpmock.NewStringResponse(http.StatusBadGateway, ""), nil
+			}
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		},
+	)
+	defer httpmock.DeactivateAndReset()
+	policy := testRetryPolicy
+	policy.RetryableStatusCodes = []int{http.StatusBadGateway}
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	c.SetRetryPolicy(&policy)
+	_, err := c.do(context.Background(), http.MethodPut, fmt.Sprintf("/ne/v1/devices/%s", devID), c.R().SetBody(api.Device{Name: String("test")}))
+	//then
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, 2, len(bodies), "Request was attempted twice")
+	assert.Equal(t, bodies[0], bodies[1], "Request body was resent")
+}
+
+func TestRetry_retryAfter(t *testing.T) {
+	//given
+	devID := "myDevice"
+	resp := httpmock.NewStringResponse(http.StatusTooManyRequests, "")
+	resp.Header.Set("Retry-After", "1")
+	testHc := setupMockedSequence("GET", fmt.Sprintf("%s/ne/v1/devices/%s", baseURL, devID),
+		resp,
+		api.Device{UUID: String(devID)},
+	)
+	defer httpmock.DeactivateAndReset()
+	policy := testRetryPolicy
+	policy.MaxBackoff = 2 * time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	c.SetRetryPolicy(&policy)
+	_, err := c.GetDeviceWithContext(ctx, devID)
+	//then
+	restErr := rest.Error{}
+	assert.True(t, errors.As(err, &restErr), "Error is rest.Error")
+	assert.Equal(t, http.StatusTooManyRequests, restErr.HTTPCode, "Rate limiting error is returned when context expires before Retry-After")
+	assert.Equal(t, 1, httpmock.GetTotalCallCount(), "Request was attempted once")
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2020, 10, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"", 0, false},
+		{"5", 5 * time.Second, true},
+		{"-1", 0, false},
+		{now.Add(30 * time.Second).Format(http.TimeFormat), 30 * time.Second, true},
+		{now.Add(-30 * time.Second).Format(http.TimeFormat), 0, true},
+		{"soon", 0, false},
+	}
+	for _, tc := range tests {
+		//when
+		delay, ok := parseRetryAfter(tc.value, now)
+		//then
+		assert.Equal(t, tc.ok, ok, "Parsing result matches for %q", tc.value)
+		assert.Equal(t, tc.expected, delay, "Delay matches for %q", tc.value)
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	//given
+	policy := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second, BackoffMultiplier: 2}
+	//when
+	delays := []time.Duration{policy.backoff(1, nil), policy.backoff(2, nil), policy.backoff(3, nil), policy.backoff(4, nil)}
+	//then
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}, delays, "Delays grow exponentially up to limit")
+	policy.Jitter = 0.5
+	for i := 0; i < 10; i++ {
+		delay := policy.backoff(2, nil)
+		assert.True(t, delay > time.Second && delay <= 2*time.Second, "Jittered delay is within range")
+	}
+}
+
+func TestRetryPolicy_backoffRetryAfterCapped(t *testing.T) {
+	//given
+	policy := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second, BackoffMultiplier: 2}
+	resp := &resty.Response{RawResponse: &http.Response{Header: http.Header{}}}
+	resp.RawResponse.Header.Set("Retry-After", "3600")
+	//when
+	delay := policy.backoff(1, resp)
+	//then
+	assert.Equal(t, 5*time.Second, delay, "Retry-After delay is capped with MaxBackoff")
+}
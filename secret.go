@@ -0,0 +1,278 @@
+package ne
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// RedactedValue is printed instead of secret values
+const RedactedValue = "[REDACTED]"
+
+// Secret is a sensitive value, like password or authentication key. Secret is
+// printed as RedactedValue by fmt functions, regardless of a verb, and is encoded
+// to JSON as RedactedValue. Use Reveal to get actual value
+type Secret string
+
+// Reveal returns actual value of a secret
+func (s Secret) Reveal() string {
+	return string(s)
+}
+
+func (s Secret) String() string {
+	return RedactedValue
+}
+
+// GoString returns RedactedValue, so that %#v verb does not print actual value
+func (s Secret) GoString() string {
+	return RedactedValue
+}
+
+// Format writes RedactedValue for any fmt verb
+func (s Secret) Format(f fmt.State, verb rune) {
+	io.WriteString(f, RedactedValue)
+}
+
+// MarshalJSON encodes secret as RedactedValue
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + RedactedValue + `"`), nil
+}
+
+func (d Device) String() string {
+	return redactedString(d, false)
+}
+
+// GoString formats device with its secrets, like license token
+// or admin passwords in vendor configuration, redacted
+func (d Device) GoString() string {
+	return redactedString(d, true)
+}
+
+func (u SSHUser) String() string {
+	return redactedString(u, false)
+}
+
+// GoString formats SSH user with its password redacted
+func (u SSHUser) GoString() string {
+	return redactedString(u, true)
+}
+
+func (c BGPConfiguration) String() string {
+	return redactedString(c, false)
+}
+
+// GoString formats BGP configuration with its authentication key redacted
+func (c BGPConfiguration) GoString() string {
+	return redactedString(c, true)
+}
+
+func (d ClusterDetails) String() string {
+	return redactedString(d, false)
+}
+
+// GoString formats cluster details with secrets of its nodes redacted
+func (d ClusterDetails) GoString() string {
+	return redactedString(d, true)
+}
+
+func (d ClusterNodeDetail) String() string {
+	return redactedString(d, false)
+}
+
+// GoString formats cluster node details with license token and
+// secrets in vendor configuration redacted
+func (d ClusterNodeDetail) GoString() string {
+	return redactedString(d, true)
+}
+
+func (n ClusterNode) String() string {
+	return redactedString(n, false)
+}
+
+// GoString formats cluster node with admin password and secrets
+// in vendor configuration redacted
+func (n ClusterNode) GoString() string {
+	return redactedString(n, true)
+}
+
+func (s ClusterSpec) String() string {
+	return redactedString(s, false)
+}
+
+// GoString formats cluster order with secrets of a device and its nodes redacted
+func (s ClusterSpec) GoString() string {
+	return redactedString(s, true)
+}
+
+func (s ClusterNodeSpec) String() string {
+	return redactedString(s, false)
+}
+
+// GoString formats cluster node order with license token and
+// secrets in vendor configuration redacted
+func (s ClusterNodeSpec) GoString() string {
+	return redactedString(s, true)
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Unexported package methods
+//_______________________________________________________________________
+
+//sensitiveNameParts are lower case fragments of field names, map keys
+//and change targets that hold secrets
+var sensitiveNameParts = []string{
+	"password", "secret", "token", "authenticationkey", "authkey",
+	"activationkey", "licensekey", "privatekey",
+}
+
+//isSensitiveName verifies if field, map key or change target with
+//a given name holds a secret
+func isSensitiveName(name string) bool {
+	name = strings.ToLower(name)
+	for _, part := range sensitiveNameParts {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+	return false
+}
+
+//redactedChangeValue formats value of a change with a given target like %s verb.
+//String held by a target with a sensitive name is replaced with RedactedValue,
+//as are secrets within composite values, like maps of device fields
+func redactedChangeValue(target string, value interface{}) string {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		if isSensitiveName(target) {
+			return RedactedValue
+		}
+	case reflect.Map, reflect.Struct, reflect.Slice, reflect.Array:
+		return redactedString(value, false)
+	}
+	return fmt.Sprintf("%s", value)
+}
+
+//redactedString formats given value like %+v verb, or like %#v verb when goSyntax
+//is set. Pointers are dereferenced and strings held by fields or map keys with
+//sensitive names are replaced with RedactedValue
+func redactedString(value interface{}, goSyntax bool) string {
+	b := &strings.Builder{}
+	writeRedacted(b, reflect.ValueOf(value), goSyntax)
+	return b.String()
+}
+
+func writeRedacted(b *strings.Builder, v reflect.Value, goSyntax bool) {
+	switch v.Kind() {
+	case reflect.Invalid:
+		b.WriteString("<nil>")
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			writeNil(b, goSyntax)
+			return
+		}
+		writeRedacted(b, v.Elem(), goSyntax)
+	case reflect.Struct:
+		if goSyntax {
+			b.WriteString(v.Type().String())
+		}
+		b.WriteByte('{')
+		for i := 0; i < v.NumField(); i++ {
+			if i > 0 {
+				writeSeparator(b, goSyntax)
+			}
+			name := v.Type().Field(i).Name
+			b.WriteString(name + ":")
+			writeRedactedNamed(b, name, v.Field(i), goSyntax)
+		}
+		b.WriteByte('}')
+	case reflect.Map:
+		if v.IsNil() {
+			writeNil(b, goSyntax)
+			return
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		if goSyntax {
+			b.WriteString(v.Type().String() + "{")
+		} else {
+			b.WriteString("map[")
+		}
+		for i, key := range keys {
+			if i > 0 {
+				writeSeparator(b, goSyntax)
+			}
+			writeRedacted(b, key, goSyntax)
+			b.WriteByte(':')
+			writeRedactedNamed(b, fmt.Sprint(key), v.MapIndex(key), goSyntax)
+		}
+		if goSyntax {
+			b.WriteByte('}')
+		} else {
+			b.WriteByte(']')
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			writeNil(b, goSyntax)
+			return
+		}
+		if goSyntax {
+			b.WriteString(v.Type().String() + "{")
+		} else {
+			b.WriteByte('[')
+		}
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				writeSeparator(b, goSyntax)
+			}
+			writeRedacted(b, v.Index(i), goSyntax)
+		}
+		if goSyntax {
+			b.WriteByte('}')
+		} else {
+			b.WriteByte(']')
+		}
+	default:
+		if goSyntax {
+			fmt.Fprintf(b, "%#v", v)
+		} else {
+			fmt.Fprint(b, v)
+		}
+	}
+}
+
+//writeRedactedNamed writes value held by a field or map key with a given name
+func writeRedactedNamed(b *strings.Builder, name string, v reflect.Value, goSyntax bool) {
+	elem := v
+	for (elem.Kind() == reflect.Ptr || elem.Kind() == reflect.Interface) && !elem.IsNil() {
+		elem = elem.Elem()
+	}
+	if elem.Kind() == reflect.String && isSensitiveName(name) {
+		b.WriteString(RedactedValue)
+		return
+	}
+	writeRedacted(b, v, goSyntax)
+}
+
+func writeNil(b *strings.Builder, goSyntax bool) {
+	if goSyntax {
+		b.WriteString("nil")
+	} else {
+		b.WriteString("<nil>")
+	}
+}
+
+func writeSeparator(b *strings.Builder, goSyntax bool) {
+	if goSyntax {
+		b.WriteString(", ")
+	} else {
+		b.WriteByte(' ')
+	}
+}
@@ -0,0 +1,99 @@
+package ne
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecret(t *testing.T) {
+	//given
+	secret := Secret("mySecret")
+	//when
+	formatted := []string{
+		fmt.Sprintf("%s", secret),
+		fmt.Sprintf("%v", secret),
+		fmt.Sprintf("%+v", secret),
+		fmt.Sprintf("%#v", secret),
+		fmt.Sprintf("%q", secret),
+		fmt.Sprintf("%x", secret),
+		fmt.Sprintf("%d", secret),
+		fmt.Sprintf("%v", []interface{}{secret}),
+	}
+	encoded, err := json.Marshal(map[string]interface{}{"password": secret})
+	//then
+	for _, str := range formatted {
+		assert.Contains(t, str, RedactedValue, "Secret is redacted")
+		assert.NotContains(t, str, "mySecret", "Secret is not revealed")
+	}
+	assert.Nil(t, err, "Error is not returned")
+	assert.Equal(t, `{"password":"[REDACTED]"}`, string(encoded), "Secret is redacted in JSON")
+	assert.Equal(t, "mySecret", secret.Reveal(), "Secret value is revealed")
+}
+
+func TestChangeError_redacted(t *testing.T) {
+	//given
+	userID := "myUser"
+	password := "myNewPassword"
+	testHc := setupMockedClient("PUT", fmt.Sprintf("%s/ne/v1/sshUsers/%s", baseURL, userID), 500, nil)
+	defer httpmock.DeactivateAndReset()
+	//when
+	c := NewClient(context.Background(), baseURL, testHc)
+	err := c.NewSSHUserUpdateRequest(userID).WithNewPassword(password).Execute()
+	changeErr := ChangeError{Type: changeTypeUpdate, Target: "authenticationKey", Value: String("myKey")}
+	mapErr := ChangeError{Type: changeTypeUpdate, Target: "vendorConfig", Value: map[string]string{"adminPassword": "myAdminPass", "hostname": "myHost"}}
+	//then
+	assert.NotNil(t, err, "Error is returned")
+	assert.Contains(t, err.Error(), RedactedValue, "Password is redacted")
+	assert.NotContains(t, err.Error(), password, "Password is not revealed")
+	assert.NotContains(t, changeErr.Error(), "myKey", "Sensitive target value is not revealed")
+	assert.NotContains(t, mapErr.Error(), "myAdminPass", "Sensitive map value is not revealed")
+	assert.Contains(t, mapErr.Error(), "myHost", "Map value is present")
+}
+
+func TestDomainTypes_redacted(t *testing.T) {
+	//given
+	device := Device{
+		Name:                String("myDevice"),
+		LicenseToken:        String("myLicenseToken"),
+		VendorConfiguration: map[string]string{"adminPassword": "myVendorPass", "hostname": "myHost"},
+		ClusterDetails: &ClusterDetails{
+			ClusterName: String("myCluster"),
+			Node0:       &ClusterNodeDetail{LicenseToken: String("myNodeToken")},
+			Nodes:       []ClusterNode{{Name: String("myNode"), AdminPassword: String("myNodePass")}},
+		},
+		IsGenerateDefaultPassword: Bool(true),
+	}
+	user := SSHUser{Username: String("myUser"), Password: String("myUserPass")}
+	bgp := BGPConfiguration{LocalASN: Int(65000), AuthenticationKey: String("myAuthKey")}
+	spec := ClusterSpec{ClusterName: "myCluster", Nodes: []ClusterNodeSpec{{LicenseToken: "mySpecToken"}}}
+	secrets := []string{"myLicenseToken", "myVendorPass", "myNodeToken", "myNodePass", "myUserPass", "myAuthKey", "mySpecToken"}
+	//when
+	formatted := []string{
+		fmt.Sprintf("%v", device),
+		fmt.Sprintf("%+v", &device),
+		fmt.Sprintf("%#v", device),
+		fmt.Sprintf("%v", user),
+		fmt.Sprintf("%#v", user),
+		fmt.Sprintf("%v", bgp),
+		fmt.Sprintf("%#v", bgp),
+		fmt.Sprintf("%v", spec),
+		fmt.Sprintf("%#v", []ClusterNode{device.ClusterDetails.Nodes[0]}),
+	}
+	//then
+	for _, str := range formatted {
+		assert.Contains(t, str, RedactedValue, "Secrets are redacted")
+		for _, secret := range secrets {
+			assert.NotContains(t, str, secret, "Secret is not revealed")
+		}
+	}
+	assert.Contains(t, formatted[0], "Name:myDevice", "Device name is present")
+	assert.Contains(t, formatted[0], "hostname:myHost", "Vendor configuration is present")
+	assert.Contains(t, formatted[0], "IsGenerateDefaultPassword:true", "Non string values are not redacted")
+	assert.Contains(t, formatted[2], `Name:"myDevice"`, "Go syntax device name is present")
+	assert.Contains(t, formatted[5], "LocalASN:65000", "BGP local ASN is present")
+}
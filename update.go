@@ -0,0 +1,161 @@
+package ne
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	//ChangeStatusSucceeded indicates that change was applied
+	ChangeStatusSucceeded = "SUCCEEDED"
+	//ChangeStatusFailed indicates that change failed to apply
+	ChangeStatusFailed = "FAILED"
+	//ChangeStatusSkipped indicates that change was not attempted because
+	//earlier change failed and update was executed in fail fast mode
+	ChangeStatusSkipped = "SKIPPED"
+)
+
+// UpdateOptions describes configuration of composite update request execution
+type UpdateOptions struct {
+	//FailFast stops execution on first failed change. Changes that were not
+	//started yet are skipped. By default, all changes are attempted
+	FailFast bool
+	//Concurrency is a maximum number of independent changes, like SSH user
+	//device associations, that are executed concurrently. Changes are
+	//executed one by one when not set
+	Concurrency int
+}
+
+// ChangeResult describes outcome of a single change attempted during
+// composite update request
+type ChangeResult struct {
+	Type     string
+	Target   string
+	Value    interface{}
+	Status   string
+	Duration time.Duration
+	Cause    error
+}
+
+// UpdateResult describes outcome of all changes of a composite update request,
+// in the order they were requested
+type UpdateResult struct {
+	Changes []ChangeResult
+}
+
+// Succeeded returns changes that were applied
+func (r UpdateResult) Succeeded() []ChangeResult {
+	return r.withStatus(ChangeStatusSucceeded)
+}
+
+// Failed returns changes that failed to apply
+func (r UpdateResult) Failed() []ChangeResult {
+	return r.withStatus(ChangeStatusFailed)
+}
+
+// Skipped returns changes that were not attempted
+func (r UpdateResult) Skipped() []ChangeResult {
+	return r.withStatus(ChangeStatusSkipped)
+}
+
+// Err returns UpdateError composed of failed changes or nil when no change failed
+func (r UpdateResult) Err() error {
+	updateErr := UpdateError{}
+	for _, change := range r.Failed() {
+		updateErr.AddChangeError(change.Type, change.Target, change.Value, change.Cause)
+	}
+	if updateErr.ChangeErrorsCount() > 0 {
+		return updateErr
+	}
+	return nil
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Unexported package methods
+//_______________________________________________________________________
+
+func (r UpdateResult) withStatus(status string) []ChangeResult {
+	var changes []ChangeResult
+	for _, change := range r.Changes {
+		if change.Status == status {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+//updateChange is a single change of a composite update request. Change may
+//affect multiple targets, i.e. ACL templates updated with single API call
+type updateChange struct {
+	changeType string
+	targets    []string
+	values     []interface{}
+	run        func(ctx context.Context) error
+}
+
+//executeChanges executes given groups of changes in order. Changes within a
+//group are independent and may run concurrently, according to given options
+func executeChanges(ctx context.Context, o *UpdateOptions, groups ...[]updateChange) *UpdateResult {
+	opts := UpdateOptions{}
+	if o != nil {
+		opts = *o
+	}
+	if opts.Concurrency < 1 {
+		opts.Concurrency = 1
+	}
+	result := &UpdateResult{}
+	failed := false
+	for _, group := range groups {
+		outcomes := make([][]ChangeResult, len(group))
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, opts.Concurrency)
+		for i := range group {
+			sem <- struct{}{}
+			mu.Lock()
+			skip := failed && opts.FailFast
+			mu.Unlock()
+			if skip {
+				<-sem
+				outcomes[i] = changeResults(group[i], ChangeStatusSkipped, 0, nil)
+				continue
+			}
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				start := time.Now()
+				err := group[i].run(ctx)
+				status := ChangeStatusSucceeded
+				if err != nil {
+					status = ChangeStatusFailed
+					mu.Lock()
+					failed = true
+					mu.Unlock()
+				}
+				outcomes[i] = changeResults(group[i], status, time.Since(start), err)
+			}(i)
+		}
+		wg.Wait()
+		for i := range outcomes {
+			result.Changes = append(result.Changes, outcomes[i]...)
+		}
+	}
+	return result
+}
+
+func changeResults(change updateChange, status string, duration time.Duration, cause error) []ChangeResult {
+	results := make([]ChangeResult, len(change.targets))
+	for i := range change.targets {
+		results[i] = ChangeResult{
+			Type:     change.changeType,
+			Target:   change.targets[i],
+			Value:    change.values[i],
+			Status:   status,
+			Duration: duration,
+			Cause:    cause,
+		}
+	}
+	return results
+}
@@ -0,0 +1,91 @@
+package ne
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testUpdateChange(target string, err error, calls *int32) updateChange {
+	return updateChange{
+		changeType: changeTypeUpdate,
+		targets:    []string{target},
+		values:     []interface{}{target + "Value"},
+		run: func(ctx context.Context) error {
+			atomic.AddInt32(calls, 1)
+			return err
+		},
+	}
+}
+
+func TestExecuteChanges(t *testing.T) {
+	//given
+	var calls int32
+	failure := errors.New("failure")
+	groups := [][]updateChange{
+		{testUpdateChange("first", nil, &calls)},
+		{testUpdateChange("second", failure, &calls), testUpdateChange("third", nil, &calls)},
+	}
+	//when
+	result := executeChanges(context.Background(), nil, groups...)
+	//then
+	assert.Equal(t, int32(3), calls, "All changes were executed")
+	assert.Equal(t, 3, len(result.Changes), "All changes are reported")
+	assert.Equal(t, ChangeStatusSucceeded, result.Changes[0].Status, "First change succeeded")
+	assert.Equal(t, ChangeStatusFailed, result.Changes[1].Status, "Second change failed")
+	assert.Equal(t, failure, result.Changes[1].Cause, "Second change cause matches")
+	assert.Equal(t, ChangeStatusSucceeded, result.Changes[2].Status, "Third change succeeded")
+	updateErr := UpdateError{}
+	assert.True(t, errors.As(result.Err(), &updateErr), "UpdateError is returned")
+	assert.Equal(t, "second", updateErr.Failed[0].Target, "Failed change target matches")
+}
+
+func TestExecuteChanges_failFast(t *testing.T) {
+	//given
+	var calls int32
+	groups := [][]updateChange{
+		{testUpdateChange("first", errors.New("failure"), &calls), testUpdateChange("second", nil, &calls)},
+		{testUpdateChange("third", nil, &calls)},
+	}
+	//when
+	result := executeChanges(context.Background(), &UpdateOptions{FailFast: true}, groups...)
+	//then
+	assert.Equal(t, int32(1), calls, "Only first change was executed")
+	assert.Equal(t, 1, len(result.Failed()), "First change failed")
+	assert.Equal(t, 2, len(result.Skipped()), "Remaining changes were skipped")
+	assert.Nil(t, result.Skipped()[0].Cause, "Skipped change has no cause")
+}
+
+func TestExecuteChanges_concurrency(t *testing.T) {
+	//given
+	var running, maxRunning int32
+	change := updateChange{
+		changeType: changeTypeCreate,
+		targets:    []string{"devices"},
+		values:     []interface{}{"dev"},
+		run: func(ctx context.Context) error {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return nil
+		},
+	}
+	group := []updateChange{change, change, change, change, change, change}
+	//when
+	result := executeChanges(context.Background(), &UpdateOptions{Concurrency: 3}, group)
+	//then
+	assert.Equal(t, 6, len(result.Succeeded()), "All changes succeeded")
+	assert.Equal(t, int32(3), maxRunning, "Changes were executed concurrently, up to the limit")
+	assert.Nil(t, result.Err(), "Error is not returned")
+	assert.True(t, result.Changes[0].Duration > 0, "Change duration is measured")
+}
@@ -0,0 +1,218 @@
+package ne
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	//DefaultWaitPollInterval is default initial delay between consecutive status checks
+	DefaultWaitPollInterval = 10 * time.Second
+	//DefaultWaitMaxPollInterval is default upper limit of delay between consecutive status checks
+	DefaultWaitMaxPollInterval = time.Minute
+	//DefaultWaitBackoffMultiplier is default factor by which delay between status checks grows
+	DefaultWaitBackoffMultiplier = 1.5
+	//DefaultWaitTimeout is default limit of total waiting time
+	DefaultWaitTimeout = 60 * time.Minute
+)
+
+// WaitOptions describes configuration of operations that wait for
+// Network Edge resource to reach given state. Zero values are replaced
+// with defaults
+type WaitOptions struct {
+	//PollInterval is initial delay between consecutive status checks
+	PollInterval time.Duration
+	//MaxPollInterval is upper limit of delay between consecutive status checks
+	MaxPollInterval time.Duration
+	//BackoffMultiplier is a factor by which delay grows after each status check.
+	//Values lower than one are replaced with default
+	BackoffMultiplier float64
+	//Timeout limits total waiting time
+	Timeout time.Duration
+	//OnProgress, when set, is called after each status check
+	OnProgress func(progress WaitProgress)
+}
+
+// WaitProgress describes single status check performed while waiting
+// for a Network Edge resource
+type WaitProgress struct {
+	//Attempt is a number of a status check, starting from one
+	Attempt int
+	//Elapsed is time elapsed since waiting has started
+	Elapsed time.Duration
+	//Status is observed status of a resource
+	Status string
+	//Resource is observed resource, i.e. *Device
+	Resource interface{}
+}
+
+// WaitTimeoutError describes situation when Network Edge resource did not
+// reach expected state before wait timeout or context deadline
+type WaitTimeoutError struct {
+	//ResourceType describes type of a resource, i.e. device
+	ResourceType string
+	//ResourceID is an identifier of a resource
+	ResourceID string
+	//LastStatus is last observed status of a resource
+	LastStatus string
+	//Elapsed is total waiting time
+	Elapsed time.Duration
+	//Cause is context error that interrupted waiting
+	Cause error
+}
+
+func (e WaitTimeoutError) Error() string {
+	return fmt.Sprintf("timeout after %s while waiting for %s %q, last observed status: %q", e.Elapsed.Round(time.Millisecond), e.ResourceType, e.ResourceID, e.LastStatus)
+}
+
+// Unwrap returns context error that interrupted waiting
+func (e WaitTimeoutError) Unwrap() error {
+	return e.Cause
+}
+
+// ResourceStatusError describes situation when Network Edge resource reached
+// a status from which it will not get to any of expected statuses
+type ResourceStatusError struct {
+	//ResourceType describes type of a resource, i.e. BGP configuration
+	ResourceType string
+	//ResourceID is an identifier of a resource
+	ResourceID string
+	//Status is observed status of a resource
+	Status string
+	//ExpectedStatuses are statuses that resource was expected to reach
+	ExpectedStatuses []string
+}
+
+func (e ResourceStatusError) Error() string {
+	return fmt.Sprintf("%s %q reached status %q, expected statuses: %s",
+		e.ResourceType, e.ResourceID, e.Status, strings.Join(e.ExpectedStatuses, ", "))
+}
+
+//‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾‾
+// Unexported package methods
+//_______________________________________________________________________
+
+//waitCheck performs single status check of a resource. It returns observed
+//status and resource, information if waiting is finished and an error that
+//stops waiting
+type waitCheck func(ctx context.Context) (status string, resource interface{}, done bool, err error)
+
+func (o *WaitOptions) withDefaults() WaitOptions {
+	opts := WaitOptions{}
+	if o != nil {
+		opts = *o
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultWaitPollInterval
+	}
+	if opts.MaxPollInterval <= 0 {
+		opts.MaxPollInterval = DefaultWaitMaxPollInterval
+	}
+	if opts.MaxPollInterval < opts.PollInterval {
+		opts.MaxPollInterval = opts.PollInterval
+	}
+	if opts.BackoffMultiplier < 1 {
+		opts.BackoffMultiplier = DefaultWaitBackoffMultiplier
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultWaitTimeout
+	}
+	return opts
+}
+
+//waitFor runs given check until it reports that waiting is finished or returns an error.
+//Delay between checks grows exponentially according to given options
+func waitFor(ctx context.Context, resourceType string, resourceID string, o *WaitOptions, check waitCheck) error {
+	opts := o.withDefaults()
+	waitCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+	start := time.Now()
+	interval := opts.PollInterval
+	lastStatus := ""
+	for attempt := 1; ; attempt++ {
+		status, resource, done, err := check(waitCtx)
+		if err != nil && waitCtx.Err() != nil {
+			return waitContextError(ctx, waitCtx, resourceType, resourceID, lastStatus, time.Since(start))
+		}
+		if resource != nil {
+			lastStatus = status
+			if opts.OnProgress != nil {
+				opts.OnProgress(WaitProgress{
+					Attempt:  attempt,
+					Elapsed:  time.Since(start),
+					Status:   status,
+					Resource: resource,
+				})
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-waitCtx.Done():
+			timer.Stop()
+			return waitContextError(ctx, waitCtx, resourceType, resourceID, lastStatus, time.Since(start))
+		case <-timer.C:
+		}
+		interval = time.Duration(float64(interval) * opts.BackoffMultiplier)
+		if interval > opts.MaxPollInterval {
+			interval = opts.MaxPollInterval
+		}
+	}
+}
+
+//waitContextError returns WaitTimeoutError when waiting was interrupted by a deadline
+//or parent context error when waiting was cancelled
+func waitContextError(parent context.Context, waitCtx context.Context, resourceType string, resourceID string, lastStatus string, elapsed time.Duration) error {
+	if parent.Err() == context.Canceled {
+		return parent.Err()
+	}
+	return WaitTimeoutError{
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		LastStatus:   lastStatus,
+		Elapsed:      elapsed,
+		Cause:        waitCtx.Err(),
+	}
+}
+
+//statusFetch retrieves resource and its status
+type statusFetch func(ctx context.Context) (status string, resource interface{}, err error)
+
+//waitForStatus polls resource status until it is one of target statuses. Waiting stops
+//with ResourceStatusError when resource reaches one of failure statuses that is not a target
+func waitForStatus(ctx context.Context, resourceType string, resourceID string, opts *WaitOptions, targetStatuses []string, failureStatuses []string, fetch statusFetch) error {
+	return waitFor(ctx, resourceType, resourceID, opts, func(ctx context.Context) (string, interface{}, bool, error) {
+		status, resource, err := fetch(ctx)
+		if err != nil {
+			return "", nil, false, err
+		}
+		if containsString(targetStatuses, status) {
+			return status, resource, true, nil
+		}
+		if containsString(failureStatuses, status) {
+			return status, resource, false, ResourceStatusError{
+				ResourceType:     resourceType,
+				ResourceID:       resourceID,
+				Status:           status,
+				ExpectedStatuses: targetStatuses,
+			}
+		}
+		return status, resource, false, nil
+	})
+}
+
+func containsString(values []string, value string) bool {
+	for i := range values {
+		if values[i] == value {
+			return true
+		}
+	}
+	return false
+}